@@ -0,0 +1,61 @@
+// cmd/copy.go
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/VallfIK/bazaotdx/internal/config"
+	"github.com/VallfIK/bazaotdx/internal/db"
+)
+
+// runCopyToPostgres выполняет подкоманду "copy-to-postgres": переносит
+// данные из файла SQLite (db-sqlite-path) в PostgreSQL (db-host, db-name...)
+// при переходе с одной машины на общий сервер БД
+func runCopyToPostgres(cfg *config.Config, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("использование: copy-to-postgres (источник — db-sqlite-path, приемник — параметры PostgreSQL)")
+	}
+
+	srcCfg := cfg.Database
+	srcCfg.Driver = config.DriverSQLite
+	src, err := db.NewSQLiteDB(srcCfg)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dstCfg := cfg.Database
+	dstCfg.Driver = config.DriverPostgres
+	dst, err := db.NewPostgresDB(dstCfg)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	// Обе базы приводим к последней версии схемы
+	srcMigrator, err := db.NewSQLiteMigrator(src.DB)
+	if err != nil {
+		return err
+	}
+	if _, err := srcMigrator.Up(); err != nil {
+		return fmt.Errorf("миграции SQLite: %w", err)
+	}
+	dstMigrator, err := db.NewMigrator(dst.DB)
+	if err != nil {
+		return err
+	}
+	if _, err := dstMigrator.Up(); err != nil {
+		return fmt.Errorf("миграции PostgreSQL: %w", err)
+	}
+
+	copied, err := db.CopySQLiteToPostgres(src.DB, dst.DB)
+	if err != nil {
+		return err
+	}
+	for _, c := range copied {
+		log.Printf("✅ %s: перенесено строк %d", c.Table, c.Rows)
+	}
+	log.Printf("🎉 Данные из %s перенесены в PostgreSQL", srcCfg.SQLitePath)
+	return nil
+}
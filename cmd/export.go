@@ -0,0 +1,126 @@
+// cmd/export.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/config"
+	"github.com/VallfIK/bazaotdx/internal/export"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+// exportUsage — подсказка по подкоманде export
+const exportUsage = "использование: export bookings|guests|tariffs|occupancy|revenue " +
+	"[-from ГГГГ-ММ-ДД] [-to ГГГГ-ММ-ДД] [-status статус] -o файл.csv|файл.xlsx"
+
+// runExport выполняет подкоманду "export": выгружает брони, гостей,
+// тарифы или отчет за период (по умолчанию — текущий месяц) в CSV или
+// Excel — формат выбирается по расширению файла
+func runExport(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(exportUsage)
+	}
+	kind := args[0]
+
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fromFlag := fs.String("from", monthStart.Format("2006-01-02"), "первый день периода")
+	toFlag := fs.String("to", monthStart.AddDate(0, 1, -1).Format("2006-01-02"), "последний день периода")
+	status := fs.String("status", "", "статус броней (booked, checked_in, completed, cancelled); без него — все брони периода")
+	output := fs.String("o", "", "файл .csv или .xlsx")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *output == "" || fs.NArg() > 0 {
+		return fmt.Errorf(exportUsage)
+	}
+	if ext := strings.ToLower(filepath.Ext(*output)); ext != ".csv" && ext != ".xlsx" {
+		return fmt.Errorf("выгрузка сохраняется в .csv или .xlsx, а не %q", filepath.Ext(*output))
+	}
+	from, err := time.ParseInLocation("2006-01-02", *fromFlag, time.Local)
+	if err != nil {
+		return fmt.Errorf("неверная дата начала %q", *fromFlag)
+	}
+	to, err := time.ParseInLocation("2006-01-02", *toFlag, time.Local)
+	if err != nil {
+		return fmt.Errorf("неверная дата конца %q", *toFlag)
+	}
+
+	database, err := openDatabase(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	store := newStore(cfg.Database, database)
+	stay := models.StayPolicy{CheckInHour: cfg.Stay.CheckInHour, CheckOutHour: cfg.Stay.CheckOutHour}
+	cottageService := service.NewCottageService(store)
+	tariffService := service.NewTariffService(store)
+
+	var tables []export.Table
+	switch kind {
+	case "bookings", "guests":
+		cottages, err := cottageService.GetAllCottages()
+		if err != nil {
+			return err
+		}
+		tariffs, err := tariffService.GetTariffs()
+		if err != nil {
+			return err
+		}
+		if kind == "bookings" {
+			bookings, err := service.NewBookingService(store, stay).GetBookingsForPeriod(from, to, *status)
+			if err != nil {
+				return err
+			}
+			tables = append(tables, export.BookingsTable(bookings, cottages, tariffs))
+		} else {
+			guests, err := service.NewGuestService(store, cfg.Paths.DocumentsRoot, stay).GetGuests()
+			if err != nil {
+				return err
+			}
+			tables = append(tables, export.GuestsTable(guests, cottages, tariffs))
+		}
+
+	case "tariffs":
+		tariffs, err := tariffService.GetTariffs()
+		if err != nil {
+			return err
+		}
+		tables = append(tables, export.TariffsTable(tariffs))
+
+	case "occupancy":
+		report, err := service.NewReportService(store, stay).Occupancy(from, to)
+		if err != nil {
+			return err
+		}
+		tables = export.OccupancyTables(report)
+
+	case "revenue":
+		report, err := service.NewReportService(store, stay).Revenue(from, to)
+		if err != nil {
+			return err
+		}
+		tables = export.RevenueTables(report)
+
+	default:
+		return fmt.Errorf("неизвестная выгрузка %q: %s", kind, exportUsage)
+	}
+
+	if err := export.Save(*output, tables...); err != nil {
+		return err
+	}
+	rows := 0
+	for _, t := range tables {
+		rows += len(t.Rows)
+	}
+	log.Printf("✅ Выгружено строк: %d → %s", rows, *output)
+	return nil
+}
@@ -2,7 +2,7 @@
 package main
 
 import (
-	"database/sql"
+	"fmt"
 	"io"
 	"log"
 	"os"
@@ -10,12 +10,26 @@ import (
 	"time"
 
 	"github.com/VallfIK/bazaotdx/internal/app"
-	"github.com/VallfIK/bazaotdx/internal/db"
+	"github.com/VallfIK/bazaotdx/internal/config"
 	"github.com/VallfIK/bazaotdx/internal/models"
 	"github.com/VallfIK/bazaotdx/internal/service"
 )
 
 func main() {
+	// Загрузка конфигурации: файл, переменные окружения BAZA_*, флаги
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("❌ Ошибка загрузки конфигурации: %v", err)
+	}
+
+	// Подкоманды выполняются без запуска интерфейса
+	if len(args) > 0 {
+		if err := runCommand(cfg, args); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		return
+	}
+
 	// Настройка логирования
 	logFile, err := os.OpenFile("app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	if err != nil {
@@ -33,8 +47,7 @@ func main() {
 	log.Printf("🏞️ База отдыха: Звуки Леса")
 
 	// Проверяем существование файлов изображений
-	projectPath := "C:\\Users\\VallfIK\\Documents\\GitHub\\bazaotdx"
-	imagesPath := filepath.Join(projectPath, "images")
+	imagesPath := cfg.Paths.ResolveImagesPath()
 	log.Printf("🔍 Проверка директории images: %s", imagesPath)
 
 	images := []string{
@@ -60,28 +73,44 @@ func main() {
 	}
 
 	// Инициализация БД
-	database, err := db.NewPostgresDB()
+	database, err := openDatabase(cfg.Database)
 	if err != nil {
 		log.Fatalf("❌ Ошибка подключения к БД: %v", err)
 	} else {
-		log.Println("✅ Успешное подключение к БД")
+		log.Printf("✅ Успешное подключение к БД (%s)", cfg.Database.Driver)
 	}
 	defer database.Close()
 
-	// Конфигурация
-	documentsRoot := "documents"
+	if cfg.Database.AutoMigrate {
+		migrator, err := newMigrator(cfg.Database, database)
+		if err != nil {
+			log.Fatalf("❌ Ошибка загрузки миграций: %v", err)
+		}
+		applied, err := migrator.Up()
+		if err != nil {
+			log.Fatalf("❌ Ошибка применения миграций: %v", err)
+		}
+		if applied > 0 {
+			log.Printf("✅ Применено миграций схемы: %d", applied)
+		}
+	}
 
 	// Инициализация сервисов
-	guestService := service.NewGuestService(database.DB, documentsRoot)
-	cottageService := service.NewCottageService(database.DB)
-	tariffService := service.NewTariffService(database.DB)
-	bookingService := service.NewBookingService(database.DB)
+	store := newStore(cfg.Database, database)
+	stay := models.StayPolicy{CheckInHour: cfg.Stay.CheckInHour, CheckOutHour: cfg.Stay.CheckOutHour}
+	guestService := service.NewGuestService(store, cfg.Paths.DocumentsRoot, stay)
+	cottageService := service.NewCottageService(store)
+	tariffService := service.NewTariffService(store)
+	bookingService := service.NewBookingService(store, stay)
+	invoiceService := service.NewInvoiceService(store, stay, cfg.Paths.DocumentsRoot, models.LegalDetails(cfg.Invoice))
+	reportService := service.NewReportService(store, stay)
 
 	// Создание улучшенного приложения "Звуки Леса"
-	app := app.NewStyledGuestApp(guestService, cottageService, tariffService, bookingService)
+	app := app.NewStyledGuestApp(guestService, cottageService, tariffService, bookingService, invoiceService, reportService, imagesPath)
 
 	// Запускаем фоновые задачи
-	go backgroundTasks(database.DB, bookingService)
+	go backgroundTasks(bookingService, guestService)
+	go holdSweeper(bookingService)
 
 	log.Println("🌲 Запуск системы управления 'Звуки Леса'...")
 	log.Println("🎯 Особенности новой версии:")
@@ -95,6 +124,20 @@ func main() {
 	app.Run()
 }
 
+// runCommand выполняет подкоманду командной строки
+func runCommand(cfg *config.Config, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrate(cfg, args[1:])
+	case "copy-to-postgres":
+		return runCopyToPostgres(cfg, args[1:])
+	case "export":
+		return runExport(cfg, args[1:])
+	default:
+		return fmt.Errorf("неизвестная команда %q", args[0])
+	}
+}
+
 // createMissingImage создает простое изображение-заглушку
 func createMissingImage(path string) {
 	// Создаем директорию если не существует
@@ -112,91 +155,44 @@ func createMissingImage(path string) {
 }
 
 // backgroundTasks выполняет фоновые задачи
-func backgroundTasks(db *sql.DB, bookingService *service.BookingService) {
+func backgroundTasks(bookingService *service.BookingService, guestService *service.GuestService) {
 	log.Println("🔄 Запуск фоновых задач для 'Звуки Леса'...")
 
 	for {
-		// Автоматическое удаление старых отмененных и завершенных бронирований
-		result, err := db.Exec(`
-			DELETE FROM lesbaza.bookings 
-			WHERE status IN ($1, $2) AND created_at <= NOW() - INTERVAL '30 days'
-		`, models.BookingStatusCancelled, models.BookingStatusCompleted)
-		if err != nil {
-			log.Printf("⚠️ Ошибка автоудаления старых броней: %v", err)
-		} else {
-			if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
-				log.Printf("🗑️ Автоматически удалено %d старых бронирований", rowsAffected)
-			}
+		// Автоматическое удаление снятых удержаний без оплат, услуг и счетов через 30 дней после выезда
+		if deleted, err := bookingService.PurgeReleasedHolds(30 * 24 * time.Hour); err != nil {
+			log.Printf("⚠️ Ошибка автоудаления снятых удержаний: %v", err)
+		} else if deleted > 0 {
+			log.Printf("🗑️ Автоматически удалено %d снятых удержаний", deleted)
 		}
 
 		// Автоматическое выселение гостей
-		result, err = db.Exec(`
-			DELETE FROM lesbaza.guests 
-			WHERE check_out_date <= NOW() - INTERVAL '2 hours'
-		`)
-		if err != nil {
+		if evicted, err := guestService.EvictCheckedOutGuests(2 * time.Hour); err != nil {
 			log.Printf("⚠️ Ошибка автовыселения: %v", err)
-		} else {
-			if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
-				log.Printf("🚪 Автоматически выселено %d гостей", rowsAffected)
-			}
+		} else if evicted > 0 {
+			log.Printf("🚪 Автоматически выселено %d гостей", evicted)
 		}
 
 		// Автоматическое обновление статусов бронирований
-		rows, err := db.Query(`
-			SELECT booking_id 
-			FROM lesbaza.bookings 
-			WHERE status = $1 
-			AND check_in_date::date = CURRENT_DATE
-			AND CURRENT_TIME > TIME '14:00'`,
-			models.BookingStatusBooked,
-		)
-		if err == nil {
-			var bookingIDs []int
-			for rows.Next() {
-				var id int
-				if rows.Scan(&id) == nil {
-					bookingIDs = append(bookingIDs, id)
-				}
-			}
-			rows.Close()
-
-			// Обновляем статусы
-			for _, id := range bookingIDs {
-				err := bookingService.CheckInBooking(id)
+		if bookings, err := bookingService.GetBookingsDueForCheckIn(time.Now()); err == nil {
+			for _, booking := range bookings {
+				err := bookingService.CheckInBooking(booking.ID)
 				if err != nil {
-					log.Printf("⚠️ Ошибка автозаселения брони %d: %v", id, err)
+					log.Printf("⚠️ Ошибка автозаселения брони %d: %v", booking.ID, err)
 				} else {
-					log.Printf("✅ Автоматически заселена бронь %d", id)
+					log.Printf("✅ Автоматически заселена бронь %d", booking.ID)
 				}
 			}
 		}
 
 		// Автоматическое завершение просроченных заселенных бронирований
-		rows, err = db.Query(`
-			SELECT booking_id 
-			FROM lesbaza.bookings 
-			WHERE status = $1 
-			AND check_out_date::date < CURRENT_DATE`,
-			models.BookingStatusCheckedIn,
-		)
-		if err == nil {
-			var bookingIDs []int
-			for rows.Next() {
-				var id int
-				if rows.Scan(&id) == nil {
-					bookingIDs = append(bookingIDs, id)
-				}
-			}
-			rows.Close()
-
-			// Выселяем просроченные брони
-			for _, id := range bookingIDs {
-				err := bookingService.CheckOutBooking(id)
+		if bookings, err := bookingService.GetOverdueCheckedInBookings(time.Now()); err == nil {
+			for _, booking := range bookings {
+				err := bookingService.CheckOutBooking(booking.ID)
 				if err != nil {
-					log.Printf("⚠️ Ошибка автовыселения брони %d: %v", id, err)
+					log.Printf("⚠️ Ошибка автовыселения брони %d: %v", booking.ID, err)
 				} else {
-					log.Printf("✅ Автоматически выселена просроченная бронь %d", id)
+					log.Printf("✅ Автоматически выселена просроченная бронь %d", booking.ID)
 				}
 			}
 		}
@@ -211,3 +207,20 @@ func backgroundTasks(db *sql.DB, bookingService *service.BookingService) {
 		time.Sleep(1 * time.Hour)
 	}
 }
+
+// holdSweeper ежеминутно снимает истекшие временные удержания домиков.
+// Удержания живут минуты, поэтому не ждут часового цикла backgroundTasks.
+func holdSweeper(bookingService *service.BookingService) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		released, err := bookingService.ReleaseExpiredHolds(time.Now())
+		if err != nil {
+			log.Printf("⚠️ Ошибка снятия истекших удержаний: %v", err)
+		}
+		if released > 0 {
+			log.Printf("⏳ Снято истекших удержаний: %d", released)
+		}
+	}
+}
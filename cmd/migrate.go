@@ -0,0 +1,69 @@
+// cmd/migrate.go
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/VallfIK/bazaotdx/internal/config"
+)
+
+// runMigrate выполняет подкоманду "migrate up|down [N]|status"
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("использование: migrate up | down [N] | status")
+	}
+
+	database, err := openDatabase(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	migrator, err := newMigrator(cfg.Database, database)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		if err != nil {
+			return err
+		}
+		log.Printf("✅ Применено миграций: %d", applied)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("неверное число шагов отката: %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(steps)
+		if err != nil {
+			return err
+		}
+		log.Printf("↩️ Откачено миграций: %d", reverted)
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			if s.Applied {
+				log.Printf("✅ %04d %s (применена %s)", s.Version, s.Name, s.AppliedAt.Local().Format("02.01.2006 15:04"))
+			} else {
+				log.Printf("⏳ %04d %s (не применена)", s.Version, s.Name)
+			}
+		}
+
+	default:
+		return fmt.Errorf("неизвестная команда migrate %q", args[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// cmd/storage.go
+package main
+
+import (
+	"database/sql"
+
+	"github.com/VallfIK/bazaotdx/internal/config"
+	"github.com/VallfIK/bazaotdx/internal/db"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+	"github.com/VallfIK/bazaotdx/internal/repository/postgres"
+	"github.com/VallfIK/bazaotdx/internal/repository/sqlite"
+)
+
+// openDatabase подключается к хранилищу, выбранному в конфигурации
+func openDatabase(cfg config.DatabaseConfig) (*sql.DB, error) {
+	if cfg.Driver == config.DriverSQLite {
+		database, err := db.NewSQLiteDB(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return database.DB, nil
+	}
+
+	database, err := db.NewPostgresDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return database.DB, nil
+}
+
+// newMigrator возвращает мигратор для выбранного хранилища
+func newMigrator(cfg config.DatabaseConfig, database *sql.DB) (*db.Migrator, error) {
+	if cfg.Driver == config.DriverSQLite {
+		return db.NewSQLiteMigrator(database)
+	}
+	return db.NewMigrator(database)
+}
+
+// newStore возвращает репозитории для выбранного хранилища
+func newStore(cfg config.DatabaseConfig, database *sql.DB) repository.Store {
+	if cfg.Driver == config.DriverSQLite {
+		return sqlite.NewStore(database)
+	}
+	return postgres.NewStore(database)
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"image/color"
 	"log"
@@ -28,6 +29,8 @@ type StyledGuestApp struct {
 	cottageService        *service.CottageService
 	tariffService         *service.TariffService
 	bookingService        *service.BookingService
+	invoiceService        *service.InvoiceService
+	reportService         *service.ReportService
 	updateCottagesContent func()
 	cottages              []models.Cottage
 	calendarWidget        *ui.BookingCalendar
@@ -35,6 +38,7 @@ type StyledGuestApp struct {
 	topBar                fyne.CanvasObject
 	sidePanel             fyne.CanvasObject
 	mainContent           fyne.CanvasObject
+	imagesPath            string
 }
 
 func NewStyledGuestApp(
@@ -42,6 +46,9 @@ func NewStyledGuestApp(
 	cottageService *service.CottageService,
 	tariffService *service.TariffService,
 	bookingService *service.BookingService,
+	invoiceService *service.InvoiceService,
+	reportService *service.ReportService,
+	imagesPath string,
 ) *StyledGuestApp {
 	a := app.New()
 
@@ -63,6 +70,9 @@ func NewStyledGuestApp(
 		cottageService: cottageService,
 		tariffService:  tariffService,
 		bookingService: bookingService,
+		invoiceService: invoiceService,
+		reportService:  reportService,
+		imagesPath:     imagesPath,
 	}
 
 	// Initialize widgets
@@ -70,11 +80,14 @@ func NewStyledGuestApp(
 		app.bookingService,
 		app.cottageService,
 		app.tariffService,
+		app.invoiceService,
 		app.window,
+		app.imagesPath,
 	)
 	app.bookingListWidget = ui.NewBookingListWidget(
 		app.bookingService,
 		app.cottageService,
+		app.tariffService,
 		app.window,
 	)
 
@@ -105,13 +118,16 @@ func (a *StyledGuestApp) createStyledUI() {
 		a.bookingService,
 		a.cottageService,
 		a.tariffService,
+		a.invoiceService,
 		a.window,
+		a.imagesPath,
 	)
 
 	// Создаем виджет списка бронирований
 	bookingListWidget := ui.NewBookingListWidget(
 		a.bookingService,
 		a.cottageService,
+		a.tariffService,
 		a.window,
 	)
 
@@ -247,9 +263,15 @@ func (a *StyledGuestApp) createSidePanel() fyne.CanvasObject {
 	})
 	upcomingBtn.Resize(fyne.NewSize(260, 40))
 
+	reportsBtn := widget.NewButtonWithIcon("📊 Отчеты", theme.DocumentIcon(), func() {
+		a.showReportsDialog()
+	})
+	reportsBtn.Resize(fyne.NewSize(260, 40))
+
 	quickActions := container.NewVBox(
 		quickBookingBtn,
 		upcomingBtn,
+		reportsBtn,
 	)
 
 	// Основной контент боковой панели
@@ -475,6 +497,7 @@ func (a *StyledGuestApp) createTariffsTab() *container.TabItem {
 				widget.NewLabel("Название"),
 				widget.NewLabel("Цена"),
 				widget.NewButton("Изменить", nil),
+				widget.NewButton("Цены", nil),
 				widget.NewButton("Удалить", nil),
 			)
 		},
@@ -488,15 +511,20 @@ func (a *StyledGuestApp) createTariffsTab() *container.TabItem {
 			nameLabel := hbox.Objects[0].(*widget.Label)
 			priceLabel := hbox.Objects[1].(*widget.Label)
 			editBtn := hbox.Objects[2].(*widget.Button)
-			deleteBtn := hbox.Objects[3].(*widget.Button)
+			pricesBtn := hbox.Objects[3].(*widget.Button)
+			deleteBtn := hbox.Objects[4].(*widget.Button)
 
 			nameLabel.SetText(tariff.Name)
-			priceLabel.SetText(fmt.Sprintf("%.2f ₽/день", tariff.PricePerDay))
+			priceLabel.SetText(fmt.Sprintf("%s/день", tariff.PricePerDay))
 
 			editBtn.OnTapped = func() {
 				a.showEditTariffDialogFixed(tariff)
 			}
 
+			pricesBtn.OnTapped = func() {
+				ui.ShowTariffPricingDialog(a.tariffService, a.cottages, tariff, a.window)
+			}
+
 			deleteBtn.OnTapped = func() {
 				dialog.ShowConfirm("Подтверждение",
 					fmt.Sprintf("Удалить тариф '%s'?", tariff.Name),
@@ -539,7 +567,7 @@ func (a *StyledGuestApp) createTariffsTab() *container.TabItem {
 			return
 		}
 
-		price, err := strconv.ParseFloat(priceEntry.Text, 64)
+		price, err := models.ParseMoney(priceEntry.Text)
 		if err != nil {
 			dialog.ShowError(fmt.Errorf("неверный формат цены"), a.window)
 			return
@@ -570,6 +598,26 @@ func (a *StyledGuestApp) createTariffsTab() *container.TabItem {
 	})
 	refreshBtn.Resize(fyne.NewSize(150, 40))
 
+	holidaysBtn := widget.NewButton("🎉 Праздники", func() {
+		ui.ShowHolidaysDialog(a.tariffService, a.window)
+	})
+
+	discountsBtn := widget.NewButton("🏷 Промокоды", func() {
+		ui.ShowDiscountsDialog(a.tariffService, a.window)
+	})
+
+	policiesBtn := widget.NewButton("📜 Правила отмены", func() {
+		ui.ShowCancellationPoliciesDialog(a.tariffService, a.window)
+	})
+
+	extrasBtn := widget.NewButton("🛎 Услуги", func() {
+		ui.ShowExtrasDialog(a.tariffService, a.window)
+	})
+
+	restrictionsBtn := widget.NewButton("⛔ Ограничения сроков", func() {
+		ui.ShowRestrictionsDialog(a.bookingService, a.cottages, a.window, a.calendarWidget.Update)
+	})
+
 	// Изначально загружаем тарифы
 	updateTariffList()
 
@@ -581,7 +629,7 @@ func (a *StyledGuestApp) createTariffsTab() *container.TabItem {
 		container.NewVBox(
 			searchEntry,
 			widget.NewCard("➕ Добавить новый тариф", "", container.NewVBox(addForm, addBtn)),
-			container.NewHBox(refreshBtn, statsLabel),
+			container.NewHBox(refreshBtn, holidaysBtn, discountsBtn, policiesBtn, extrasBtn, restrictionsBtn, statsLabel),
 			widget.NewSeparator(),
 		),
 		nil, nil, nil,
@@ -606,17 +654,53 @@ func (a *StyledGuestApp) updateStats(label *widget.RichText) {
 		return
 	}
 
-	totalBookings := len(bookings)
-	var totalRevenue float64
+	totalBookings := 0
 	activeBookings := 0
 
 	for _, booking := range bookings {
+		// Блокировки на обслуживание — не брони и выручки не дают
+		if booking.Status == models.BookingStatusBlocked {
+			continue
+		}
+		totalBookings++
 		if booking.Status != models.BookingStatusCancelled {
-			totalRevenue += booking.TotalCost
 			activeBookings++
 		}
 	}
 
+	// Начислено — только за ночи и услуги этого месяца: бронь на стыке
+	// месяцев не учитывается целиком в обоих
+	revenue, err := a.reportService.Revenue(startOfMonth, endOfMonth)
+	if err != nil {
+		fyne.Do(func() {
+			label.ParseMarkdown("❌ Ошибка загрузки статистики")
+		})
+		return
+	}
+	extras := revenue.Total.Extras
+	accrued := revenue.Total.Revenue.Add(extras)
+
+	// Доход — деньги, фактически полученные за месяц, за вычетом возвратов
+	payments, err := a.bookingService.GetPaymentsBetween(startOfMonth, startOfMonth.AddDate(0, 1, 0).Add(-time.Nanosecond))
+	if err != nil {
+		fyne.Do(func() {
+			label.ParseMarkdown("❌ Ошибка загрузки статистики")
+		})
+		return
+	}
+	totalRevenue := models.NewMoney(0)
+	paidBookings := make(map[int]bool)
+	for _, p := range payments {
+		// Штраф — долг гостя, а не полученные деньги
+		if p.IsPenalty() {
+			continue
+		}
+		totalRevenue = totalRevenue.Add(p.Amount)
+		if !p.IsRefund() {
+			paidBookings[p.BookingID] = true
+		}
+	}
+
 	// Получаем статистику по домикам
 	cottages, _ := a.cottageService.GetAllCottages()
 	freeCottages := 0
@@ -629,10 +713,7 @@ func (a *StyledGuestApp) updateStats(label *widget.RichText) {
 		}
 	}
 
-	avgCheck := 0.0
-	if activeBookings > 0 {
-		avgCheck = totalRevenue / float64(activeBookings)
-	}
+	avgCheck := totalRevenue.Div(len(paidBookings))
 
 	statsText := fmt.Sprintf(`## 📊 %s %d
 
@@ -646,12 +727,14 @@ func (a *StyledGuestApp) updateStats(label *widget.RichText) {
 • Активных: %d
 
 **💰 Доходы:**
-• Общий: **%.0f ₽**
-• Средний чек: **%.0f ₽**`,
+• Получено: **%s**
+• Средний чек: **%s**
+• Начислено за месяц: %s
+• В т.ч. услуги: %s`,
 		a.getMonthName(now.Month()), now.Year(),
 		len(cottages), freeCottages, occupiedCottages,
 		totalBookings, activeBookings,
-		totalRevenue, avgCheck)
+		totalRevenue, avgCheck, accrued, extras)
 
 	fyne.Do(func() {
 		label.ParseMarkdown(statsText)
@@ -669,11 +752,11 @@ func (a *StyledGuestApp) getMonthName(month time.Month) string {
 }
 
 func (a *StyledGuestApp) generateOccupancyReport() {
-	dialog.ShowInformation("🔧 В разработке", "Отчет о заполняемости будет реализован в следующей версии", a.window)
+	ui.ShowOccupancyReportDialog(a.reportService, a.window)
 }
 
 func (a *StyledGuestApp) generateRevenueReport() {
-	dialog.ShowInformation("🔧 В разработке", "Отчет о доходах будет реализован в следующей версии", a.window)
+	ui.ShowRevenueReportDialog(a.reportService, a.window)
 }
 
 func (a *StyledGuestApp) showUpcomingArrivals() {
@@ -704,7 +787,7 @@ func (a *StyledGuestApp) showUpcomingArrivals() {
 			fmt.Sprintf("📅 Заезд: %s", booking.CheckInDate.Format("02.01.2006")),
 			container.NewVBox(
 				widget.NewLabel(fmt.Sprintf("📞 %s", booking.Phone)),
-				widget.NewLabel(fmt.Sprintf("💰 %.0f ₽", booking.TotalCost)),
+				widget.NewLabel(fmt.Sprintf("💰 %s", booking.Total())),
 			),
 		)
 		content.Add(card)
@@ -718,120 +801,25 @@ func (a *StyledGuestApp) showUpcomingArrivals() {
 	d.Show()
 }
 
-// showQuickBookingDialog показывает диалог быстрого бронирования (УНИКАЛЬНАЯ ВЕРСИЯ)
+// showQuickBookingDialogFromSidePanel показывает диалог быстрого бронирования
+// с боковой панели
 func (a *StyledGuestApp) showQuickBookingDialogFromSidePanel() {
-	// Получаем список свободных домиков
-	cottages, err := a.cottageService.GetFreeCottages()
-	if err != nil {
-		dialog.ShowError(err, a.window)
-		return
-	}
-
-	if len(cottages) == 0 {
-		dialog.ShowInformation("ℹ️ Информация", "Нет свободных домиков", a.window)
-		return
-	}
-
-	// Получаем тарифы
-	tariffs, err := a.tariffService.GetTariffs()
-	if err != nil {
-		dialog.ShowError(err, a.window)
-		return
-	}
-
-	// Создаем форму
-	nameEntry := ui.StyledEntry("ФИО гостя")
-	phoneEntry := ui.StyledEntry("+7 (999) 123-45-67")
-	emailEntry := ui.StyledEntry("email@example.com")
-
-	cottageOptions := make([]string, len(cottages))
-	for i, c := range cottages {
-		cottageOptions[i] = fmt.Sprintf("🏠 %s", c.Name)
-	}
-	cottageSelect := widget.NewSelect(cottageOptions, nil)
-
-	tariffOptions := make([]string, len(tariffs))
-	for i, t := range tariffs {
-		tariffOptions[i] = fmt.Sprintf("💰 %s - %.0f ₽/сутки", t.Name, t.PricePerDay)
-	}
-	tariffSelect := widget.NewSelect(tariffOptions, nil)
-
-	// Даты
-	checkInDate := time.Now().Add(24 * time.Hour)
-	checkOutDate := checkInDate.Add(24 * time.Hour)
-
-	checkInPicker := ui.NewDatePickerButton("📅 Дата заезда", a.window, func(t time.Time) {
-		checkInDate = t
-	})
-	checkInPicker.SetSelectedDate(checkInDate)
-
-	checkOutPicker := ui.NewDatePickerButton("📅 Дата выезда", a.window, func(t time.Time) {
-		checkOutDate = t
-	})
-	checkOutPicker.SetSelectedDate(checkOutDate)
-
-	notesEntry := widget.NewMultiLineEntry()
-	notesEntry.SetPlaceHolder("📝 Дополнительные примечания...")
-	notesEntry.SetMinRowsVisible(3)
-
-	form := &widget.Form{
-		Items: []*widget.FormItem{
-			{Text: "👤 ФИО гостя", Widget: nameEntry},
-			{Text: "📞 Телефон", Widget: phoneEntry},
-			{Text: "📧 Email", Widget: emailEntry},
-			{Text: "🏠 Домик", Widget: cottageSelect},
-			{Text: "💰 Тариф", Widget: tariffSelect},
-			{Text: "📅 Дата заезда", Widget: checkInPicker},
-			{Text: "📅 Дата выезда", Widget: checkOutPicker},
-			{Text: "📝 Примечания", Widget: notesEntry},
-		},
-		OnSubmit: func() {
-			// Валидация
-			if nameEntry.Text == "" || phoneEntry.Text == "" ||
-				cottageSelect.SelectedIndex() < 0 || tariffSelect.SelectedIndex() < 0 {
-				dialog.ShowError(fmt.Errorf("Заполните все обязательные поля"), a.window)
-				return
-			}
-
-			// Создаем бронирование
-			booking := models.Booking{
-				CottageID:    cottages[cottageSelect.SelectedIndex()].ID,
-				GuestName:    nameEntry.Text,
-				Phone:        phoneEntry.Text,
-				Email:        emailEntry.Text,
-				CheckInDate:  checkInPicker.GetSelectedDate(),
-				CheckOutDate: checkOutPicker.GetSelectedDate(),
-				TariffID:     tariffs[tariffSelect.SelectedIndex()].ID,
-				Notes:        notesEntry.Text,
-			}
-
-			_, err := a.bookingService.CreateBooking(booking)
-			if err != nil {
-				dialog.ShowError(err, a.window)
-				return
-			}
-
-			a.calendarWidget.Update()
-			dialog.ShowInformation("✅ Успешно", "Бронирование создано", a.window)
-		},
-		OnCancel: func() {},
-	}
-
-	d := dialog.NewCustom("✨ Новое бронирование", "Отмена", form, a.window)
-	d.Resize(fyne.NewSize(500, 600))
-	d.Show()
+	a.showQuickBookingDialog()
 }
 
 // showEditCottageDialogFixed показывает диалог редактирования домика (УНИКАЛЬНАЯ ВЕРСИЯ)
 func (a *StyledGuestApp) showEditCottageDialogFixed(cottage models.Cottage) {
 	nameEntry := widget.NewEntry()
 	nameEntry.SetText(cottage.Name)
+	maxAdultsEntry, maxGuestsEntry := newCapacityEntries(cottage)
 
 	form := &widget.Form{
 		Items: []*widget.FormItem{
 			{Text: "🆔 ID", Widget: widget.NewLabel(fmt.Sprintf("%d", cottage.ID))},
 			{Text: "🏠 Название", Widget: nameEntry},
 			{Text: "📊 Статус", Widget: widget.NewLabel(cottage.Status)},
+			{Text: "👥 Гостей до", Widget: maxGuestsEntry},
+			{Text: "🧑 Взрослых до", Widget: maxAdultsEntry},
 		},
 		OnSubmit: func() {
 			err := a.cottageService.UpdateCottageName(cottage.ID, nameEntry.Text)
@@ -839,6 +827,10 @@ func (a *StyledGuestApp) showEditCottageDialogFixed(cottage models.Cottage) {
 				dialog.ShowError(err, a.window)
 				return
 			}
+			if err := a.saveCottageCapacity(cottage.ID, maxAdultsEntry, maxGuestsEntry); err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
 			dialog.ShowInformation("✅ Успешно", "Домик обновлен", a.window)
 			a.calendarWidget.Update()
 		},
@@ -854,7 +846,7 @@ func (a *StyledGuestApp) showEditTariffDialogFixed(tariff models.Tariff) {
 	nameEntry.SetText(tariff.Name)
 
 	priceEntry := widget.NewEntry()
-	priceEntry.SetText(fmt.Sprintf("%.2f", tariff.PricePerDay))
+	priceEntry.SetText(tariff.PricePerDay.Decimal())
 
 	form := &widget.Form{
 		Items: []*widget.FormItem{
@@ -867,7 +859,7 @@ func (a *StyledGuestApp) showEditTariffDialogFixed(tariff models.Tariff) {
 				return
 			}
 
-			price, err := strconv.ParseFloat(priceEntry.Text, 64)
+			price, err := models.ParseMoney(priceEntry.Text)
 			if err != nil {
 				dialog.ShowError(fmt.Errorf("неверный формат цены"), a.window)
 				return
@@ -901,6 +893,8 @@ func lerpColor(start, end color.Color, t float32) color.Color {
 	}
 }
 
+// showQuickBookingDialog показывает диалог быстрого бронирования свободного
+// домика с выбором состава гостей, промокодом и расчетом стоимости по ночам
 func (a *StyledGuestApp) showQuickBookingDialog() {
 	// Получаем список свободных домиков
 	cottages, err := a.cottageService.GetFreeCottages()
@@ -926,17 +920,27 @@ func (a *StyledGuestApp) showQuickBookingDialog() {
 	phoneEntry := ui.StyledEntry("+7 (999) 123-45-67")
 	emailEntry := ui.StyledEntry("email@example.com")
 
+	// Стоимость пересчитывается при изменении домика, тарифа, дат, состава
+	// гостей и промокода
+	var updateCost func()
+
 	cottageOptions := make([]string, len(cottages))
 	for i, c := range cottages {
-		cottageOptions[i] = fmt.Sprintf("🏠 %s", c.Name)
+		cottageOptions[i] = fmt.Sprintf("🏠 %s (%s)", c.Name, c.CapacityTitle())
 	}
-	cottageSelect := widget.NewSelect(cottageOptions, nil)
+	cottageSelect := widget.NewSelect(cottageOptions, func(string) { updateCost() })
 
 	tariffOptions := make([]string, len(tariffs))
 	for i, t := range tariffs {
-		tariffOptions[i] = fmt.Sprintf("💰 %s - %.0f ₽/сутки", t.Name, t.PricePerDay)
+		tariffOptions[i] = fmt.Sprintf("💰 %s - %s/сутки", t.Name, t.PricePerDay)
 	}
-	tariffSelect := widget.NewSelect(tariffOptions, nil)
+	tariffSelect := widget.NewSelect(tariffOptions, func(string) { updateCost() })
+
+	guests := ui.NewGuestCountPicker(1, 0, func() { updateCost() })
+
+	// Промокод; скидка пересчитывается при вводе
+	promoEntry := ui.StyledEntry("Необязательно")
+	promoEntry.OnChanged = func(string) { updateCost() }
 
 	// Даты
 	checkInDate := time.Now().Add(24 * time.Hour)
@@ -944,14 +948,47 @@ func (a *StyledGuestApp) showQuickBookingDialog() {
 
 	checkInPicker := ui.NewDatePickerButton("📅 Дата заезда", a.window, func(t time.Time) {
 		checkInDate = t
+		updateCost()
 	})
 	checkInPicker.SetSelectedDate(checkInDate)
 
 	checkOutPicker := ui.NewDatePickerButton("📅 Дата выезда", a.window, func(t time.Time) {
 		checkOutDate = t
+		updateCost()
 	})
 	checkOutPicker.SetSelectedDate(checkOutDate)
 
+	costLabel := widget.NewLabel("💵 Стоимость: -")
+	breakdownLabel := widget.NewLabel("")
+	discountLabel := widget.NewLabel("")
+
+	updateCost = func() {
+		costLabel.SetText("💵 Стоимость: -")
+		breakdownLabel.SetText("")
+		discountLabel.SetText("")
+		if cottageSelect.SelectedIndex() < 0 || tariffSelect.SelectedIndex() < 0 {
+			return
+		}
+
+		// Стоимость и разбивку по ночам считает движок цен
+		quote, err := a.bookingService.QuoteBooking(tariffs[tariffSelect.SelectedIndex()].ID,
+			cottages[cottageSelect.SelectedIndex()].ID, guests.Adults(), guests.Children(),
+			checkInPicker.GetSelectedDate(), checkOutPicker.GetSelectedDate())
+		if err != nil {
+			return
+		}
+
+		// Неподходящий промокод не мешает расчету: причина видна в строке скидки
+		if err := a.bookingService.ApplyDiscountCode(quote, promoEntry.Text); err != nil {
+			discountLabel.SetText("❌ " + err.Error())
+		} else if quote.Discount.IsPositive() {
+			discountLabel.SetText(fmt.Sprintf("🏷 Скидка по промокоду %s: −%s (без скидки %s)",
+				quote.DiscountCode, quote.Discount, quote.Total))
+		}
+		costLabel.SetText(fmt.Sprintf("💵 Стоимость: %s (ночей: %d)", quote.Due(), len(quote.Nights)))
+		breakdownLabel.SetText(ui.FormatPriceQuote(quote))
+	}
+
 	notesEntry := widget.NewMultiLineEntry()
 	notesEntry.SetPlaceHolder("📝 Дополнительные примечания...")
 	notesEntry.SetMinRowsVisible(3)
@@ -962,9 +999,14 @@ func (a *StyledGuestApp) showQuickBookingDialog() {
 			{Text: "📞 Телефон", Widget: phoneEntry},
 			{Text: "📧 Email", Widget: emailEntry},
 			{Text: "🏠 Домик", Widget: cottageSelect},
+			{Text: "👥 Гости", Widget: guests.Widget()},
 			{Text: "💰 Тариф", Widget: tariffSelect},
+			{Text: "🏷 Промокод", Widget: promoEntry},
 			{Text: "📅 Дата заезда", Widget: checkInPicker},
 			{Text: "📅 Дата выезда", Widget: checkOutPicker},
+			{Text: "", Widget: costLabel},
+			{Text: "🌙 По ночам", Widget: breakdownLabel},
+			{Text: "", Widget: discountLabel},
 			{Text: "📝 Примечания", Widget: notesEntry},
 		},
 		OnSubmit: func() {
@@ -985,9 +1027,31 @@ func (a *StyledGuestApp) showQuickBookingDialog() {
 				CheckOutDate: checkOutPicker.GetSelectedDate(),
 				TariffID:     tariffs[tariffSelect.SelectedIndex()].ID,
 				Notes:        notesEntry.Text,
+				Adults:       guests.Adults(),
+				Children:     guests.Children(),
+				DiscountCode: promoEntry.Text,
 			}
 
 			_, err := a.bookingService.CreateBooking(booking)
+			if errors.Is(err, service.ErrCottageUnavailable) {
+				// Домик успели забронировать с другого рабочего места
+				a.calendarWidget.Update()
+				dialog.ShowInformation("⚠️ Домик занят",
+					"Эти даты уже забронированы. Календарь обновлен — выберите другие даты или домик.", a.window)
+				return
+			}
+			if errors.Is(err, service.ErrOverCapacity) {
+				dialog.ShowInformation("👥 Вместимость", err.Error(), a.window)
+				return
+			}
+			if errors.Is(err, service.ErrStayRestricted) {
+				dialog.ShowInformation("📏 Ограничения сроков", err.Error(), a.window)
+				return
+			}
+			if errors.Is(err, service.ErrDiscountInvalid) {
+				dialog.ShowInformation("🏷 Промокод", err.Error(), a.window)
+				return
+			}
 			if err != nil {
 				dialog.ShowError(err, a.window)
 				return
@@ -1000,7 +1064,7 @@ func (a *StyledGuestApp) showQuickBookingDialog() {
 	}
 
 	d := dialog.NewCustom("✨ Новое бронирование", "Отмена", form, a.window)
-	d.Resize(fyne.NewSize(500, 600))
+	d.Resize(fyne.NewSize(500, 700))
 	d.Show()
 }
 
@@ -1008,12 +1072,15 @@ func (a *StyledGuestApp) showQuickBookingDialog() {
 func (a *StyledGuestApp) showEditCottageDialog(cottage models.Cottage) {
 	nameEntry := widget.NewEntry()
 	nameEntry.SetText(cottage.Name)
+	maxAdultsEntry, maxGuestsEntry := newCapacityEntries(cottage)
 
 	form := &widget.Form{
 		Items: []*widget.FormItem{
 			{Text: "🆔 ID", Widget: widget.NewLabel(fmt.Sprintf("%d", cottage.ID))},
 			{Text: "🏠 Название", Widget: nameEntry},
 			{Text: "📊 Статус", Widget: widget.NewLabel(cottage.Status)},
+			{Text: "👥 Гостей до", Widget: maxGuestsEntry},
+			{Text: "🧑 Взрослых до", Widget: maxAdultsEntry},
 		},
 		OnSubmit: func() {
 			err := a.cottageService.UpdateCottageName(cottage.ID, nameEntry.Text)
@@ -1021,6 +1088,10 @@ func (a *StyledGuestApp) showEditCottageDialog(cottage models.Cottage) {
 				dialog.ShowError(err, a.window)
 				return
 			}
+			if err := a.saveCottageCapacity(cottage.ID, maxAdultsEntry, maxGuestsEntry); err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
 			dialog.ShowInformation("✅ Успешно", "Домик обновлен", a.window)
 			a.calendarWidget.Update()
 		},
@@ -1030,13 +1101,45 @@ func (a *StyledGuestApp) showEditCottageDialog(cottage models.Cottage) {
 	d.Show()
 }
 
+// newCapacityEntries создает поля вместимости домика; пустое поле — без ограничения
+func newCapacityEntries(cottage models.Cottage) (maxAdults, maxGuests *widget.Entry) {
+	maxAdults = widget.NewEntry()
+	maxAdults.PlaceHolder = "без ограничения"
+	if cottage.MaxAdults > 0 {
+		maxAdults.SetText(strconv.Itoa(cottage.MaxAdults))
+	}
+	maxGuests = widget.NewEntry()
+	maxGuests.PlaceHolder = "без ограничения"
+	if cottage.MaxGuests > 0 {
+		maxGuests.SetText(strconv.Itoa(cottage.MaxGuests))
+	}
+	return maxAdults, maxGuests
+}
+
+// saveCottageCapacity сохраняет вместимость домика из полей формы
+func (a *StyledGuestApp) saveCottageCapacity(cottageID int, maxAdultsEntry, maxGuestsEntry *widget.Entry) error {
+	parse := func(s string) (int, error) {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(s)
+	}
+	maxAdults, err1 := parse(maxAdultsEntry.Text)
+	maxGuests, err2 := parse(maxGuestsEntry.Text)
+	if err1 != nil || err2 != nil {
+		return fmt.Errorf("неверный формат вместимости")
+	}
+	return a.cottageService.UpdateCottageCapacity(cottageID, maxAdults, maxGuests)
+}
+
 // showEditTariffDialog - основная версия диалога редактирования тарифа
 func (a *StyledGuestApp) showEditTariffDialog(tariff models.Tariff) {
 	nameEntry := widget.NewEntry()
 	nameEntry.SetText(tariff.Name)
 
 	priceEntry := widget.NewEntry()
-	priceEntry.SetText(fmt.Sprintf("%.2f", tariff.PricePerDay))
+	priceEntry.SetText(tariff.PricePerDay.Decimal())
 
 	form := &widget.Form{
 		Items: []*widget.FormItem{
@@ -1049,7 +1152,7 @@ func (a *StyledGuestApp) showEditTariffDialog(tariff models.Tariff) {
 				return
 			}
 
-			price, err := strconv.ParseFloat(priceEntry.Text, 64)
+			price, err := models.ParseMoney(priceEntry.Text)
 			if err != nil {
 				dialog.ShowError(fmt.Errorf("неверный формат цены"), a.window)
 				return
@@ -3,12 +3,13 @@ package app
 
 import (
 	"fmt"
-	"strconv"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/ui"
 )
 
 // showReportsDialog показывает диалог с отчетами
@@ -16,13 +17,13 @@ func (a *StyledGuestApp) showReportsDialog() {
 	content := container.NewVBox(
 		widget.NewCard("📊 Доступные отчеты", "", container.NewVBox(
 			widget.NewButton("📈 Отчет о заполняемости", func() {
-				dialog.ShowInformation("🔧 В разработке", "Эта функция будет доступна в следующей версии", a.window)
+				a.generateOccupancyReport()
 			}),
 			widget.NewButton("💰 Финансовый отчет", func() {
-				dialog.ShowInformation("🔧 В разработке", "Эта функция будет доступна в следующей версии", a.window)
+				a.generateRevenueReport()
 			}),
 			widget.NewButton("📋 Отчет по бронированиям", func() {
-				dialog.ShowInformation("🔧 В разработке", "Эта функция будет доступна в следующей версии", a.window)
+				ui.ShowBookingsReportDialog(a.bookingService, a.guestService, a.cottageService, a.tariffService, a.window)
 			}),
 		)),
 	)
@@ -112,6 +113,7 @@ func (a *StyledGuestApp) showTariffsDialog() {
 				widget.NewLabel("Тариф"),
 				widget.NewLabel("Цена"),
 				widget.NewButton("Изменить", nil),
+				widget.NewButton("Цены", nil),
 			)
 		},
 		func(id widget.ListItemID, item fyne.CanvasObject) {
@@ -119,11 +121,14 @@ func (a *StyledGuestApp) showTariffsDialog() {
 			box := item.(*fyne.Container)
 
 			box.Objects[0].(*widget.Label).SetText(fmt.Sprintf("💰 %s", tariff.Name))
-			box.Objects[1].(*widget.Label).SetText(fmt.Sprintf("%.0f ₽/сутки", tariff.PricePerDay))
+			box.Objects[1].(*widget.Label).SetText(fmt.Sprintf("%s/сутки", tariff.PricePerDay))
 
 			box.Objects[2].(*widget.Button).OnTapped = func() {
 				a.showEditTariffDialog(tariff)
 			}
+			box.Objects[3].(*widget.Button).OnTapped = func() {
+				ui.ShowTariffPricingDialog(a.tariffService, a.cottages, tariff, a.window)
+			}
 		},
 	)
 
@@ -140,7 +145,7 @@ func (a *StyledGuestApp) showTariffsDialog() {
 			return
 		}
 
-		price, err := strconv.ParseFloat(priceEntry.Text, 64)
+		price, err := models.ParseMoney(priceEntry.Text)
 		if err != nil {
 			dialog.ShowError(fmt.Errorf("Неверный формат цены"), a.window)
 			return
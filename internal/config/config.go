@@ -0,0 +1,404 @@
+// internal/config/config.go
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultConfigFile — файл конфигурации, который ищется в рабочей директории
+const DefaultConfigFile = "config.json"
+
+// Config — настройки приложения "Звуки Леса"
+type Config struct {
+	Database DatabaseConfig `json:"database"`
+	Paths    PathsConfig    `json:"paths"`
+	Stay     StayConfig     `json:"stay"`
+	Invoice  InvoiceConfig  `json:"invoice"`
+}
+
+// Поддерживаемые хранилища данных
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// DatabaseConfig — параметры подключения к базе данных
+type DatabaseConfig struct {
+	// Driver — postgres (сервер БД) или sqlite (локальный файл без сервера)
+	Driver string `json:"driver"`
+	// SQLitePath — файл базы данных для драйвера sqlite
+	SQLitePath string `json:"sqlite_path"`
+
+	// DSN, если задан, используется как есть вместо отдельных параметров
+	DSN      string `json:"dsn"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+	SSLMode  string `json:"sslmode"`
+
+	// Пул соединений
+	MaxOpenConns    int      `json:"max_open_conns"`
+	MaxIdleConns    int      `json:"max_idle_conns"`
+	ConnMaxLifetime Duration `json:"conn_max_lifetime"`
+
+	// Подключение при запуске
+	ConnectTimeout Duration `json:"connect_timeout"`
+	ConnectRetries int      `json:"connect_retries"`
+	RetryBackoff   Duration `json:"retry_backoff"`
+
+	// AutoMigrate применяет миграции схемы при запуске приложения
+	AutoMigrate bool `json:"auto_migrate"`
+}
+
+// PathsConfig — пути к файлам приложения
+type PathsConfig struct {
+	DocumentsRoot string `json:"documents_root"`
+	// ImagesPath — директория с изображениями календаря. Если пусто,
+	// ищется папка images рядом с корнем проекта.
+	ImagesPath string `json:"images_path"`
+}
+
+// StayConfig — расчетный час: по нему выставляются время заезда и выезда
+// новых броней и считаются ночи проживания
+type StayConfig struct {
+	CheckInHour  int `json:"check_in_hour"`
+	CheckOutHour int `json:"check_out_hour"`
+}
+
+// InvoiceConfig — реквизиты продавца в счетах и квитанциях гостям.
+// Задаются только в файле конфигурации; пустые реквизиты в счет не выводятся.
+type InvoiceConfig struct {
+	CompanyName string `json:"company_name"`
+	INN         string `json:"inn"`
+	KPP         string `json:"kpp"`
+	OGRN        string `json:"ogrn"`
+	Address     string `json:"address"`
+	Phone       string `json:"phone"`
+	Email       string `json:"email"`
+	BankName    string `json:"bank_name"`
+	BIK         string `json:"bik"`
+	Account     string `json:"account"`
+	CorrAccount string `json:"corr_account"`
+}
+
+// Duration — time.Duration, который читается из JSON строкой вида "30s"
+type Duration struct {
+	time.Duration
+}
+
+// UnmarshalJSON принимает строку ("1m30s") или число секунд
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		d.Duration = parsed
+		return nil
+	}
+
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return fmt.Errorf("invalid duration %s", data)
+	}
+	d.Duration = time.Duration(seconds * float64(time.Second))
+	return nil
+}
+
+// MarshalJSON записывает длительность строкой
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
+}
+
+// Default возвращает конфигурацию по умолчанию (локальный сервер БД)
+func Default() *Config {
+	return &Config{
+		Database: DatabaseConfig{
+			Driver:          DriverPostgres,
+			SQLitePath:      "bazaotdx.db",
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Name:            "BD_LesBaza",
+			SSLMode:         "disable",
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: Duration{30 * time.Minute},
+			ConnectTimeout:  Duration{5 * time.Second},
+			ConnectRetries:  5,
+			RetryBackoff:    Duration{time.Second},
+			AutoMigrate:     true,
+		},
+		Paths: PathsConfig{
+			DocumentsRoot: "documents",
+		},
+		Stay: StayConfig{
+			CheckInHour:  14,
+			CheckOutHour: 12,
+		},
+	}
+}
+
+// Load собирает конфигурацию: значения по умолчанию, затем файл,
+// затем переменные окружения BAZA_*, затем флаги командной строки.
+// Возвращает аргументы, оставшиеся после флагов (подкоманды).
+func Load(args []string) (*Config, []string, error) {
+	fs := flag.NewFlagSet("bazaotdx", flag.ContinueOnError)
+	configPath := fs.String("config", "", "путь к файлу конфигурации (JSON)")
+	registerFlags(fs, Default())
+
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	cfg := Default()
+
+	path := *configPath
+	if path == "" {
+		path = os.Getenv("BAZA_CONFIG")
+	}
+	explicit := path != ""
+	if path == "" {
+		path = DefaultConfigFile
+	}
+	if err := cfg.loadFile(path, explicit); err != nil {
+		return nil, nil, err
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, nil, err
+	}
+
+	// Флаги имеют наивысший приоритет: переносим только явно заданные
+	target := flag.NewFlagSet("bazaotdx", flag.ContinueOnError)
+	registerFlags(target, cfg)
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "config" || flagErr != nil {
+			return
+		}
+		flagErr = target.Set(f.Name, f.Value.String())
+	})
+	if flagErr != nil {
+		return nil, nil, flagErr
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, fs.Args(), nil
+}
+
+// registerFlags привязывает флаги командной строки к полям конфигурации
+func registerFlags(fs *flag.FlagSet, c *Config) {
+	db := &c.Database
+	fs.StringVar(&db.Driver, "db-driver", db.Driver, "хранилище: postgres или sqlite")
+	fs.StringVar(&db.SQLitePath, "db-sqlite-path", db.SQLitePath, "файл базы данных SQLite")
+	fs.StringVar(&db.DSN, "db-dsn", db.DSN, "строка подключения PostgreSQL (перекрывает остальные db-*)")
+	fs.StringVar(&db.Host, "db-host", db.Host, "хост PostgreSQL")
+	fs.IntVar(&db.Port, "db-port", db.Port, "порт PostgreSQL")
+	fs.StringVar(&db.User, "db-user", db.User, "пользователь PostgreSQL")
+	fs.StringVar(&db.Password, "db-password", db.Password, "пароль PostgreSQL")
+	fs.StringVar(&db.Name, "db-name", db.Name, "имя базы данных")
+	fs.StringVar(&db.SSLMode, "db-sslmode", db.SSLMode, "режим SSL (disable, require, verify-full)")
+	fs.IntVar(&db.MaxOpenConns, "db-max-open-conns", db.MaxOpenConns, "максимум открытых соединений")
+	fs.IntVar(&db.MaxIdleConns, "db-max-idle-conns", db.MaxIdleConns, "максимум простаивающих соединений")
+	fs.DurationVar(&db.ConnMaxLifetime.Duration, "db-conn-max-lifetime", db.ConnMaxLifetime.Duration, "время жизни соединения")
+	fs.DurationVar(&db.ConnectTimeout.Duration, "db-connect-timeout", db.ConnectTimeout.Duration, "таймаут подключения")
+	fs.IntVar(&db.ConnectRetries, "db-connect-retries", db.ConnectRetries, "число повторных попыток подключения при запуске")
+	fs.DurationVar(&db.RetryBackoff.Duration, "db-retry-backoff", db.RetryBackoff.Duration, "начальная пауза между попытками (удваивается)")
+	fs.BoolVar(&db.AutoMigrate, "db-auto-migrate", db.AutoMigrate, "применять миграции схемы при запуске")
+
+	fs.StringVar(&c.Paths.DocumentsRoot, "documents-root", c.Paths.DocumentsRoot, "папка для документов гостей")
+	fs.StringVar(&c.Paths.ImagesPath, "images-path", c.Paths.ImagesPath, "папка с изображениями календаря")
+
+	fs.IntVar(&c.Stay.CheckInHour, "check-in-hour", c.Stay.CheckInHour, "час заезда (0-23)")
+	fs.IntVar(&c.Stay.CheckOutHour, "check-out-hour", c.Stay.CheckOutHour, "час выезда (0-23)")
+}
+
+// loadFile читает JSON-файл поверх текущих значений.
+// Отсутствие файла — ошибка, только если путь задан явно.
+func (c *Config) loadFile(path string, required bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !required {
+			return nil
+		}
+		return fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv применяет переменные окружения BAZA_*
+func (c *Config) applyEnv() error {
+	db := &c.Database
+
+	strVars := map[string]*string{
+		"BAZA_DB_DRIVER":      &db.Driver,
+		"BAZA_DB_SQLITE_PATH": &db.SQLitePath,
+		"BAZA_DB_DSN":         &db.DSN,
+		"BAZA_DB_HOST":        &db.Host,
+		"BAZA_DB_USER":        &db.User,
+		"BAZA_DB_PASSWORD":    &db.Password,
+		"BAZA_DB_NAME":        &db.Name,
+		"BAZA_DB_SSLMODE":     &db.SSLMode,
+		"BAZA_DOCUMENTS_ROOT": &c.Paths.DocumentsRoot,
+		"BAZA_IMAGES_PATH":    &c.Paths.ImagesPath,
+	}
+	for name, dst := range strVars {
+		if v, ok := os.LookupEnv(name); ok {
+			*dst = v
+		}
+	}
+
+	intVars := map[string]*int{
+		"BAZA_DB_PORT":            &db.Port,
+		"BAZA_DB_MAX_OPEN_CONNS":  &db.MaxOpenConns,
+		"BAZA_DB_MAX_IDLE_CONNS":  &db.MaxIdleConns,
+		"BAZA_DB_CONNECT_RETRIES": &db.ConnectRetries,
+		"BAZA_CHECK_IN_HOUR":      &c.Stay.CheckInHour,
+		"BAZA_CHECK_OUT_HOUR":     &c.Stay.CheckOutHour,
+	}
+	for name, dst := range intVars {
+		if v, ok := os.LookupEnv(name); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s=%q: %w", name, v, err)
+			}
+			*dst = n
+		}
+	}
+
+	boolVars := map[string]*bool{
+		"BAZA_DB_AUTO_MIGRATE": &db.AutoMigrate,
+	}
+	for name, dst := range boolVars {
+		if v, ok := os.LookupEnv(name); ok {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s=%q: %w", name, v, err)
+			}
+			*dst = b
+		}
+	}
+
+	durVars := map[string]*Duration{
+		"BAZA_DB_CONN_MAX_LIFETIME": &db.ConnMaxLifetime,
+		"BAZA_DB_CONNECT_TIMEOUT":   &db.ConnectTimeout,
+		"BAZA_DB_RETRY_BACKOFF":     &db.RetryBackoff,
+	}
+	for name, dst := range durVars {
+		if v, ok := os.LookupEnv(name); ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s=%q: %w", name, v, err)
+			}
+			dst.Duration = d
+		}
+	}
+
+	return nil
+}
+
+// Validate проверяет согласованность настроек
+func (c *Config) Validate() error {
+	db := c.Database
+	switch db.Driver {
+	case DriverPostgres:
+		if db.DSN == "" && (db.Host == "" || db.Name == "") {
+			return fmt.Errorf("config: database host and name are required when dsn is empty")
+		}
+		if db.Port <= 0 || db.Port > 65535 {
+			return fmt.Errorf("config: invalid database port %d", db.Port)
+		}
+	case DriverSQLite:
+		if db.SQLitePath == "" {
+			return fmt.Errorf("config: sqlite_path is required for sqlite driver")
+		}
+	default:
+		return fmt.Errorf("config: unknown database driver %q (expected %s or %s)", db.Driver, DriverPostgres, DriverSQLite)
+	}
+	if db.MaxOpenConns < 0 || db.MaxIdleConns < 0 || db.ConnectRetries < 0 {
+		return fmt.Errorf("config: pool limits and retries must not be negative")
+	}
+	if c.Paths.DocumentsRoot == "" {
+		return fmt.Errorf("config: documents_root is required")
+	}
+	if c.Stay.CheckInHour < 0 || c.Stay.CheckInHour > 23 || c.Stay.CheckOutHour < 0 || c.Stay.CheckOutHour > 23 {
+		return fmt.Errorf("config: check_in_hour and check_out_hour must be between 0 and 23")
+	}
+	return nil
+}
+
+// ConnString возвращает строку подключения для lib/pq
+func (db DatabaseConfig) ConnString() string {
+	if db.DSN != "" {
+		return db.DSN
+	}
+
+	parts := []string{
+		"host=" + quoteConnValue(db.Host),
+		"port=" + strconv.Itoa(db.Port),
+		"user=" + quoteConnValue(db.User),
+		"dbname=" + quoteConnValue(db.Name),
+		"sslmode=" + quoteConnValue(db.SSLMode),
+	}
+	if db.Password != "" {
+		parts = append(parts, "password="+quoteConnValue(db.Password))
+	}
+	if secs := int(db.ConnectTimeout.Seconds()); secs > 0 {
+		parts = append(parts, "connect_timeout="+strconv.Itoa(secs))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteConnValue экранирует значение в формате key=value libpq
+func quoteConnValue(v string) string {
+	if v != "" && !strings.ContainsAny(v, " '\\") {
+		return v
+	}
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `'`, `\'`)
+	return "'" + v + "'"
+}
+
+// ResolveImagesPath возвращает директорию с изображениями календаря.
+// Без явной настройки ищет images рядом с go.mod выше исполняемого файла,
+// а затем в рабочей директории.
+func (p PathsConfig) ResolveImagesPath() string {
+	if p.ImagesPath != "" {
+		return p.ImagesPath
+	}
+
+	if execPath, err := os.Executable(); err == nil {
+		dir := filepath.Dir(execPath)
+		for {
+			if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+				return filepath.Join(dir, "images")
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+
+	return "images"
+}
@@ -0,0 +1,310 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// copyTable описывает таблицу, переносимую из SQLite в PostgreSQL
+type copyTable struct {
+	name        string
+	idColumn    string
+	columns     []string
+	timeColumns map[string]bool
+	// boolColumns хранятся в SQLite числами 0 и 1
+	boolColumns map[string]bool
+	// moneyColumns — суммы в копейках, в SQLite хранятся в столбцах REAL
+	moneyColumns map[string]bool
+	// linkTable — таблица связей без своей последовательности ID
+	linkTable bool
+}
+
+// copyTables перечислены в порядке внешних ключей
+var copyTables = []copyTable{
+	{
+		name:     "cottages",
+		idColumn: "cottage_id",
+		columns:  []string{"cottage_id", "name", "status", "max_adults", "max_guests"},
+	},
+	{
+		name:        "cancellation_policies",
+		idColumn:    "policy_id",
+		columns:     []string{"policy_id", "name", "free_days", "penalty_percent", "non_refundable"},
+		boolColumns: map[string]bool{"non_refundable": true},
+	},
+	{
+		name:     "tariffs",
+		idColumn: "tariff_id",
+		columns: []string{"tariff_id", "name", "price_per_day", "weekend_surcharge", "holiday_surcharge",
+			"base_guests", "extra_adult_price", "extra_child_price", "cancellation_policy_id"},
+		moneyColumns: map[string]bool{"price_per_day": true, "extra_adult_price": true, "extra_child_price": true},
+	},
+	{
+		name:         "tariff_rates",
+		idColumn:     "rate_id",
+		columns:      []string{"rate_id", "tariff_id", "cottage_id", "name", "start_date", "end_date", "price_per_day"},
+		timeColumns:  map[string]bool{"start_date": true, "end_date": true},
+		moneyColumns: map[string]bool{"price_per_day": true},
+	},
+	{
+		name:        "holidays",
+		idColumn:    "holiday_id",
+		columns:     []string{"holiday_id", "holiday_date", "name"},
+		timeColumns: map[string]bool{"holiday_date": true},
+	},
+	{
+		name:     "discounts",
+		idColumn: "discount_id",
+		columns: []string{"discount_id", "code", "name", "kind", "value", "amount", "valid_from", "valid_to",
+			"max_uses", "used_count", "active"},
+		timeColumns:  map[string]bool{"valid_from": true, "valid_to": true},
+		moneyColumns: map[string]bool{"amount": true},
+		boolColumns:  map[string]bool{"active": true},
+	},
+	{
+		name:      "discount_tariffs",
+		idColumn:  "discount_id",
+		columns:   []string{"discount_id", "tariff_id"},
+		linkTable: true,
+	},
+	{
+		name:     "stay_restrictions",
+		idColumn: "restriction_id",
+		columns: []string{"restriction_id", "cottage_id", "name", "start_date", "end_date",
+			"min_nights", "closed_to_arrival", "closed_to_departure"},
+		timeColumns: map[string]bool{"start_date": true, "end_date": true},
+		boolColumns: map[string]bool{"closed_to_arrival": true, "closed_to_departure": true},
+	},
+	{
+		name:     "guests",
+		idColumn: "guest_id",
+		columns: []string{"guest_id", "full_name", "email", "phone", "cottage_id",
+			"document_scan_path", "check_in_date", "check_out_date", "tariff_id"},
+		timeColumns: map[string]bool{"check_in_date": true, "check_out_date": true},
+	},
+	{
+		name:        "booking_groups",
+		idColumn:    "group_id",
+		columns:     []string{"group_id", "name", "payer_name", "phone", "email", "notes", "created_at"},
+		timeColumns: map[string]bool{"created_at": true},
+	},
+	{
+		name:     "bookings",
+		idColumn: "booking_id",
+		columns: []string{"booking_id", "cottage_id", "guest_name", "phone", "email",
+			"check_in_date", "check_out_date", "status", "created_at", "notes",
+			"tariff_id", "total_cost", "hold_expires_at", "block_reason", "group_id",
+			"adults", "children", "discount_id", "discount_code", "discount_amount", "deposit_amount"},
+		timeColumns: map[string]bool{
+			"check_in_date": true, "check_out_date": true, "created_at": true, "hold_expires_at": true,
+		},
+		moneyColumns: map[string]bool{"total_cost": true, "discount_amount": true, "deposit_amount": true},
+	},
+	{
+		name:         "payments",
+		idColumn:     "payment_id",
+		columns:      []string{"payment_id", "booking_id", "kind", "amount", "method", "paid_at", "operator", "note"},
+		timeColumns:  map[string]bool{"paid_at": true},
+		moneyColumns: map[string]bool{"amount": true},
+	},
+	{
+		name:     "invoices",
+		idColumn: "invoice_id",
+		columns: []string{"invoice_id", "booking_id", "year", "seq", "number", "issued_at",
+			"guest_name", "total", "paid", "file_path"},
+		timeColumns:  map[string]bool{"issued_at": true},
+		moneyColumns: map[string]bool{"total": true, "paid": true},
+	},
+	{
+		name:         "extras",
+		idColumn:     "extra_id",
+		columns:      []string{"extra_id", "name", "unit", "price", "active"},
+		boolColumns:  map[string]bool{"active": true},
+		moneyColumns: map[string]bool{"price": true},
+	},
+	{
+		name:     "folio_items",
+		idColumn: "item_id",
+		columns: []string{"item_id", "booking_id", "extra_id", "title", "quantity", "unit_price",
+			"service_date", "created_at"},
+		timeColumns:  map[string]bool{"service_date": true, "created_at": true},
+		moneyColumns: map[string]bool{"unit_price": true},
+	},
+	{
+		name:     "booking_changes",
+		idColumn: "change_id",
+		columns: []string{"change_id", "booking_id", "changed_at", "old_cottage_id", "new_cottage_id",
+			"old_check_in_date", "new_check_in_date", "old_check_out_date", "new_check_out_date",
+			"old_tariff_id", "new_tariff_id", "old_total_cost", "new_total_cost", "reason"},
+		timeColumns: map[string]bool{
+			"changed_at": true, "old_check_in_date": true, "new_check_in_date": true,
+			"old_check_out_date": true, "new_check_out_date": true,
+		},
+		moneyColumns: map[string]bool{"old_total_cost": true, "new_total_cost": true},
+	},
+}
+
+// CopiedTable — итог переноса одной таблицы
+type CopiedTable struct {
+	Table string
+	Rows  int
+}
+
+// sqliteTimeLayout — формат дат в SQLite-хранилище (UTC)
+const sqliteTimeLayout = "2006-01-02 15:04:05.000000"
+
+// CopySQLiteToPostgres переносит все данные из базы SQLite в пустую схему
+// lesbaza с сохранением идентификаторов. Обе базы должны быть на последней
+// версии миграций. Копирование выполняется в одной транзакции PostgreSQL:
+// при ошибке целевая база остается пустой.
+func CopySQLiteToPostgres(src, dst *sql.DB) ([]CopiedTable, error) {
+	tx, err := dst.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range copyTables {
+		var exists bool
+		err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM lesbaza." + table.name + ")").Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check lesbaza.%s: %w", table.name, err)
+		}
+		if exists {
+			return nil, fmt.Errorf("target table lesbaza.%s is not empty", table.name)
+		}
+	}
+
+	var copied []CopiedTable
+	for _, table := range copyTables {
+		n, err := copyRows(src, tx, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", table.name, err)
+		}
+		copied = append(copied, CopiedTable{Table: table.name, Rows: n})
+		if table.linkTable {
+			continue
+		}
+
+		// Последовательность продолжает нумерацию после перенесенных ID
+		_, err = tx.Exec(fmt.Sprintf(`
+			SELECT setval(pg_get_serial_sequence('lesbaza.%[1]s', '%[2]s'),
+				COALESCE(MAX(%[2]s), 1), MAX(%[2]s) IS NOT NULL)
+			FROM lesbaza.%[1]s`, table.name, table.idColumn))
+		if err != nil {
+			return nil, fmt.Errorf("failed to reset sequence for %s: %w", table.name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return copied, nil
+}
+
+func copyRows(src *sql.DB, tx *sql.Tx, table copyTable) (int, error) {
+	columnList := strings.Join(table.columns, ", ")
+	rows, err := src.Query("SELECT " + columnList + " FROM " + table.name + " ORDER BY " + table.idColumn)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	placeholders := make([]string, len(table.columns))
+	for i := range placeholders {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+	}
+	insert, err := tx.Prepare("INSERT INTO lesbaza." + table.name + " (" + columnList +
+		") VALUES (" + strings.Join(placeholders, ", ") + ")")
+	if err != nil {
+		return 0, err
+	}
+	defer insert.Close()
+
+	count := 0
+	values := make([]any, len(table.columns))
+	dest := make([]any, len(table.columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return count, err
+		}
+		for i, column := range table.columns {
+			if table.timeColumns[column] {
+				if values[i], err = sqliteTime(values[i]); err != nil {
+					return count, fmt.Errorf("%s: %w", column, err)
+				}
+			}
+			if table.boolColumns[column] {
+				if values[i], err = sqliteBool(values[i]); err != nil {
+					return count, fmt.Errorf("%s: %w", column, err)
+				}
+			}
+			if table.moneyColumns[column] {
+				if values[i], err = sqliteMoney(values[i]); err != nil {
+					return count, fmt.Errorf("%s: %w", column, err)
+				}
+			}
+		}
+		if _, err := insert.Exec(values...); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, rows.Err()
+}
+
+// sqliteBool переводит логическое значение SQLite (0 или 1) в bool
+func sqliteBool(v any) (any, error) {
+	switch x := v.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return x, nil
+	case int64:
+		return x != 0, nil
+	default:
+		return nil, fmt.Errorf("unexpected bool value %T", v)
+	}
+}
+
+// sqliteMoney переводит сумму в копейках из столбца REAL в целое число
+func sqliteMoney(v any) (any, error) {
+	switch x := v.(type) {
+	case nil:
+		return nil, nil
+	case int64:
+		return x, nil
+	case float64:
+		return int64(math.Round(x)), nil
+	default:
+		return nil, fmt.Errorf("unexpected money value %T", v)
+	}
+}
+
+// sqliteTime переводит дату из формата SQLite-хранилища в местное время,
+// в котором приложение пишет даты в PostgreSQL (TIMESTAMP без часового пояса)
+func sqliteTime(v any) (any, error) {
+	switch x := v.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		return x.Local(), nil
+	case []byte:
+		return sqliteTime(string(x))
+	case string:
+		t, err := time.ParseInLocation(sqliteTimeLayout, x, time.UTC)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q: %w", x, err)
+		}
+		return t.Local(), nil
+	default:
+		return nil, fmt.Errorf("unexpected time value %T", v)
+	}
+}
@@ -1,26 +1,68 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"log"
+	"time"
 
+	"github.com/VallfIK/bazaotdx/internal/config"
 	_ "github.com/lib/pq"
 )
 
+// maxRetryBackoff ограничивает паузу между попытками подключения
+const maxRetryBackoff = 30 * time.Second
+
 type PostgresDB struct {
 	*sql.DB
 }
 
-func NewPostgresDB() (*PostgresDB, error) {
-	connStr := "host=192.168.0.104 port=5432 user=postgres password=L0kijuhy! dbname=BD_LesBaza sslmode=disable"
-	db, err := sql.Open("postgres", connStr)
+func NewPostgresDB(cfg config.DatabaseConfig) (*PostgresDB, error) {
+	db, err := sql.Open("postgres", cfg.ConnString())
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	if err = db.Ping(); err != nil {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime.Duration)
+
+	if err = pingWithRetry(db, cfg); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	return &PostgresDB{db}, nil
 }
+
+// pingWithRetry проверяет соединение, повторяя попытки с растущей паузой
+func pingWithRetry(db *sql.DB, cfg config.DatabaseConfig) error {
+	backoff := cfg.RetryBackoff.Duration
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		err = ping(db, cfg.ConnectTimeout.Duration)
+		if err == nil || attempt >= cfg.ConnectRetries {
+			return err
+		}
+
+		log.Printf("⚠️ БД недоступна (попытка %d из %d): %v. Повтор через %s",
+			attempt+1, cfg.ConnectRetries+1, err, backoff)
+		time.Sleep(backoff)
+
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+func ping(db *sql.DB, timeout time.Duration) error {
+	if timeout <= 0 {
+		return db.Ping()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return db.PingContext(ctx)
+}
@@ -0,0 +1,317 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/postgres/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey — ключ advisory-блокировки, чтобы два клиента,
+// запущенные одновременно, не применяли миграции параллельно
+const migrationLockKey = 7_452_019_001
+
+// Migration — одна версия схемы с SQL для наката и отката
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus — состояние миграции в базе
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// dialect описывает различия PostgreSQL и SQLite для мигратора
+type dialect struct {
+	dir          string
+	versionTable string
+	createTable  string
+	placeholder  func(n int) string
+	// lock сериализует мигрирующие процессы; nil — блокировка не нужна
+	lock func(conn *sql.Conn) (unlock func(), err error)
+}
+
+var postgresDialect = dialect{
+	dir:          "migrations/postgres",
+	versionTable: "lesbaza.schema_migrations",
+	createTable: `
+		CREATE SCHEMA IF NOT EXISTS lesbaza;
+		CREATE TABLE IF NOT EXISTS lesbaza.schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+	placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+	lock: func(conn *sql.Conn) (func(), error) {
+		ctx := context.Background()
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+			return nil, err
+		}
+		return func() {
+			conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey)
+		}, nil
+	},
+}
+
+// В SQLite каждая миграция выполняется в транзакции BEGIN IMMEDIATE
+// (см. NewSQLiteDB), поэтому отдельная блокировка не нужна
+var sqliteDialect = dialect{
+	dir:          "migrations/sqlite",
+	versionTable: "schema_migrations",
+	createTable: `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+	placeholder: func(int) string { return "?" },
+}
+
+// Migrator применяет встроенные миграции к базе данных
+type Migrator struct {
+	db         *sql.DB
+	dialect    dialect
+	migrations []Migration
+}
+
+// NewMigrator загружает встроенные миграции схемы lesbaza для PostgreSQL
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	return newMigrator(db, postgresDialect)
+}
+
+// NewSQLiteMigrator загружает встроенные миграции для SQLite
+func NewSQLiteMigrator(db *sql.DB) (*Migrator, error) {
+	return newMigrator(db, sqliteDialect)
+}
+
+func newMigrator(db *sql.DB, d dialect) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, d.dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: d, migrations: migrations}, nil
+}
+
+// loadMigrations читает файлы вида 0001_name.up.sql / 0001_name.down.sql
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		versionPart, title, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name %q", name)
+		}
+		version, err := strconv.Atoi(versionPart)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", name)
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: title}
+			byVersion[version] = m
+		} else if m.Name != title {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, title)
+		}
+
+		if direction == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d (%s) has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up применяет все ещё не применённые миграции и возвращает их число
+func (m *Migrator) Up() (int, error) {
+	count := 0
+	err := m.withLock(func(conn *sql.Conn, applied map[int]time.Time) error {
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(conn, migration, migration.Up, true); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down откатывает последние steps применённых миграций
+func (m *Migrator) Down(steps int) (int, error) {
+	count := 0
+	err := m.withLock(func(conn *sql.Conn, applied map[int]time.Time) error {
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d (%s) cannot be rolled back: no down script", migration.Version, migration.Name)
+			}
+			if err := m.apply(conn, migration, migration.Down, false); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status возвращает список миграций с отметкой о применении
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(func(conn *sql.Conn, applied map[int]time.Time) error {
+		for _, migration := range m.migrations {
+			at, ok := applied[migration.Version]
+			statuses = append(statuses, MigrationStatus{
+				Migration: migration,
+				Applied:   ok,
+				AppliedAt: at,
+			})
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock берёт блокировку миграций на отдельном соединении,
+// гарантирует наличие таблицы версий и передаёт применённые версии в fn
+func (m *Migrator) withLock(fn func(conn *sql.Conn, applied map[int]time.Time) error) error {
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if m.dialect.lock != nil {
+		unlock, err := m.dialect.lock(conn)
+		if err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer unlock()
+	}
+
+	if _, err := conn.ExecContext(ctx, m.dialect.createTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM "+m.dialect.versionTable)
+	if err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[version] = at
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	return fn(conn, applied)
+}
+
+// apply выполняет скрипт миграции и обновляет таблицу версий в одной транзакции
+func (m *Migrator) apply(conn *sql.Conn, migration Migration, script string, up bool) error {
+	ctx := context.Background()
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Повторная проверка внутри транзакции: другой процесс мог успеть
+	// применить ту же миграцию, пока мы читали список версий
+	var recorded int
+	err = tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM "+m.dialect.versionTable+" WHERE version = "+m.dialect.placeholder(1),
+		migration.Version).Scan(&recorded)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to check migration %d: %w", migration.Version, err)
+	}
+	if (recorded > 0) == up {
+		return tx.Rollback()
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Name, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO "+m.dialect.versionTable+" (version, name) VALUES ("+
+				m.dialect.placeholder(1)+", "+m.dialect.placeholder(2)+")",
+			migration.Version, migration.Name)
+	} else {
+		_, err = tx.ExecContext(ctx,
+			"DELETE FROM "+m.dialect.versionTable+" WHERE version = "+m.dialect.placeholder(1),
+			migration.Version)
+	}
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+	}
+
+	return tx.Commit()
+}
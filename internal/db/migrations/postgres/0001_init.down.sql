@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS lesbaza.bookings;
+DROP TABLE IF EXISTS lesbaza.guests;
+DROP TABLE IF EXISTS lesbaza.tariffs;
+DROP TABLE IF EXISTS lesbaza.cottages;
@@ -0,0 +1,49 @@
+-- Базовая схема "Звуки Леса". IF NOT EXISTS позволяет принять под
+-- управление существующую базу, созданную до появления миграций.
+CREATE SCHEMA IF NOT EXISTS lesbaza;
+
+CREATE TABLE IF NOT EXISTS lesbaza.cottages (
+    cottage_id SERIAL PRIMARY KEY,
+    name       TEXT NOT NULL,
+    status     TEXT NOT NULL DEFAULT 'free'
+);
+
+CREATE TABLE IF NOT EXISTS lesbaza.tariffs (
+    tariff_id     SERIAL PRIMARY KEY,
+    name          TEXT NOT NULL,
+    price_per_day NUMERIC(10, 2) NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS lesbaza.guests (
+    guest_id           SERIAL PRIMARY KEY,
+    full_name          TEXT NOT NULL,
+    email              TEXT NOT NULL DEFAULT '',
+    phone              TEXT NOT NULL DEFAULT '',
+    cottage_id         INTEGER NOT NULL REFERENCES lesbaza.cottages (cottage_id),
+    document_scan_path TEXT NOT NULL DEFAULT '',
+    check_in_date      TIMESTAMP,
+    check_out_date     TIMESTAMP,
+    tariff_id          INTEGER REFERENCES lesbaza.tariffs (tariff_id)
+);
+
+CREATE TABLE IF NOT EXISTS lesbaza.bookings (
+    booking_id     SERIAL PRIMARY KEY,
+    cottage_id     INTEGER NOT NULL REFERENCES lesbaza.cottages (cottage_id),
+    guest_name     TEXT NOT NULL DEFAULT '',
+    phone          TEXT NOT NULL DEFAULT '',
+    email          TEXT NOT NULL DEFAULT '',
+    check_in_date  TIMESTAMP NOT NULL,
+    check_out_date TIMESTAMP NOT NULL,
+    status         TEXT NOT NULL DEFAULT 'booked',
+    created_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    notes          TEXT NOT NULL DEFAULT '',
+    tariff_id      INTEGER REFERENCES lesbaza.tariffs (tariff_id),
+    total_cost     NUMERIC(10, 2) NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS bookings_cottage_dates_idx
+    ON lesbaza.bookings (cottage_id, check_in_date, check_out_date);
+CREATE INDEX IF NOT EXISTS bookings_status_idx
+    ON lesbaza.bookings (status);
+CREATE INDEX IF NOT EXISTS guests_cottage_idx
+    ON lesbaza.guests (cottage_id);
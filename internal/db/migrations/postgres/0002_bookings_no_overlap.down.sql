@@ -0,0 +1 @@
+ALTER TABLE lesbaza.bookings DROP CONSTRAINT IF EXISTS bookings_no_overlap;
@@ -0,0 +1,38 @@
+-- Две действующие брони одного домика не могут пересекаться по времени.
+-- Ограничение работает для всех клиентов базы (приложение, мобильный backend),
+-- поэтому параллельные запросы не приводят к двойному бронированию.
+--
+-- Если в базе уже есть пересекающиеся действующие брони, ограничение не
+-- добавится. Тогда миграция останавливается и перечисляет пары booking_id:
+-- одну бронь из каждой пары нужно перенести или отменить
+-- (UPDATE lesbaza.bookings SET status = 'cancelled' WHERE booking_id = ...)
+-- и запустить миграцию снова.
+DO $$
+DECLARE
+    conflicts TEXT;
+BEGIN
+    SELECT string_agg(a.booking_id || '/' || b.booking_id, ', ' ORDER BY a.booking_id, b.booking_id)
+    INTO conflicts
+    FROM lesbaza.bookings a
+    JOIN lesbaza.bookings b
+        ON b.cottage_id = a.cottage_id
+        AND b.booking_id > a.booking_id
+        AND b.check_in_date < a.check_out_date
+        AND b.check_out_date > a.check_in_date
+    WHERE a.status IN ('booked', 'checked_in', 'temporary')
+        AND b.status IN ('booked', 'checked_in', 'temporary');
+
+    IF conflicts IS NOT NULL THEN
+        RAISE EXCEPTION 'overlapping active bookings (booking_id pairs): %; move or cancel one booking of each pair and run the migration again', conflicts;
+    END IF;
+END $$;
+
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE lesbaza.bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        cottage_id WITH =,
+        tsrange(check_in_date, check_out_date, '[)') WITH &&
+    )
+    WHERE (status IN ('booked', 'checked_in', 'temporary'));
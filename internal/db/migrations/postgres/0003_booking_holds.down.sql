@@ -0,0 +1 @@
+ALTER TABLE lesbaza.bookings DROP COLUMN IF EXISTS hold_expires_at;
@@ -0,0 +1,3 @@
+-- Временное удержание домика (статус temporary) действует до hold_expires_at,
+-- после чего фоновая задача его снимает.
+ALTER TABLE lesbaza.bookings ADD COLUMN IF NOT EXISTS hold_expires_at TIMESTAMP;
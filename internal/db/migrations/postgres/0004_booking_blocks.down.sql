@@ -0,0 +1,10 @@
+ALTER TABLE lesbaza.bookings DROP CONSTRAINT IF EXISTS bookings_no_overlap;
+ALTER TABLE lesbaza.bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        cottage_id WITH =,
+        tsrange(check_in_date, check_out_date, '[)') WITH &&
+    )
+    WHERE (status IN ('booked', 'checked_in', 'temporary'));
+
+ALTER TABLE lesbaza.bookings DROP COLUMN IF EXISTS block_reason;
@@ -0,0 +1,13 @@
+-- Блокировка домика на обслуживание хранится как бронь со статусом blocked
+-- и причиной (repair, cleaning, owner). Блокировка занимает домик так же,
+-- как действующая бронь, поэтому входит в ограничение bookings_no_overlap.
+ALTER TABLE lesbaza.bookings ADD COLUMN IF NOT EXISTS block_reason TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE lesbaza.bookings DROP CONSTRAINT IF EXISTS bookings_no_overlap;
+ALTER TABLE lesbaza.bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        cottage_id WITH =,
+        tsrange(check_in_date, check_out_date, '[)') WITH &&
+    )
+    WHERE (status IN ('booked', 'checked_in', 'temporary', 'blocked'));
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS lesbaza.bookings_group_idx;
+ALTER TABLE lesbaza.bookings DROP COLUMN IF EXISTS group_id;
+DROP TABLE IF EXISTS lesbaza.booking_groups;
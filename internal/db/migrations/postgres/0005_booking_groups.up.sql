@@ -0,0 +1,17 @@
+-- Групповая бронь: несколько домиков на одного плательщика.
+-- Брони группы ссылаются на нее через group_id.
+CREATE TABLE IF NOT EXISTS lesbaza.booking_groups (
+    group_id   SERIAL PRIMARY KEY,
+    name       TEXT NOT NULL DEFAULT '',
+    payer_name TEXT NOT NULL,
+    phone      TEXT NOT NULL DEFAULT '',
+    email      TEXT NOT NULL DEFAULT '',
+    notes      TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE lesbaza.bookings ADD COLUMN IF NOT EXISTS group_id INTEGER
+    REFERENCES lesbaza.booking_groups (group_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS bookings_group_idx
+    ON lesbaza.bookings (group_id);
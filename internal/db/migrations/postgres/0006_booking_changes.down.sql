@@ -0,0 +1 @@
+DROP TABLE IF EXISTS lesbaza.booking_changes;
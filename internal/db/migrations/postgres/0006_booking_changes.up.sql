@@ -0,0 +1,21 @@
+-- Журнал изменений брони: перенос дат, смена домика или тарифа
+-- с пересчетом стоимости.
+CREATE TABLE IF NOT EXISTS lesbaza.booking_changes (
+    change_id          SERIAL PRIMARY KEY,
+    booking_id         INTEGER NOT NULL REFERENCES lesbaza.bookings (booking_id) ON DELETE CASCADE,
+    changed_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    old_cottage_id     INTEGER NOT NULL,
+    new_cottage_id     INTEGER NOT NULL,
+    old_check_in_date  TIMESTAMP NOT NULL,
+    new_check_in_date  TIMESTAMP NOT NULL,
+    old_check_out_date TIMESTAMP NOT NULL,
+    new_check_out_date TIMESTAMP NOT NULL,
+    old_tariff_id      INTEGER,
+    new_tariff_id      INTEGER,
+    old_total_cost     NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    new_total_cost     NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    reason             TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS booking_changes_booking_idx
+    ON lesbaza.booking_changes (booking_id);
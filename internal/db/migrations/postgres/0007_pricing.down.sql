@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS lesbaza.holidays;
+DROP TABLE IF EXISTS lesbaza.tariff_rates;
+ALTER TABLE lesbaza.tariffs DROP COLUMN IF EXISTS holiday_surcharge;
+ALTER TABLE lesbaza.tariffs DROP COLUMN IF EXISTS weekend_surcharge;
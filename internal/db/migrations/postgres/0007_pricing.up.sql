@@ -0,0 +1,25 @@
+-- Сезонные цены и наценки. Цена ночи берется из самой точной записи
+-- tariff_rates (сезон для домика, сезон, цена домика), иначе из тарифа;
+-- на выходные и праздники тариф добавляет наценку в процентах.
+ALTER TABLE lesbaza.tariffs ADD COLUMN IF NOT EXISTS weekend_surcharge NUMERIC(5, 2) NOT NULL DEFAULT 0;
+ALTER TABLE lesbaza.tariffs ADD COLUMN IF NOT EXISTS holiday_surcharge NUMERIC(5, 2) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS lesbaza.tariff_rates (
+    rate_id       SERIAL PRIMARY KEY,
+    tariff_id     INTEGER NOT NULL REFERENCES lesbaza.tariffs (tariff_id) ON DELETE CASCADE,
+    cottage_id    INTEGER REFERENCES lesbaza.cottages (cottage_id) ON DELETE CASCADE,
+    name          TEXT NOT NULL DEFAULT '',
+    start_date    TIMESTAMP,
+    end_date      TIMESTAMP,
+    price_per_day NUMERIC(10, 2) NOT NULL,
+    CHECK ((start_date IS NULL) = (end_date IS NULL) AND start_date <= end_date)
+);
+
+CREATE INDEX IF NOT EXISTS tariff_rates_tariff_idx
+    ON lesbaza.tariff_rates (tariff_id);
+
+CREATE TABLE IF NOT EXISTS lesbaza.holidays (
+    holiday_id   SERIAL PRIMARY KEY,
+    holiday_date TIMESTAMP NOT NULL UNIQUE,
+    name         TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,7 @@
+ALTER TABLE lesbaza.tariffs DROP COLUMN IF EXISTS extra_child_price;
+ALTER TABLE lesbaza.tariffs DROP COLUMN IF EXISTS extra_adult_price;
+ALTER TABLE lesbaza.tariffs DROP COLUMN IF EXISTS base_guests;
+ALTER TABLE lesbaza.bookings DROP COLUMN IF EXISTS children;
+ALTER TABLE lesbaza.bookings DROP COLUMN IF EXISTS adults;
+ALTER TABLE lesbaza.cottages DROP COLUMN IF EXISTS max_guests;
+ALTER TABLE lesbaza.cottages DROP COLUMN IF EXISTS max_adults;
@@ -0,0 +1,11 @@
+-- Вместимость домиков, состав гостей брони и доплата за гостей сверх
+-- включенных в цену тарифа. Ноль означает «без ограничения» / «не указано».
+ALTER TABLE lesbaza.cottages ADD COLUMN IF NOT EXISTS max_adults INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE lesbaza.cottages ADD COLUMN IF NOT EXISTS max_guests INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE lesbaza.bookings ADD COLUMN IF NOT EXISTS adults INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE lesbaza.bookings ADD COLUMN IF NOT EXISTS children INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE lesbaza.tariffs ADD COLUMN IF NOT EXISTS base_guests INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE lesbaza.tariffs ADD COLUMN IF NOT EXISTS extra_adult_price NUMERIC(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE lesbaza.tariffs ADD COLUMN IF NOT EXISTS extra_child_price NUMERIC(10, 2) NOT NULL DEFAULT 0;
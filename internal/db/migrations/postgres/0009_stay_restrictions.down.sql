@@ -0,0 +1 @@
+DROP TABLE IF EXISTS lesbaza.stay_restrictions;
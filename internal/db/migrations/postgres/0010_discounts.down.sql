@@ -0,0 +1,5 @@
+ALTER TABLE lesbaza.bookings DROP COLUMN IF EXISTS discount_amount;
+ALTER TABLE lesbaza.bookings DROP COLUMN IF EXISTS discount_code;
+ALTER TABLE lesbaza.bookings DROP COLUMN IF EXISTS discount_id;
+DROP TABLE IF EXISTS lesbaza.discount_tariffs;
+DROP TABLE IF EXISTS lesbaza.discounts;
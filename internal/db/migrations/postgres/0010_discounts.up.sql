@@ -0,0 +1,25 @@
+-- Промокоды на скидку: процент или сумма на бронь, срок действия, лимит
+-- использований и тарифы, к которым применяется промокод (нет строк — ко всем).
+CREATE TABLE IF NOT EXISTS lesbaza.discounts (
+    discount_id SERIAL PRIMARY KEY,
+    code        TEXT NOT NULL UNIQUE,
+    name        TEXT NOT NULL DEFAULT '',
+    kind        TEXT NOT NULL CHECK (kind IN ('percent', 'fixed')),
+    value       NUMERIC(10, 2) NOT NULL CHECK (value > 0),
+    valid_from  TIMESTAMP,
+    valid_to    TIMESTAMP,
+    max_uses    INTEGER NOT NULL DEFAULT 0 CHECK (max_uses >= 0),
+    used_count  INTEGER NOT NULL DEFAULT 0,
+    active      BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE IF NOT EXISTS lesbaza.discount_tariffs (
+    discount_id INTEGER NOT NULL REFERENCES lesbaza.discounts (discount_id) ON DELETE CASCADE,
+    tariff_id   INTEGER NOT NULL REFERENCES lesbaza.tariffs (tariff_id) ON DELETE CASCADE,
+    PRIMARY KEY (discount_id, tariff_id)
+);
+
+-- Скидка хранится в брони отдельной строкой и уже вычтена из total_cost
+ALTER TABLE lesbaza.bookings ADD COLUMN IF NOT EXISTS discount_id INTEGER REFERENCES lesbaza.discounts (discount_id);
+ALTER TABLE lesbaza.bookings ADD COLUMN IF NOT EXISTS discount_code TEXT NOT NULL DEFAULT '';
+ALTER TABLE lesbaza.bookings ADD COLUMN IF NOT EXISTS discount_amount NUMERIC(10, 2) NOT NULL DEFAULT 0;
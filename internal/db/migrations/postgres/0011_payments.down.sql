@@ -0,0 +1,4 @@
+ALTER TABLE lesbaza.bookings DROP COLUMN IF EXISTS deposit_amount;
+DROP INDEX IF EXISTS lesbaza.payments_paid_at_idx;
+DROP INDEX IF EXISTS lesbaza.payments_booking_idx;
+DROP TABLE IF EXISTS lesbaza.payments;
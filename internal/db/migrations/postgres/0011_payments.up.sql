@@ -0,0 +1,17 @@
+-- Журнал оплат броней: поступления и возвраты (отрицательная сумма).
+-- Предоплата, которую должен внести гость, хранится в брони.
+-- Журнал не теряется: бронь с оплатами удалить нельзя.
+CREATE TABLE IF NOT EXISTS lesbaza.payments (
+    payment_id SERIAL PRIMARY KEY,
+    booking_id INTEGER NOT NULL REFERENCES lesbaza.bookings (booking_id) ON DELETE RESTRICT,
+    amount     NUMERIC(10, 2) NOT NULL CHECK (amount <> 0),
+    method     TEXT NOT NULL CHECK (method IN ('cash', 'card', 'transfer')),
+    paid_at    TIMESTAMP NOT NULL,
+    operator   TEXT NOT NULL DEFAULT '',
+    note       TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS payments_booking_idx ON lesbaza.payments (booking_id);
+CREATE INDEX IF NOT EXISTS payments_paid_at_idx ON lesbaza.payments (paid_at);
+
+ALTER TABLE lesbaza.bookings ADD COLUMN IF NOT EXISTS deposit_amount NUMERIC(10, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+DELETE FROM lesbaza.payments WHERE kind = 'penalty';
+ALTER TABLE lesbaza.payments DROP COLUMN IF EXISTS kind;
+ALTER TABLE lesbaza.tariffs DROP COLUMN IF EXISTS cancellation_policy_id;
+DROP TABLE IF EXISTS lesbaza.cancellation_policies;
@@ -0,0 +1,19 @@
+ALTER TABLE lesbaza.payments
+    ALTER COLUMN amount TYPE NUMERIC(10, 2) USING amount / 100.0;
+
+ALTER TABLE lesbaza.booking_changes
+    ALTER COLUMN old_total_cost TYPE NUMERIC(10, 2) USING old_total_cost / 100.0,
+    ALTER COLUMN new_total_cost TYPE NUMERIC(10, 2) USING new_total_cost / 100.0;
+
+ALTER TABLE lesbaza.bookings
+    ALTER COLUMN total_cost TYPE NUMERIC(10, 2) USING total_cost / 100.0,
+    ALTER COLUMN discount_amount TYPE NUMERIC(10, 2) USING discount_amount / 100.0,
+    ALTER COLUMN deposit_amount TYPE NUMERIC(10, 2) USING deposit_amount / 100.0;
+
+ALTER TABLE lesbaza.tariff_rates
+    ALTER COLUMN price_per_day TYPE NUMERIC(10, 2) USING price_per_day / 100.0;
+
+ALTER TABLE lesbaza.tariffs
+    ALTER COLUMN price_per_day TYPE NUMERIC(10, 2) USING price_per_day / 100.0,
+    ALTER COLUMN extra_adult_price TYPE NUMERIC(10, 2) USING extra_adult_price / 100.0,
+    ALTER COLUMN extra_child_price TYPE NUMERIC(10, 2) USING extra_child_price / 100.0;
@@ -0,0 +1,22 @@
+-- Денежные суммы хранятся целым числом копеек: сложение и вычитание
+-- точные, округляются только доли (проценты скидок, предоплат, штрафов).
+-- Процентные столбцы и значение скидки discounts.value не меняются.
+ALTER TABLE lesbaza.tariffs
+    ALTER COLUMN price_per_day TYPE BIGINT USING round(price_per_day * 100)::BIGINT,
+    ALTER COLUMN extra_adult_price TYPE BIGINT USING round(extra_adult_price * 100)::BIGINT,
+    ALTER COLUMN extra_child_price TYPE BIGINT USING round(extra_child_price * 100)::BIGINT;
+
+ALTER TABLE lesbaza.tariff_rates
+    ALTER COLUMN price_per_day TYPE BIGINT USING round(price_per_day * 100)::BIGINT;
+
+ALTER TABLE lesbaza.bookings
+    ALTER COLUMN total_cost TYPE BIGINT USING round(total_cost * 100)::BIGINT,
+    ALTER COLUMN discount_amount TYPE BIGINT USING round(discount_amount * 100)::BIGINT,
+    ALTER COLUMN deposit_amount TYPE BIGINT USING round(deposit_amount * 100)::BIGINT;
+
+ALTER TABLE lesbaza.booking_changes
+    ALTER COLUMN old_total_cost TYPE BIGINT USING round(old_total_cost * 100)::BIGINT,
+    ALTER COLUMN new_total_cost TYPE BIGINT USING round(new_total_cost * 100)::BIGINT;
+
+ALTER TABLE lesbaza.payments
+    ALTER COLUMN amount TYPE BIGINT USING round(amount * 100)::BIGINT;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS lesbaza.invoices_booking_idx;
+DROP TABLE IF EXISTS lesbaza.invoices;
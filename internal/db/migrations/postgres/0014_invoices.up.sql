@@ -0,0 +1,17 @@
+-- Счета-квитанции гостям. Номер (seq) идет подряд в пределах года
+-- и не освобождается: при удалении брони счет остается без нее.
+CREATE TABLE IF NOT EXISTS lesbaza.invoices (
+    invoice_id SERIAL PRIMARY KEY,
+    booking_id INTEGER REFERENCES lesbaza.bookings (booking_id) ON DELETE SET NULL,
+    year       INTEGER NOT NULL,
+    seq        INTEGER NOT NULL CHECK (seq > 0),
+    number     TEXT NOT NULL UNIQUE,
+    issued_at  TIMESTAMP NOT NULL,
+    guest_name TEXT NOT NULL DEFAULT '',
+    total      BIGINT NOT NULL DEFAULT 0,
+    paid       BIGINT NOT NULL DEFAULT 0,
+    file_path  TEXT NOT NULL DEFAULT '',
+    UNIQUE (year, seq)
+);
+
+CREATE INDEX IF NOT EXISTS invoices_booking_idx ON lesbaza.invoices (booking_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS lesbaza.folio_items_booking_idx;
+DROP TABLE IF EXISTS lesbaza.folio_items;
+DROP TABLE IF EXISTS lesbaza.extras;
@@ -0,0 +1,10 @@
+ALTER TABLE lesbaza.discounts
+    DROP CONSTRAINT IF EXISTS discounts_amount_check,
+    DROP CONSTRAINT IF EXISTS discounts_value_check;
+
+UPDATE lesbaza.discounts SET value = amount / 100.0 WHERE kind = 'fixed';
+
+ALTER TABLE lesbaza.discounts
+    DROP COLUMN IF EXISTS amount,
+    ALTER COLUMN value DROP DEFAULT,
+    ADD CONSTRAINT discounts_value_check CHECK (value > 0);
@@ -0,0 +1,13 @@
+-- Сумма фиксированной скидки хранится целым числом копеек в amount,
+-- как остальные деньги; value остается только для процента скидки.
+ALTER TABLE lesbaza.discounts
+    ADD COLUMN IF NOT EXISTS amount BIGINT NOT NULL DEFAULT 0,
+    ALTER COLUMN value SET DEFAULT 0,
+    DROP CONSTRAINT IF EXISTS discounts_value_check;
+
+UPDATE lesbaza.discounts SET amount = round(value * 100)::BIGINT, value = 0
+WHERE kind = 'fixed';
+
+ALTER TABLE lesbaza.discounts
+    ADD CONSTRAINT discounts_value_check CHECK (kind <> 'percent' OR value > 0),
+    ADD CONSTRAINT discounts_amount_check CHECK (kind <> 'fixed' OR amount > 0);
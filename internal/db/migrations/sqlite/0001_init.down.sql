@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bookings;
+DROP TABLE IF EXISTS guests;
+DROP TABLE IF EXISTS tariffs;
+DROP TABLE IF EXISTS cottages;
@@ -0,0 +1,47 @@
+-- Базовая схема "Звуки Леса" для SQLite (одна машина, без сервера).
+-- Таблицы совпадают со схемой lesbaza в PostgreSQL.
+CREATE TABLE IF NOT EXISTS cottages (
+    cottage_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name       TEXT NOT NULL,
+    status     TEXT NOT NULL DEFAULT 'free'
+);
+
+CREATE TABLE IF NOT EXISTS tariffs (
+    tariff_id     INTEGER PRIMARY KEY AUTOINCREMENT,
+    name          TEXT NOT NULL,
+    price_per_day REAL NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS guests (
+    guest_id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    full_name          TEXT NOT NULL,
+    email              TEXT NOT NULL DEFAULT '',
+    phone              TEXT NOT NULL DEFAULT '',
+    cottage_id         INTEGER NOT NULL REFERENCES cottages (cottage_id),
+    document_scan_path TEXT NOT NULL DEFAULT '',
+    check_in_date      TIMESTAMP,
+    check_out_date     TIMESTAMP,
+    tariff_id          INTEGER REFERENCES tariffs (tariff_id)
+);
+
+CREATE TABLE IF NOT EXISTS bookings (
+    booking_id     INTEGER PRIMARY KEY AUTOINCREMENT,
+    cottage_id     INTEGER NOT NULL REFERENCES cottages (cottage_id),
+    guest_name     TEXT NOT NULL DEFAULT '',
+    phone          TEXT NOT NULL DEFAULT '',
+    email          TEXT NOT NULL DEFAULT '',
+    check_in_date  TIMESTAMP NOT NULL,
+    check_out_date TIMESTAMP NOT NULL,
+    status         TEXT NOT NULL DEFAULT 'booked',
+    created_at     TIMESTAMP NOT NULL,
+    notes          TEXT NOT NULL DEFAULT '',
+    tariff_id      INTEGER REFERENCES tariffs (tariff_id),
+    total_cost     REAL NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS bookings_cottage_dates_idx
+    ON bookings (cottage_id, check_in_date, check_out_date);
+CREATE INDEX IF NOT EXISTS bookings_status_idx
+    ON bookings (status);
+CREATE INDEX IF NOT EXISTS guests_cottage_idx
+    ON guests (cottage_id);
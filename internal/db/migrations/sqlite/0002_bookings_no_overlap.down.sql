@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS bookings_no_overlap_update;
+DROP TRIGGER IF EXISTS bookings_no_overlap_insert;
@@ -0,0 +1,63 @@
+-- Аналог ограничения bookings_no_overlap из PostgreSQL: триггеры
+-- отклоняют действующую бронь, пересекающуюся с другой бронью домика.
+--
+-- Триггеры не видят брони, пересекшиеся до миграции, поэтому она сначала
+-- проверяет, что таких нет, и иначе останавливается. Пары пересекающихся
+-- броней показывает запрос:
+--
+--   SELECT a.booking_id, b.booking_id FROM bookings a
+--   JOIN bookings b ON b.cottage_id = a.cottage_id AND b.booking_id > a.booking_id
+--       AND b.check_in_date < a.check_out_date AND b.check_out_date > a.check_in_date
+--   WHERE a.status IN ('booked', 'checked_in', 'temporary')
+--       AND b.status IN ('booked', 'checked_in', 'temporary');
+--
+-- Одну бронь из каждой пары нужно перенести или отменить
+-- (UPDATE bookings SET status = 'cancelled' WHERE booking_id = ...)
+-- и запустить миграцию снова. RAISE в SQLite принимает только строку,
+-- поэтому номера броней в сообщение не попадают.
+CREATE TEMP TABLE bookings_overlap_check (conflicts INTEGER NOT NULL);
+
+CREATE TEMP TRIGGER bookings_overlap_check_abort
+BEFORE INSERT ON bookings_overlap_check
+WHEN NEW.conflicts > 0
+BEGIN
+    SELECT RAISE(ABORT, 'overlapping active bookings: list them with the query from migration 0002_bookings_no_overlap, move or cancel one booking of each pair and run the migration again');
+END;
+
+INSERT INTO bookings_overlap_check
+SELECT COUNT(*) FROM bookings a
+JOIN bookings b ON b.cottage_id = a.cottage_id AND b.booking_id > a.booking_id
+    AND b.check_in_date < a.check_out_date AND b.check_out_date > a.check_in_date
+WHERE a.status IN ('booked', 'checked_in', 'temporary')
+    AND b.status IN ('booked', 'checked_in', 'temporary');
+
+DROP TABLE bookings_overlap_check;
+
+CREATE TRIGGER IF NOT EXISTS bookings_no_overlap_insert
+BEFORE INSERT ON bookings
+WHEN NEW.status IN ('booked', 'checked_in', 'temporary')
+BEGIN
+    SELECT RAISE(ABORT, 'bookings_no_overlap')
+    WHERE EXISTS (
+        SELECT 1 FROM bookings b
+        WHERE b.cottage_id = NEW.cottage_id
+        AND b.status IN ('booked', 'checked_in', 'temporary')
+        AND b.check_in_date < NEW.check_out_date
+        AND b.check_out_date > NEW.check_in_date
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS bookings_no_overlap_update
+BEFORE UPDATE OF cottage_id, check_in_date, check_out_date, status ON bookings
+WHEN NEW.status IN ('booked', 'checked_in', 'temporary')
+BEGIN
+    SELECT RAISE(ABORT, 'bookings_no_overlap')
+    WHERE EXISTS (
+        SELECT 1 FROM bookings b
+        WHERE b.cottage_id = NEW.cottage_id
+        AND b.booking_id <> NEW.booking_id
+        AND b.status IN ('booked', 'checked_in', 'temporary')
+        AND b.check_in_date < NEW.check_out_date
+        AND b.check_out_date > NEW.check_in_date
+    );
+END;
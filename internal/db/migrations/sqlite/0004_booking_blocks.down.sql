@@ -0,0 +1,33 @@
+DROP TRIGGER IF EXISTS bookings_no_overlap_update;
+DROP TRIGGER IF EXISTS bookings_no_overlap_insert;
+
+CREATE TRIGGER IF NOT EXISTS bookings_no_overlap_insert
+BEFORE INSERT ON bookings
+WHEN NEW.status IN ('booked', 'checked_in', 'temporary')
+BEGIN
+    SELECT RAISE(ABORT, 'bookings_no_overlap')
+    WHERE EXISTS (
+        SELECT 1 FROM bookings b
+        WHERE b.cottage_id = NEW.cottage_id
+        AND b.status IN ('booked', 'checked_in', 'temporary')
+        AND b.check_in_date < NEW.check_out_date
+        AND b.check_out_date > NEW.check_in_date
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS bookings_no_overlap_update
+BEFORE UPDATE OF cottage_id, check_in_date, check_out_date, status ON bookings
+WHEN NEW.status IN ('booked', 'checked_in', 'temporary')
+BEGIN
+    SELECT RAISE(ABORT, 'bookings_no_overlap')
+    WHERE EXISTS (
+        SELECT 1 FROM bookings b
+        WHERE b.cottage_id = NEW.cottage_id
+        AND b.booking_id <> NEW.booking_id
+        AND b.status IN ('booked', 'checked_in', 'temporary')
+        AND b.check_in_date < NEW.check_out_date
+        AND b.check_out_date > NEW.check_in_date
+    );
+END;
+
+ALTER TABLE bookings DROP COLUMN block_reason;
@@ -0,0 +1,36 @@
+-- Блокировка домика на обслуживание хранится как бронь со статусом blocked
+-- и причиной (repair, cleaning, owner). Блокировка занимает домик так же,
+-- как действующая бронь, поэтому триггеры bookings_no_overlap учитывают её.
+ALTER TABLE bookings ADD COLUMN block_reason TEXT NOT NULL DEFAULT '';
+
+DROP TRIGGER IF EXISTS bookings_no_overlap_update;
+DROP TRIGGER IF EXISTS bookings_no_overlap_insert;
+
+CREATE TRIGGER IF NOT EXISTS bookings_no_overlap_insert
+BEFORE INSERT ON bookings
+WHEN NEW.status IN ('booked', 'checked_in', 'temporary', 'blocked')
+BEGIN
+    SELECT RAISE(ABORT, 'bookings_no_overlap')
+    WHERE EXISTS (
+        SELECT 1 FROM bookings b
+        WHERE b.cottage_id = NEW.cottage_id
+        AND b.status IN ('booked', 'checked_in', 'temporary', 'blocked')
+        AND b.check_in_date < NEW.check_out_date
+        AND b.check_out_date > NEW.check_in_date
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS bookings_no_overlap_update
+BEFORE UPDATE OF cottage_id, check_in_date, check_out_date, status ON bookings
+WHEN NEW.status IN ('booked', 'checked_in', 'temporary', 'blocked')
+BEGIN
+    SELECT RAISE(ABORT, 'bookings_no_overlap')
+    WHERE EXISTS (
+        SELECT 1 FROM bookings b
+        WHERE b.cottage_id = NEW.cottage_id
+        AND b.booking_id <> NEW.booking_id
+        AND b.status IN ('booked', 'checked_in', 'temporary', 'blocked')
+        AND b.check_in_date < NEW.check_out_date
+        AND b.check_out_date > NEW.check_in_date
+    );
+END;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS bookings_group_idx;
+ALTER TABLE bookings DROP COLUMN group_id;
+DROP TABLE IF EXISTS booking_groups;
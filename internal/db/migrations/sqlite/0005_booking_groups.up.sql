@@ -0,0 +1,17 @@
+-- Групповая бронь: несколько домиков на одного плательщика.
+-- Брони группы ссылаются на нее через group_id.
+CREATE TABLE IF NOT EXISTS booking_groups (
+    group_id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    name       TEXT NOT NULL DEFAULT '',
+    payer_name TEXT NOT NULL,
+    phone      TEXT NOT NULL DEFAULT '',
+    email      TEXT NOT NULL DEFAULT '',
+    notes      TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE bookings ADD COLUMN group_id INTEGER
+    REFERENCES booking_groups (group_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS bookings_group_idx
+    ON bookings (group_id);
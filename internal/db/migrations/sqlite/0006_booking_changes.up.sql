@@ -0,0 +1,21 @@
+-- Журнал изменений брони: перенос дат, смена домика или тарифа
+-- с пересчетом стоимости.
+CREATE TABLE IF NOT EXISTS booking_changes (
+    change_id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id         INTEGER NOT NULL REFERENCES bookings (booking_id) ON DELETE CASCADE,
+    changed_at         TIMESTAMP NOT NULL,
+    old_cottage_id     INTEGER NOT NULL,
+    new_cottage_id     INTEGER NOT NULL,
+    old_check_in_date  TIMESTAMP NOT NULL,
+    new_check_in_date  TIMESTAMP NOT NULL,
+    old_check_out_date TIMESTAMP NOT NULL,
+    new_check_out_date TIMESTAMP NOT NULL,
+    old_tariff_id      INTEGER,
+    new_tariff_id      INTEGER,
+    old_total_cost     REAL NOT NULL DEFAULT 0,
+    new_total_cost     REAL NOT NULL DEFAULT 0,
+    reason             TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS booking_changes_booking_idx
+    ON booking_changes (booking_id);
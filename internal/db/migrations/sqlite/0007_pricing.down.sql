@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS holidays;
+DROP TABLE IF EXISTS tariff_rates;
+ALTER TABLE tariffs DROP COLUMN holiday_surcharge;
+ALTER TABLE tariffs DROP COLUMN weekend_surcharge;
@@ -0,0 +1,25 @@
+-- Сезонные цены и наценки. Цена ночи берется из самой точной записи
+-- tariff_rates (сезон для домика, сезон, цена домика), иначе из тарифа;
+-- на выходные и праздники тариф добавляет наценку в процентах.
+ALTER TABLE tariffs ADD COLUMN weekend_surcharge REAL NOT NULL DEFAULT 0;
+ALTER TABLE tariffs ADD COLUMN holiday_surcharge REAL NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS tariff_rates (
+    rate_id       INTEGER PRIMARY KEY AUTOINCREMENT,
+    tariff_id     INTEGER NOT NULL REFERENCES tariffs (tariff_id) ON DELETE CASCADE,
+    cottage_id    INTEGER REFERENCES cottages (cottage_id) ON DELETE CASCADE,
+    name          TEXT NOT NULL DEFAULT '',
+    start_date    TIMESTAMP,
+    end_date      TIMESTAMP,
+    price_per_day REAL NOT NULL,
+    CHECK ((start_date IS NULL) = (end_date IS NULL) AND start_date <= end_date)
+);
+
+CREATE INDEX IF NOT EXISTS tariff_rates_tariff_idx
+    ON tariff_rates (tariff_id);
+
+CREATE TABLE IF NOT EXISTS holidays (
+    holiday_id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    holiday_date TIMESTAMP NOT NULL UNIQUE,
+    name         TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,7 @@
+ALTER TABLE tariffs DROP COLUMN extra_child_price;
+ALTER TABLE tariffs DROP COLUMN extra_adult_price;
+ALTER TABLE tariffs DROP COLUMN base_guests;
+ALTER TABLE bookings DROP COLUMN children;
+ALTER TABLE bookings DROP COLUMN adults;
+ALTER TABLE cottages DROP COLUMN max_guests;
+ALTER TABLE cottages DROP COLUMN max_adults;
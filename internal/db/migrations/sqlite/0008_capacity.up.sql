@@ -0,0 +1,11 @@
+-- Вместимость домиков, состав гостей брони и доплата за гостей сверх
+-- включенных в цену тарифа. Ноль означает «без ограничения» / «не указано».
+ALTER TABLE cottages ADD COLUMN max_adults INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE cottages ADD COLUMN max_guests INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE bookings ADD COLUMN adults INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE bookings ADD COLUMN children INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE tariffs ADD COLUMN base_guests INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tariffs ADD COLUMN extra_adult_price REAL NOT NULL DEFAULT 0;
+ALTER TABLE tariffs ADD COLUMN extra_child_price REAL NOT NULL DEFAULT 0;
@@ -0,0 +1,16 @@
+-- Ограничения сроков брони: минимальный срок проживания при заезде
+-- в период, запрет заезда и запрет выезда. Без домика — для всех домиков.
+CREATE TABLE IF NOT EXISTS stay_restrictions (
+    restriction_id      INTEGER PRIMARY KEY AUTOINCREMENT,
+    cottage_id          INTEGER REFERENCES cottages (cottage_id) ON DELETE CASCADE,
+    name                TEXT NOT NULL DEFAULT '',
+    start_date          TIMESTAMP NOT NULL,
+    end_date            TIMESTAMP NOT NULL,
+    min_nights          INTEGER NOT NULL DEFAULT 0,
+    closed_to_arrival   BOOLEAN NOT NULL DEFAULT 0,
+    closed_to_departure BOOLEAN NOT NULL DEFAULT 0,
+    CHECK (start_date <= end_date AND min_nights >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS stay_restrictions_dates_idx
+    ON stay_restrictions (start_date, end_date);
@@ -0,0 +1,5 @@
+ALTER TABLE bookings DROP COLUMN discount_amount;
+ALTER TABLE bookings DROP COLUMN discount_code;
+ALTER TABLE bookings DROP COLUMN discount_id;
+DROP TABLE IF EXISTS discount_tariffs;
+DROP TABLE IF EXISTS discounts;
@@ -0,0 +1,25 @@
+-- Промокоды на скидку: процент или сумма на бронь, срок действия, лимит
+-- использований и тарифы, к которым применяется промокод (нет строк — ко всем).
+CREATE TABLE IF NOT EXISTS discounts (
+    discount_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    code        TEXT NOT NULL UNIQUE,
+    name        TEXT NOT NULL DEFAULT '',
+    kind        TEXT NOT NULL CHECK (kind IN ('percent', 'fixed')),
+    value       REAL NOT NULL CHECK (value > 0),
+    valid_from  TIMESTAMP,
+    valid_to    TIMESTAMP,
+    max_uses    INTEGER NOT NULL DEFAULT 0 CHECK (max_uses >= 0),
+    used_count  INTEGER NOT NULL DEFAULT 0,
+    active      BOOLEAN NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS discount_tariffs (
+    discount_id INTEGER NOT NULL REFERENCES discounts (discount_id) ON DELETE CASCADE,
+    tariff_id   INTEGER NOT NULL REFERENCES tariffs (tariff_id) ON DELETE CASCADE,
+    PRIMARY KEY (discount_id, tariff_id)
+);
+
+-- Скидка хранится в брони отдельной строкой и уже вычтена из total_cost
+ALTER TABLE bookings ADD COLUMN discount_id INTEGER REFERENCES discounts (discount_id);
+ALTER TABLE bookings ADD COLUMN discount_code TEXT NOT NULL DEFAULT '';
+ALTER TABLE bookings ADD COLUMN discount_amount REAL NOT NULL DEFAULT 0;
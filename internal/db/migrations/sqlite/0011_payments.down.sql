@@ -0,0 +1,4 @@
+ALTER TABLE bookings DROP COLUMN deposit_amount;
+DROP INDEX IF EXISTS payments_paid_at_idx;
+DROP INDEX IF EXISTS payments_booking_idx;
+DROP TABLE IF EXISTS payments;
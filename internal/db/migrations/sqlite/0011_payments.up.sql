@@ -0,0 +1,17 @@
+-- Журнал оплат броней: поступления и возвраты (отрицательная сумма).
+-- Предоплата, которую должен внести гость, хранится в брони.
+-- Журнал не теряется: бронь с оплатами удалить нельзя.
+CREATE TABLE IF NOT EXISTS payments (
+    payment_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id INTEGER NOT NULL REFERENCES bookings (booking_id) ON DELETE RESTRICT,
+    amount     REAL NOT NULL CHECK (amount <> 0),
+    method     TEXT NOT NULL CHECK (method IN ('cash', 'card', 'transfer')),
+    paid_at    TIMESTAMP NOT NULL,
+    operator   TEXT NOT NULL DEFAULT '',
+    note       TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS payments_booking_idx ON payments (booking_id);
+CREATE INDEX IF NOT EXISTS payments_paid_at_idx ON payments (paid_at);
+
+ALTER TABLE bookings ADD COLUMN deposit_amount REAL NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+DELETE FROM payments WHERE kind = 'penalty';
+ALTER TABLE payments DROP COLUMN kind;
+ALTER TABLE tariffs DROP COLUMN cancellation_policy_id;
+DROP TABLE IF EXISTS cancellation_policies;
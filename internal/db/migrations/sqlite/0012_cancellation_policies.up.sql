@@ -0,0 +1,19 @@
+-- Правила отмены: бесплатная отмена не позднее чем за free_days дней
+-- до заезда, затем штраф penalty_percent от стоимости; невозвратный тариф
+-- удерживает всю стоимость. Правила назначаются тарифам.
+CREATE TABLE IF NOT EXISTS cancellation_policies (
+    policy_id       INTEGER PRIMARY KEY AUTOINCREMENT,
+    name            TEXT NOT NULL UNIQUE,
+    free_days       INTEGER NOT NULL DEFAULT 0 CHECK (free_days >= 0),
+    penalty_percent REAL NOT NULL DEFAULT 0 CHECK (penalty_percent BETWEEN 0 AND 100),
+    non_refundable  BOOLEAN NOT NULL DEFAULT 0
+);
+
+ALTER TABLE tariffs ADD COLUMN cancellation_policy_id INTEGER
+    REFERENCES cancellation_policies (policy_id) ON DELETE SET NULL;
+
+-- Вид записи журнала оплат: оплата, возврат гостю или штраф по правилам
+-- отмены. Штраф — не движение денег, а сумма, которую гость должен.
+ALTER TABLE payments ADD COLUMN kind TEXT NOT NULL DEFAULT 'payment'
+    CHECK (kind IN ('payment', 'refund', 'penalty'));
+UPDATE payments SET kind = 'refund' WHERE amount < 0;
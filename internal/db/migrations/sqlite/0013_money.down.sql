@@ -0,0 +1,14 @@
+UPDATE payments SET amount = amount / 100.0;
+
+UPDATE booking_changes SET old_total_cost = old_total_cost / 100.0,
+    new_total_cost = new_total_cost / 100.0;
+
+UPDATE bookings SET total_cost = total_cost / 100.0,
+    discount_amount = discount_amount / 100.0,
+    deposit_amount = deposit_amount / 100.0;
+
+UPDATE tariff_rates SET price_per_day = price_per_day / 100.0;
+
+UPDATE tariffs SET price_per_day = price_per_day / 100.0,
+    extra_adult_price = extra_adult_price / 100.0,
+    extra_child_price = extra_child_price / 100.0;
@@ -0,0 +1,18 @@
+-- Денежные суммы хранятся целым числом копеек: сложение и вычитание
+-- точные, округляются только доли (проценты скидок, предоплат, штрафов).
+-- SQLite не меняет тип столбца без пересоздания таблицы, поэтому столбцы
+-- остаются REAL: целые копейки в нем представлены точно.
+UPDATE tariffs SET price_per_day = ROUND(price_per_day * 100),
+    extra_adult_price = ROUND(extra_adult_price * 100),
+    extra_child_price = ROUND(extra_child_price * 100);
+
+UPDATE tariff_rates SET price_per_day = ROUND(price_per_day * 100);
+
+UPDATE bookings SET total_cost = ROUND(total_cost * 100),
+    discount_amount = ROUND(discount_amount * 100),
+    deposit_amount = ROUND(deposit_amount * 100);
+
+UPDATE booking_changes SET old_total_cost = ROUND(old_total_cost * 100),
+    new_total_cost = ROUND(new_total_cost * 100);
+
+UPDATE payments SET amount = ROUND(amount * 100);
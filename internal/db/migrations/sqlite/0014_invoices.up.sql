@@ -0,0 +1,17 @@
+-- Счета-квитанции гостям. Номер (seq) идет подряд в пределах года
+-- и не освобождается: при удалении брони счет остается без нее.
+CREATE TABLE IF NOT EXISTS invoices (
+    invoice_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id INTEGER REFERENCES bookings (booking_id) ON DELETE SET NULL,
+    year       INTEGER NOT NULL,
+    seq        INTEGER NOT NULL CHECK (seq > 0),
+    number     TEXT NOT NULL UNIQUE,
+    issued_at  TIMESTAMP NOT NULL,
+    guest_name TEXT NOT NULL DEFAULT '',
+    total      REAL NOT NULL DEFAULT 0,
+    paid       REAL NOT NULL DEFAULT 0,
+    file_path  TEXT NOT NULL DEFAULT '',
+    UNIQUE (year, seq)
+);
+
+CREATE INDEX IF NOT EXISTS invoices_booking_idx ON invoices (booking_id);
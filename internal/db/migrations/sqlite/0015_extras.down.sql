@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS folio_items_booking_idx;
+DROP TABLE IF EXISTS folio_items;
+DROP TABLE IF EXISTS extras;
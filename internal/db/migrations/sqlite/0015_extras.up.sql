@@ -0,0 +1,24 @@
+-- Справочник платных услуг: дрова, баня, прокат, поздний выезд.
+CREATE TABLE IF NOT EXISTS extras (
+    extra_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name     TEXT NOT NULL UNIQUE,
+    unit     TEXT NOT NULL DEFAULT '',
+    price    REAL NOT NULL DEFAULT 0 CHECK (price >= 0),
+    active   BOOLEAN NOT NULL DEFAULT 1
+);
+
+-- Фолио брони: услуги, заказанные гостем. Название и цена копируются
+-- из справочника на момент заказа; стоимость услуг входит в итог брони.
+-- Бронь с услугами удалить нельзя.
+CREATE TABLE IF NOT EXISTS folio_items (
+    item_id      INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id   INTEGER NOT NULL REFERENCES bookings (booking_id) ON DELETE RESTRICT,
+    extra_id     INTEGER REFERENCES extras (extra_id) ON DELETE SET NULL,
+    title        TEXT NOT NULL,
+    quantity     INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price   REAL NOT NULL CHECK (unit_price >= 0),
+    service_date TIMESTAMP NOT NULL,
+    created_at   TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS folio_items_booking_idx ON folio_items (booking_id);
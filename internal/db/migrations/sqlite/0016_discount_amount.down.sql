@@ -0,0 +1,38 @@
+CREATE TEMP TABLE discount_bookings AS
+    SELECT booking_id, discount_id FROM bookings WHERE discount_id IS NOT NULL;
+CREATE TEMP TABLE discount_tariff_links AS
+    SELECT discount_id, tariff_id FROM discount_tariffs;
+UPDATE bookings SET discount_id = NULL WHERE discount_id IS NOT NULL;
+
+CREATE TABLE discounts_old (
+    discount_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    code        TEXT NOT NULL UNIQUE,
+    name        TEXT NOT NULL DEFAULT '',
+    kind        TEXT NOT NULL CHECK (kind IN ('percent', 'fixed')),
+    value       REAL NOT NULL CHECK (value > 0),
+    valid_from  TIMESTAMP,
+    valid_to    TIMESTAMP,
+    max_uses    INTEGER NOT NULL DEFAULT 0 CHECK (max_uses >= 0),
+    used_count  INTEGER NOT NULL DEFAULT 0,
+    active      BOOLEAN NOT NULL DEFAULT 1
+);
+
+INSERT INTO discounts_old (discount_id, code, name, kind, value,
+    valid_from, valid_to, max_uses, used_count, active)
+SELECT discount_id, code, name, kind,
+    CASE WHEN kind = 'fixed' THEN amount / 100.0 ELSE value END,
+    valid_from, valid_to, max_uses, used_count, active
+FROM discounts;
+
+DROP TABLE discounts;
+ALTER TABLE discounts_old RENAME TO discounts;
+
+INSERT INTO discount_tariffs (discount_id, tariff_id)
+SELECT discount_id, tariff_id FROM discount_tariff_links;
+UPDATE bookings SET discount_id = (
+    SELECT l.discount_id FROM discount_bookings l WHERE l.booking_id = bookings.booking_id
+)
+WHERE booking_id IN (SELECT booking_id FROM discount_bookings);
+
+DROP TABLE discount_bookings;
+DROP TABLE discount_tariff_links;
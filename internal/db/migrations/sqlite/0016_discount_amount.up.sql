@@ -0,0 +1,45 @@
+-- Сумма фиксированной скидки хранится целым числом копеек в amount,
+-- как остальные деньги; value остается только для процента скидки.
+-- SQLite не меняет ограничения столбца без пересоздания таблицы.
+-- Внешние ключи в транзакции не отключить, поэтому ссылки броней и тарифов
+-- на промокоды на время пересоздания переносятся во временные таблицы.
+CREATE TEMP TABLE discount_bookings AS
+    SELECT booking_id, discount_id FROM bookings WHERE discount_id IS NOT NULL;
+CREATE TEMP TABLE discount_tariff_links AS
+    SELECT discount_id, tariff_id FROM discount_tariffs;
+UPDATE bookings SET discount_id = NULL WHERE discount_id IS NOT NULL;
+
+CREATE TABLE discounts_new (
+    discount_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    code        TEXT NOT NULL UNIQUE,
+    name        TEXT NOT NULL DEFAULT '',
+    kind        TEXT NOT NULL CHECK (kind IN ('percent', 'fixed')),
+    value       REAL NOT NULL DEFAULT 0 CHECK (kind <> 'percent' OR value > 0),
+    amount      REAL NOT NULL DEFAULT 0 CHECK (kind <> 'fixed' OR amount > 0),
+    valid_from  TIMESTAMP,
+    valid_to    TIMESTAMP,
+    max_uses    INTEGER NOT NULL DEFAULT 0 CHECK (max_uses >= 0),
+    used_count  INTEGER NOT NULL DEFAULT 0,
+    active      BOOLEAN NOT NULL DEFAULT 1
+);
+
+INSERT INTO discounts_new (discount_id, code, name, kind, value, amount,
+    valid_from, valid_to, max_uses, used_count, active)
+SELECT discount_id, code, name, kind,
+    CASE WHEN kind = 'fixed' THEN 0 ELSE value END,
+    CASE WHEN kind = 'fixed' THEN ROUND(value * 100) ELSE 0 END,
+    valid_from, valid_to, max_uses, used_count, active
+FROM discounts;
+
+DROP TABLE discounts;
+ALTER TABLE discounts_new RENAME TO discounts;
+
+INSERT INTO discount_tariffs (discount_id, tariff_id)
+SELECT discount_id, tariff_id FROM discount_tariff_links;
+UPDATE bookings SET discount_id = (
+    SELECT l.discount_id FROM discount_bookings l WHERE l.booking_id = bookings.booking_id
+)
+WHERE booking_id IN (SELECT booking_id FROM discount_bookings);
+
+DROP TABLE discount_bookings;
+DROP TABLE discount_tariff_links;
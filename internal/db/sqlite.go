@@ -0,0 +1,50 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/VallfIK/bazaotdx/internal/config"
+	_ "modernc.org/sqlite"
+)
+
+type SQLiteDB struct {
+	*sql.DB
+}
+
+// NewSQLiteDB открывает (и при необходимости создает) файл базы SQLite.
+// Транзакции начинаются с BEGIN IMMEDIATE, чтобы параллельные записи
+// ждали друг друга (busy_timeout), а не падали на середине.
+func NewSQLiteDB(cfg config.DatabaseConfig) (*SQLiteDB, error) {
+	if dir := filepath.Dir(cfg.SQLitePath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
+	}
+
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_txlock", "immediate")
+	dsn := "file:" + cfg.SQLitePath + "?" + params.Encode()
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime.Duration)
+
+	if err = ping(db, cfg.ConnectTimeout.Duration); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open database %s: %w", cfg.SQLitePath, err)
+	}
+
+	return &SQLiteDB{db}, nil
+}
@@ -0,0 +1,83 @@
+// internal/export/csv.go
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// utf8BOM помечает файл как UTF-8, иначе Excel открывает кириллицу
+// в кодировке Windows-1251
+const utf8BOM = "\ufeff"
+
+// WriteCSV выгружает таблицы в CSV для Excel с русскими настройками:
+// UTF-8 с BOM, разделитель «;», десятичная запятая. Несколько таблиц
+// идут друг за другом под своими заголовками через пустую строку.
+func WriteCSV(w io.Writer, tables ...Table) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = ';'
+	cw.UseCRLF = true
+	for i, t := range tables {
+		if len(tables) > 1 {
+			if i > 0 {
+				cw.Write(nil)
+			}
+			cw.Write([]string{t.Title})
+		}
+		cw.Write(t.Columns)
+		for _, row := range t.Rows {
+			record := make([]string, len(row))
+			for j, v := range row {
+				record[j] = csvCell(v)
+			}
+			cw.Write(record)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+// SaveCSV сохраняет таблицы в CSV-файл path
+func SaveCSV(path string, tables ...Table) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := WriteCSV(f, tables...); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// csvCell печатает ячейку так, чтобы Excel распознал числа и даты
+func csvCell(v any) string {
+	switch v := v.(type) {
+	case float64:
+		return decimalComma(strconv.FormatFloat(v, 'f', 2, 64))
+	case Percent:
+		return decimalComma(strconv.FormatFloat(float64(v), 'f', 1, 64))
+	case models.Money:
+		return decimalComma(v.Decimal())
+	default:
+		return FormatCell(v)
+	}
+}
+
+// decimalComma заменяет десятичную точку запятой
+func decimalComma(s string) string {
+	return strings.Replace(s, ".", ",", 1)
+}
@@ -0,0 +1,62 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// testTables — две таблицы со всеми видами ячеек
+func testTables() []Table {
+	return []Table{
+		{
+			Title:   "Брони",
+			Columns: []string{"Гость", "Сумма", "Заполняемость", "Заезд", "Ночей", "ALOS"},
+			Rows: [][]any{
+				{"Иванов", models.NewMoney(150050), Percent(75.5), time.Date(2026, time.February, 1, 14, 0, 0, 0, time.Local), 3, 2.5},
+				{"Петров; мл.", models.NewMoney(-20000), Percent(0), time.Time{}, nil, 0.0},
+			},
+		},
+		{
+			Title:   "Тарифы",
+			Columns: []string{"Тариф", "Цена"},
+			Rows:    [][]any{{"Стандарт", models.Rubles(3000)}},
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testTables()...); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte{0xEF, 0xBB, 0xBF}) {
+		t.Errorf("csv starts with % x, want UTF-8 BOM", buf.Bytes()[:3])
+	}
+
+	want := utf8BOM +
+		"Брони\r\n" +
+		"Гость;Сумма;Заполняемость;Заезд;Ночей;ALOS\r\n" +
+		"Иванов;1500,50;75,5;01.02.2026;3;2,50\r\n" +
+		"\"Петров; мл.\";-200,00;0,0;;;0,00\r\n" +
+		"\r\n" +
+		"Тарифы\r\n" +
+		"Тариф;Цена\r\n" +
+		"Стандарт;3000,00\r\n"
+	if got := buf.String(); got != want {
+		t.Errorf("csv =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestWriteCSVSingleTable(t *testing.T) {
+	// Одна таблица выгружается без строки с названием
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testTables()[1]); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if got, want := buf.String(), utf8BOM + "Тариф;Цена\r\nСтандарт;3000,00\r\n"; got != want {
+		t.Errorf("csv = %q, want %q", got, want)
+	}
+}
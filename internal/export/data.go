@@ -0,0 +1,100 @@
+// internal/export/data.go
+package export
+
+import (
+	"fmt"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// BookingsTable раскладывает брони в таблицу с названиями домиков и
+// тарифов и всеми суммами брони
+func BookingsTable(bookings []models.Booking, cottages []models.Cottage, tariffs []models.Tariff) Table {
+	t := Table{
+		Title: "Брони",
+		Columns: []string{
+			"№", "Домик", "Гость", "Телефон", "Email", "Заезд", "Выезд", "Статус",
+			"Тариф", "Взрослых", "Детей", "Проживание", "Скидка", "Промокод",
+			"Услуги", "Итого", "Предоплата", "Создана", "Примечание",
+		},
+	}
+	cottageName, tariffName := cottageNames(cottages), tariffNames(tariffs)
+	for _, b := range bookings {
+		t.Rows = append(t.Rows, []any{
+			b.ID, cottageName(b.CottageID), b.GuestName, b.Phone, b.Email,
+			b.CheckInDate, b.CheckOutDate, models.BookingStatusTitle(b.Status),
+			tariffName(b.TariffID), b.Adults, b.Children, b.TotalCost, b.DiscountAmount, b.DiscountCode,
+			b.ExtrasTotal, b.Total(), b.DepositAmount, b.CreatedAt, b.Notes,
+		})
+	}
+	return t
+}
+
+// GuestsTable раскладывает зарегистрированных гостей в таблицу
+func GuestsTable(guests []models.Guest, cottages []models.Cottage, tariffs []models.Tariff) Table {
+	t := Table{
+		Title:   "Гости",
+		Columns: []string{"№", "ФИО", "Телефон", "Email", "Домик", "Заезд", "Выезд", "Тариф"},
+	}
+	cottageName, tariffName := cottageNames(cottages), tariffNames(tariffs)
+	for _, g := range guests {
+		t.Rows = append(t.Rows, []any{
+			g.ID, g.FullName, g.Phone, g.Email, cottageName(g.CottageID),
+			g.CheckInDate, g.CheckOutDate, tariffName(g.TariffID),
+		})
+	}
+	return t
+}
+
+// TariffsTable раскладывает тарифы с ценами и доплатами в таблицу
+func TariffsTable(tariffs []models.Tariff) Table {
+	t := Table{
+		Title: "Тарифы",
+		Columns: []string{
+			"Тариф", "Цена за ночь", "Наценка выходных", "Наценка праздников",
+			"Гостей в цене", "Доплата за взрослого", "Доплата за ребенка",
+		},
+	}
+	for _, tr := range tariffs {
+		var baseGuests any = "все"
+		if tr.BaseGuests > 0 {
+			baseGuests = tr.BaseGuests
+		}
+		t.Rows = append(t.Rows, []any{
+			tr.Name, tr.PricePerDay, Percent(tr.WeekendSurcharge), Percent(tr.HolidaySurcharge),
+			baseGuests, tr.ExtraAdultPrice, tr.ExtraChildPrice,
+		})
+	}
+	return t
+}
+
+// cottageNames возвращает поиск названия домика по номеру
+func cottageNames(cottages []models.Cottage) func(id int) string {
+	names := make(map[int]string, len(cottages))
+	for _, c := range cottages {
+		names[c.ID] = c.Name
+	}
+	return func(id int) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+		return fmt.Sprintf("Домик #%d", id)
+	}
+}
+
+// tariffNames возвращает поиск названия тарифа по номеру; 0 — без тарифа
+func tariffNames(tariffs []models.Tariff) func(id int) string {
+	names := make(map[int]string, len(tariffs))
+	for _, t := range tariffs {
+		names[t.ID] = t.Name
+	}
+	return func(id int) string {
+		if id == 0 {
+			return ""
+		}
+		if name, ok := names[id]; ok {
+			return name
+		}
+		return fmt.Sprintf("Тариф #%d", id)
+	}
+}
@@ -0,0 +1,102 @@
+// internal/export/reports.go
+package export
+
+import (
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// occupancyColumns — столбцы счетчиков ночей в таблицах заполняемости
+var occupancyColumns = []string{"Продано ночей", "Заблокировано", "Доступно", "Заполняемость"}
+
+// OccupancyTables раскладывает отчет о заполняемости на таблицы:
+// по домикам с итогом, по дням и по месяцам
+func OccupancyTables(r *models.OccupancyReport) []Table {
+	cottages := Table{
+		Title:   "По домикам",
+		Columns: append([]string{"Домик"}, occupancyColumns...),
+	}
+	for _, c := range r.Cottages {
+		cottages.Rows = append(cottages.Rows, occupancyRow(c.Name, c.OccupancyStats))
+	}
+	cottages.Rows = append(cottages.Rows, occupancyRow("Итого", r.Total))
+
+	days := Table{
+		Title:   "По дням",
+		Columns: append([]string{"Дата"}, occupancyColumns...),
+	}
+	for _, d := range r.Days {
+		days.Rows = append(days.Rows, occupancyRow(d.Start, d.OccupancyStats))
+	}
+
+	months := Table{
+		Title:   "По месяцам",
+		Columns: append([]string{"Месяц"}, occupancyColumns...),
+	}
+	for _, m := range r.Months {
+		months.Rows = append(months.Rows, occupancyRow(monthTitle(m.Start), m.OccupancyStats))
+	}
+
+	return []Table{cottages, days, months}
+}
+
+// occupancyRow собирает строку таблицы заполняемости с подписью label
+func occupancyRow(label any, s models.OccupancyStats) []any {
+	return []any{label, s.Sold, s.Blocked, s.Available, Percent(s.Percent())}
+}
+
+// revenueColumns — столбцы показателей в таблицах выручки
+var revenueColumns = []string{
+	"Выручка", "Услуги", "Продано ночей", "ADR", "RevPAR",
+	"Заездов", "ALOS, ночей", "Срок брони, дн.", "Отмены",
+}
+
+// RevenueTables раскладывает отчет о выручке на таблицы: по месяцам,
+// по дням, по домикам и по тарифам. У тарифов нет доступных ночей,
+// поэтому RevPAR для них не выводится.
+func RevenueTables(r *models.RevenueReport) []Table {
+	months := Table{
+		Title:   "По месяцам",
+		Columns: append([]string{"Месяц"}, revenueColumns...),
+	}
+	for _, m := range r.Months {
+		months.Rows = append(months.Rows, revenueRow(monthTitle(m.Start), m.RevenueStats))
+	}
+	months.Rows = append(months.Rows, revenueRow("Итого", r.Total))
+
+	days := Table{
+		Title:   "По дням",
+		Columns: append([]string{"Дата"}, revenueColumns...),
+	}
+	for _, d := range r.Days {
+		days.Rows = append(days.Rows, revenueRow(d.Start, d.RevenueStats))
+	}
+
+	cottages := Table{
+		Title:   "По домикам",
+		Columns: append([]string{"Домик"}, revenueColumns...),
+	}
+	for _, c := range r.Cottages {
+		cottages.Rows = append(cottages.Rows, revenueRow(c.Name, c.RevenueStats))
+	}
+	cottages.Rows = append(cottages.Rows, revenueRow("Итого", r.Total))
+
+	tariffs := Table{
+		Title:   "По тарифам",
+		Columns: append([]string{"Тариф"}, revenueColumns...),
+	}
+	for _, t := range r.Tariffs {
+		row := revenueRow(t.Name, t.RevenueStats)
+		row[5] = nil
+		tariffs.Rows = append(tariffs.Rows, row)
+	}
+
+	return []Table{months, days, cottages, tariffs}
+}
+
+// revenueRow собирает строку таблицы выручки с подписью label
+func revenueRow(label any, s models.RevenueStats) []any {
+	return []any{
+		label, s.Revenue, s.Extras, s.Sold, s.ADR(), s.RevPAR(),
+		s.Arrivals, s.ALOS(), s.LeadTime(), Percent(s.CancellationRate()),
+	}
+}
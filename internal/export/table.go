@@ -0,0 +1,73 @@
+// internal/export/table.go
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// Table — таблица отчета для показа и выгрузки: заголовок, названия
+// столбцов и строки. Ячейки — string, int, float64, Percent, models.Money
+// или time.Time (дата); nil — пустая ячейка.
+type Table struct {
+	Title   string
+	Columns []string
+	Rows    [][]any
+}
+
+// Percent — ячейка с процентом, например заполняемостью
+type Percent float64
+
+// FormatCell печатает ячейку для показа в интерфейсе
+func FormatCell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	case Percent:
+		return strconv.FormatFloat(float64(v), 'f', 1, 64) + "%"
+	case models.Money:
+		return v.String()
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format("02.01.2006")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Save сохраняет таблицы в файл path в формате по расширению:
+// .xlsx — книгой Excel, .csv — CSV
+func Save(path string, tables ...Table) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xlsx":
+		return SaveXLSX(path, tables...)
+	case ".csv":
+		return SaveCSV(path, tables...)
+	default:
+		return fmt.Errorf("unsupported export format %q: use .csv or .xlsx", filepath.Ext(path))
+	}
+}
+
+// monthNames — названия месяцев в строках отчетов
+var monthNames = []string{
+	"", "Январь", "Февраль", "Март", "Апрель", "Май", "Июнь",
+	"Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь",
+}
+
+// monthTitle печатает месяц отчета, например «Март 2026»
+func monthTitle(t time.Time) string {
+	return fmt.Sprintf("%s %d", monthNames[t.Month()], t.Year())
+}
@@ -0,0 +1,197 @@
+// internal/export/xlsx.go
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// maxSheetName — предельная длина имени листа Excel
+const maxSheetName = 31
+
+// xlsxStyles — форматы ячеек книги: заголовок, деньги, дробь, процент, дата
+type xlsxStyles struct {
+	header, money, number, percent, date int
+}
+
+// WriteXLSX выгружает таблицы в книгу Excel — по листу на таблицу с
+// названием таблицы. Числа, суммы, проценты и даты записываются
+// значениями, а не текстом, чтобы по ним можно было считать.
+func WriteXLSX(w io.Writer, tables ...Table) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	styles, err := newXLSXStyles(f)
+	if err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+
+	used := make(map[string]bool)
+	for i, t := range tables {
+		name := sheetName(t.Title, i, used)
+		if i == 0 {
+			err = f.SetSheetName(f.GetSheetName(0), name)
+		} else {
+			_, err = f.NewSheet(name)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add sheet %q: %w", name, err)
+		}
+		if err := writeSheet(f, name, t, styles); err != nil {
+			return fmt.Errorf("failed to write sheet %q: %w", name, err)
+		}
+	}
+
+	if err := f.Write(w); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
+
+// SaveXLSX сохраняет таблицы в файл Excel path
+func SaveXLSX(path string, tables ...Table) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := WriteXLSX(f, tables...); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// newXLSXStyles регистрирует в книге форматы ячеек
+func newXLSXStyles(f *excelize.File) (xlsxStyles, error) {
+	var s xlsxStyles
+	var err error
+	custom := func(format string) (int, error) {
+		return f.NewStyle(&excelize.Style{CustomNumFmt: &format})
+	}
+	if s.header, err = f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}}); err != nil {
+		return s, err
+	}
+	if s.money, err = custom("#,##0.00"); err != nil {
+		return s, err
+	}
+	if s.number, err = custom("0.00"); err != nil {
+		return s, err
+	}
+	if s.percent, err = custom("0.0%"); err != nil {
+		return s, err
+	}
+	if s.date, err = custom("dd.mm.yyyy"); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// writeSheet записывает таблицу на лист: строка заголовков закреплена,
+// первый столбец шире остальных
+func writeSheet(f *excelize.File, sheet string, t Table, styles xlsxStyles) error {
+	for col, title := range t.Columns {
+		if err := setCell(f, sheet, col, 0, title, styles.header); err != nil {
+			return err
+		}
+	}
+	for row, values := range t.Rows {
+		for col, v := range values {
+			if err := writeCell(f, sheet, col, row+1, v, styles); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(t.Columns) > 0 {
+		last, err := excelize.ColumnNumberToName(len(t.Columns))
+		if err != nil {
+			return err
+		}
+		if err := f.SetColWidth(sheet, "A", last, 16); err != nil {
+			return err
+		}
+		if err := f.SetColWidth(sheet, "A", "A", 28); err != nil {
+			return err
+		}
+	}
+	return f.SetPanes(sheet, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+}
+
+// writeCell записывает ячейку значением подходящего типа и формата
+func writeCell(f *excelize.File, sheet string, col, row int, v any, styles xlsxStyles) error {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case float64:
+		return setCell(f, sheet, col, row, v, styles.number)
+	case Percent:
+		return setCell(f, sheet, col, row, float64(v)/100, styles.percent)
+	case models.Money:
+		return setCell(f, sheet, col, row, v.Float64(), styles.money)
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		// Excel хранит дату без часового пояса — записываем местный день
+		day := time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC)
+		return setCell(f, sheet, col, row, day, styles.date)
+	case string, int:
+		return setCell(f, sheet, col, row, v, 0)
+	default:
+		return setCell(f, sheet, col, row, FormatCell(v), 0)
+	}
+}
+
+// setCell записывает значение в ячейку по номерам столбца и строки с нуля
+func setCell(f *excelize.File, sheet string, col, row int, v any, style int) error {
+	cell, err := excelize.CoordinatesToCellName(col+1, row+1)
+	if err != nil {
+		return err
+	}
+	if err := f.SetCellValue(sheet, cell, v); err != nil {
+		return err
+	}
+	if style == 0 {
+		return nil
+	}
+	return f.SetCellStyle(sheet, cell, cell, style)
+}
+
+// sheetName делает из названия таблицы допустимое и неповторяющееся имя
+// листа: без запрещенных Excel символов и не длиннее 31 знака
+func sheetName(title string, i int, used map[string]bool) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if name == "" {
+		name = fmt.Sprintf("Лист%d", i+1)
+	}
+	if r := []rune(name); len(r) > maxSheetName {
+		name = string(r[:maxSheetName])
+	}
+
+	base := []rune(name)
+	for n := 2; used[strings.ToLower(name)]; n++ {
+		suffix := []rune(fmt.Sprintf(" (%d)", n))
+		if len(base)+len(suffix) > maxSheetName {
+			base = base[:maxSheetName-len(suffix)]
+		}
+		name = string(base) + string(suffix)
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
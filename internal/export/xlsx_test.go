@@ -0,0 +1,99 @@
+package export
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, testTables()...); err != nil {
+		t.Fatalf("write xlsx: %v", err)
+	}
+	f, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("open xlsx: %v", err)
+	}
+	defer f.Close()
+
+	if got := f.GetSheetList(); len(got) != 2 || got[0] != "Брони" || got[1] != "Тарифы" {
+		t.Fatalf("sheets = %v, want [Брони Тарифы]", got)
+	}
+
+	// Числа, суммы, проценты и даты — числовые ячейки со своим форматом
+	tests := []struct {
+		sheet, cell string
+		want        float64
+		format      string
+	}{
+		{"Брони", "B2", 1500.5, "#,##0.00"},
+		{"Брони", "B3", -200, "#,##0.00"},
+		{"Брони", "C2", 0.755, "0.0%"},
+		{"Брони", "D2", dateSerial(time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)), "dd.mm.yyyy"},
+		{"Брони", "E2", 3, ""},
+		{"Брони", "F2", 2.5, "0.00"},
+		{"Тарифы", "B2", 3000, "#,##0.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sheet+"!"+tt.cell, func(t *testing.T) {
+			typ, err := f.GetCellType(tt.sheet, tt.cell)
+			if err != nil {
+				t.Fatalf("cell type: %v", err)
+			}
+			if typ != excelize.CellTypeUnset && typ != excelize.CellTypeNumber {
+				t.Errorf("cell type = %v, want number", typ)
+			}
+			raw, err := f.GetCellValue(tt.sheet, tt.cell, excelize.Options{RawCellValue: true})
+			if err != nil {
+				t.Fatalf("cell value: %v", err)
+			}
+			got, err := strconv.ParseFloat(raw, 64)
+			if err != nil || got != tt.want {
+				t.Errorf("value = %q, want %v", raw, tt.want)
+			}
+			if format := cellFormat(t, f, tt.sheet, tt.cell); format != tt.format {
+				t.Errorf("format = %q, want %q", format, tt.format)
+			}
+		})
+	}
+
+	// Текст остается текстом, пустые ячейки не записываются
+	if got, _ := f.GetCellValue("Брони", "A3"); got != "Петров; мл." {
+		t.Errorf("A3 = %q, want guest name", got)
+	}
+	for _, cell := range []string{"D3", "E3"} {
+		if got, _ := f.GetCellValue("Брони", cell); got != "" {
+			t.Errorf("%s = %q, want empty", cell, got)
+		}
+	}
+	if got, _ := f.GetCellValue("Брони", "A1"); got != "Гость" {
+		t.Errorf("A1 = %q, want header", got)
+	}
+}
+
+// dateSerial возвращает дату в числовом представлении Excel
+func dateSerial(day time.Time) float64 {
+	epoch := time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	return day.Sub(epoch).Hours() / 24
+}
+
+// cellFormat возвращает пользовательский числовой формат ячейки ("" — нет)
+func cellFormat(t *testing.T, f *excelize.File, sheet, cell string) string {
+	t.Helper()
+	idx, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		t.Fatalf("cell style: %v", err)
+	}
+	style, err := f.GetStyle(idx)
+	if err != nil {
+		t.Fatalf("style %d: %v", idx, err)
+	}
+	if style.CustomNumFmt == nil {
+		return ""
+	}
+	return *style.CustomNumFmt
+}
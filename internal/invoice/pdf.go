@@ -0,0 +1,251 @@
+// internal/invoice/pdf.go
+package invoice
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/go-pdf/fpdf"
+)
+
+// Document — все, что печатается в счете-квитанции по брони
+type Document struct {
+	Invoice models.Invoice
+	Seller  models.LegalDetails
+	Booking models.Booking
+	Cottage string
+	Tariff  string
+	// Lines — начисления: проживание по ночам, услуги, скидка и штрафы
+	Lines    []models.InvoiceLine
+	Payments []models.Payment
+}
+
+// fontFamily — шрифт счета: NotoSans с кириллицей под лицензией
+// SIL Open Font License (fonts/OFL.txt), встроенный в программу
+const fontFamily = "NotoSans"
+
+var (
+	//go:embed fonts/NotoSans-Regular.ttf
+	fontRegular []byte
+	//go:embed fonts/NotoSans-Bold.ttf
+	fontBold []byte
+)
+
+// Ширина столбцов таблицы начислений, мм: №, наименование, кол-во, цена, сумма
+var columnWidths = []float64{10, 95, 20, 30, 30}
+
+// WritePDF формирует PDF счета и сохраняет его в path,
+// создавая недостающие папки
+func WritePDF(path string, doc Document) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Счет-квитанция № "+doc.Invoice.Number, true)
+	pdf.SetAuthor(models.ResortName, true)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", fontRegular)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", fontBold)
+	pdf.SetMargins(12.5, 15, 12.5)
+	pdf.AddPage()
+
+	writeHeader(pdf, doc)
+	writeStay(pdf, doc)
+	writeLines(pdf, doc)
+	writePayments(pdf, doc)
+	writeFooter(pdf)
+
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("failed to render invoice %s: %w", doc.Invoice.Number, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create invoice folder: %w", err)
+	}
+	if err := pdf.OutputFileAndClose(path); err != nil {
+		return fmt.Errorf("failed to save invoice %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeHeader печатает название базы, реквизиты продавца и номер счета
+func writeHeader(pdf *fpdf.Fpdf, doc Document) {
+	pdf.SetFont(fontFamily, "B", 18)
+	pdf.CellFormat(0, 9, models.ResortName, "", 1, "L", false, 0, "")
+
+	pdf.SetFont(fontFamily, "", 9)
+	for _, line := range sellerLines(doc.Seller) {
+		pdf.MultiCell(0, 4.5, line, "", "L", false)
+	}
+	pdf.Ln(6)
+
+	pdf.SetFont(fontFamily, "B", 14)
+	title := fmt.Sprintf("Счет-квитанция № %s от %s", doc.Invoice.Number, doc.Invoice.IssuedAt.Format("02.01.2006"))
+	pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+}
+
+// sellerLines собирает заполненные реквизиты продавца в строки
+func sellerLines(s models.LegalDetails) []string {
+	rows := [][]string{
+		{s.CompanyName},
+		{field("ИНН ", s.INN), field("КПП ", s.KPP), field("ОГРН ", s.OGRN)},
+		{s.Address},
+		{field("тел. ", s.Phone), s.Email},
+		{field("р/с ", s.Account), s.BankName, field("БИК ", s.BIK), field("к/с ", s.CorrAccount)},
+	}
+
+	var lines []string
+	for _, row := range rows {
+		var filled []string
+		for _, part := range row {
+			if part = strings.TrimSpace(part); part != "" {
+				filled = append(filled, part)
+			}
+		}
+		if len(filled) > 0 {
+			lines = append(lines, plainText(strings.Join(filled, ", ")))
+		}
+	}
+	return lines
+}
+
+// field подписывает реквизит; пустой реквизит остается пустым
+func field(label, value string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+	return label + value
+}
+
+// writeStay печатает гостя, домик и срок проживания
+func writeStay(pdf *fpdf.Fpdf, doc Document) {
+	b := doc.Booking
+	rows := [][2]string{
+		{"Гость", doc.Invoice.GuestName},
+		{"Телефон", b.Phone},
+		{"Домик", doc.Cottage},
+		{"Тариф", doc.Tariff},
+		{"Заезд", b.CheckInDate.Format("02.01.2006 15:04")},
+		{"Выезд", b.CheckOutDate.Format("02.01.2006 15:04")},
+	}
+	if b.Adults > 0 {
+		guests := strconv.Itoa(b.Adults) + " взр."
+		if b.Children > 0 {
+			guests += fmt.Sprintf(", %d дет.", b.Children)
+		}
+		rows = append(rows, [2]string{"Гостей", guests})
+	}
+
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		pdf.SetFont(fontFamily, "", 10)
+		pdf.CellFormat(30, 6, row[0]+":", "", 0, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "B", 10)
+		pdf.CellFormat(0, 6, plainText(row[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+}
+
+// writeLines печатает таблицу начислений и итог
+func writeLines(pdf *fpdf.Fpdf, doc Document) {
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.SetFillColor(232, 240, 232)
+	for i, title := range []string{"№", "Наименование", "Кол-во", "Цена", "Сумма"} {
+		pdf.CellFormat(columnWidths[i], 7, title, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont(fontFamily, "", 10)
+	for i, line := range doc.Lines {
+		pdf.CellFormat(columnWidths[0], 6.5, strconv.Itoa(i+1), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(columnWidths[1], 6.5, fitText(pdf, plainText(line.Title), columnWidths[1]-2), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(columnWidths[2], 6.5, strconv.Itoa(line.Quantity), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(columnWidths[3], 6.5, line.Price.Decimal(), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(columnWidths[4], 6.5, line.Amount.Decimal(), "1", 1, "R", false, 0, "")
+	}
+
+	pdf.SetFont(fontFamily, "B", 11)
+	labelWidth := columnWidths[0] + columnWidths[1] + columnWidths[2] + columnWidths[3]
+	pdf.CellFormat(labelWidth, 8, "Итого:", "", 0, "R", false, 0, "")
+	pdf.CellFormat(columnWidths[4], 8, doc.Invoice.Total.String(), "", 1, "R", false, 0, "")
+	pdf.Ln(4)
+}
+
+// fitText укорачивает текст, чтобы он поместился в ячейку шириной width
+func fitText(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// plainText убирает из текста эмодзи: их нет в шрифте, а символы
+// за пределами базовой плоскости Юникода fpdf не выводит
+func plainText(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r > 0xFFFF || unicode.Is(unicode.Variation_Selector, r) {
+			return -1
+		}
+		return r
+	}, text)
+	return strings.TrimSpace(text)
+}
+
+// writePayments печатает оплаты и остаток к оплате
+func writePayments(pdf *fpdf.Fpdf, doc Document) {
+	pdf.SetFont(fontFamily, "B", 11)
+	pdf.CellFormat(0, 7, "Оплаты", "", 1, "L", false, 0, "")
+
+	pdf.SetFont(fontFamily, "", 10)
+	shown := 0
+	for _, p := range doc.Payments {
+		if p.IsPenalty() {
+			continue
+		}
+		title := plainText(models.PaymentMethodTitle(p.Method))
+		if p.IsRefund() {
+			title = "Возврат, " + strings.ToLower(title)
+		}
+		pdf.CellFormat(35, 6, p.PaidAt.Format("02.01.2006 15:04"), "", 0, "L", false, 0, "")
+		pdf.CellFormat(120, 6, title, "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, p.Amount.Decimal(), "", 1, "R", false, 0, "")
+		shown++
+	}
+	if shown == 0 {
+		pdf.CellFormat(0, 6, "Оплат не поступало", "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(2)
+
+	pdf.SetFont(fontFamily, "B", 11)
+	pdf.CellFormat(155, 7, "Оплачено:", "", 0, "R", false, 0, "")
+	pdf.CellFormat(0, 7, doc.Invoice.Paid.String(), "", 1, "R", false, 0, "")
+
+	balance := doc.Invoice.Balance()
+	switch {
+	case balance.IsPositive():
+		pdf.CellFormat(155, 7, "К оплате:", "", 0, "R", false, 0, "")
+		pdf.CellFormat(0, 7, balance.String(), "", 1, "R", false, 0, "")
+	case balance.IsNegative():
+		pdf.CellFormat(155, 7, "Переплата:", "", 0, "R", false, 0, "")
+		pdf.CellFormat(0, 7, balance.Neg().String(), "", 1, "R", false, 0, "")
+	default:
+		pdf.CellFormat(0, 7, "Оплачено полностью", "", 1, "R", false, 0, "")
+	}
+	pdf.Ln(10)
+}
+
+// writeFooter печатает благодарность и место для подписи
+func writeFooter(pdf *fpdf.Fpdf) {
+	pdf.SetFont(fontFamily, "", 10)
+	pdf.CellFormat(0, 6, "Спасибо, что выбрали «"+models.ResortName+"»!", "", 1, "L", false, 0, "")
+	pdf.Ln(8)
+	pdf.CellFormat(0, 6, "Администратор ____________________", "", 1, "L", false, 0, "")
+}
@@ -14,7 +14,32 @@ type Booking struct {
 	CreatedAt    time.Time `db:"created_at"`
 	Notes        string    `db:"notes"`
 	TariffID     int       `db:"tariff_id"`
-	TotalCost    float64   `db:"total_cost"`
+	TotalCost    Money     `db:"total_cost"`
+	// HoldExpiresAt — окончание временного удержания (только для статуса temporary)
+	HoldExpiresAt time.Time `db:"hold_expires_at"`
+	// BlockReason — причина блокировки домика (только для статуса blocked)
+	BlockReason string `db:"block_reason"`
+	// GroupID — групповая бронь, в которую входит бронь (0 — одиночная)
+	GroupID int `db:"group_id"`
+	// Adults и Children — число взрослых и детей (0 и 0 — не указано)
+	Adults   int `db:"adults"`
+	Children int `db:"children"`
+	// DiscountID и DiscountCode — примененный промокод (0 — без скидки),
+	// DiscountAmount — скидка, уже вычтенная из TotalCost
+	DiscountID     int    `db:"discount_id"`
+	DiscountCode   string `db:"discount_code"`
+	DiscountAmount Money  `db:"discount_amount"`
+	// DepositAmount — предоплата, которую гость должен внести. Новой брони
+	// без предоплаты назначается DefaultDepositPercent от стоимости.
+	DepositAmount Money `db:"deposit_amount"`
+	// ExtrasTotal — стоимость услуг по фолио брони; в TotalCost не входит.
+	// Считается хранилищем при чтении брони.
+	ExtrasTotal Money `db:"extras_total"`
+}
+
+// Total возвращает стоимость брони вместе с услугами по фолио
+func (b Booking) Total() Money {
+	return b.TotalCost.Add(b.ExtrasTotal)
 }
 
 // BookingStatus константы для статусов
@@ -27,6 +52,49 @@ const (
 	BookingStatusCompleted = "completed" // Добавляем новый статус для завершенных бронирований
 )
 
+// ActiveBookingStatuses — статусы, при которых бронь занимает домик.
+// Должны совпадать с условием ограничения bookings_no_overlap в миграциях.
+var ActiveBookingStatuses = []string{
+	BookingStatusBooked,
+	BookingStatusCheckedIn,
+	BookingStatusTemporary,
+	BookingStatusBlocked,
+}
+
+// Причины блокировки домика
+const (
+	BlockReasonRepair   = "repair"   // ремонт
+	BlockReasonCleaning = "cleaning" // генеральная уборка
+	BlockReasonOwner    = "owner"    // проживание владельца
+)
+
+// BlockReasons перечисляет причины блокировки в порядке показа
+var BlockReasons = []string{BlockReasonRepair, BlockReasonCleaning, BlockReasonOwner}
+
+// BlockReasonTitle возвращает название причины блокировки для пользователя
+func BlockReasonTitle(reason string) string {
+	switch reason {
+	case BlockReasonRepair:
+		return "🔧 Ремонт"
+	case BlockReasonCleaning:
+		return "🧹 Уборка"
+	case BlockReasonOwner:
+		return "🏡 Владелец"
+	default:
+		return "⛔ Закрыто"
+	}
+}
+
+// IsActiveBookingStatus сообщает, занимает ли бронь с таким статусом домик
+func IsActiveBookingStatus(status string) bool {
+	for _, s := range ActiveBookingStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
 // CalendarDay представляет день в календаре
 type CalendarDay struct {
 	Date     time.Time
@@ -41,4 +109,6 @@ type BookingStatus struct {
 	IsPartDay  bool // true если это день заезда или выезда
 	IsCheckIn  bool // true если это день заезда
 	IsCheckOut bool // true если это день выезда
+	// BlockReason — причина, если день занят блокировкой
+	BlockReason string
 }
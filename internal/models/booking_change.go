@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// BookingModification — новые домик, даты и тариф брони для ModifyBooking
+type BookingModification struct {
+	CottageID    int
+	CheckInDate  time.Time
+	CheckOutDate time.Time
+	TariffID     int
+	Adults       int
+	Children     int
+	// Reason — причина изменения, попадает в журнал
+	Reason string
+}
+
+// BookingChange — запись журнала изменений брони: что было и что стало
+type BookingChange struct {
+	ID              int       `db:"change_id"`
+	BookingID       int       `db:"booking_id"`
+	ChangedAt       time.Time `db:"changed_at"`
+	OldCottageID    int       `db:"old_cottage_id"`
+	NewCottageID    int       `db:"new_cottage_id"`
+	OldCheckInDate  time.Time `db:"old_check_in_date"`
+	NewCheckInDate  time.Time `db:"new_check_in_date"`
+	OldCheckOutDate time.Time `db:"old_check_out_date"`
+	NewCheckOutDate time.Time `db:"new_check_out_date"`
+	OldTariffID     int       `db:"old_tariff_id"`
+	NewTariffID     int       `db:"new_tariff_id"`
+	OldTotalCost    Money     `db:"old_total_cost"`
+	NewTotalCost    Money     `db:"new_total_cost"`
+	Reason          string    `db:"reason"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// BookingGroup объединяет брони нескольких домиков на одни даты под одним
+// контактом (семейный праздник, корпоратив). Плательщик один на всю группу.
+type BookingGroup struct {
+	ID        int       `db:"group_id"`
+	Name      string    `db:"name"`
+	PayerName string    `db:"payer_name"`
+	Phone     string    `db:"phone"`
+	Email     string    `db:"email"`
+	Notes     string    `db:"notes"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// BookingGroupView — группа вместе с бронями домиков
+type BookingGroupView struct {
+	Group    BookingGroup
+	Bookings []Booking
+}
+
+// TotalCost возвращает общую стоимость группы с услугами, без отмененных броней
+func (v BookingGroupView) TotalCost() Money {
+	total := NewMoney(0)
+	for _, b := range v.Bookings {
+		if b.Status != BookingStatusCancelled {
+			total = total.Add(b.Total())
+		}
+	}
+	return total
+}
+
+// Title возвращает название группы для списков
+func (g BookingGroup) Title() string {
+	if g.Name != "" {
+		return g.Name
+	}
+	return g.PayerName
+}
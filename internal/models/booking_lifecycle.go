@@ -0,0 +1,209 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BookingEvent — действие, переводящее бронь из одного статуса в другой
+type BookingEvent string
+
+const (
+	BookingEventCheckIn  BookingEvent = "check_in"  // заселение гостя
+	BookingEventCheckOut BookingEvent = "check_out" // выселение, бронь завершена
+	BookingEventCancel   BookingEvent = "cancel"    // отмена брони или снятие блокировки
+	BookingEventConfirm  BookingEvent = "confirm"   // удержание становится обычной бронью
+	BookingEventRelease  BookingEvent = "release"   // удержание снято вручную или по истечении срока
+)
+
+// ErrInvalidTransition — общая причина всех ошибок TransitionError,
+// проверяется через errors.Is
+var ErrInvalidTransition = errors.New("недопустимое изменение статуса брони")
+
+// TransitionError описывает отклоненный переход: действие не разрешено
+// из текущего статуса или не прошло проверку (Reason)
+type TransitionError struct {
+	BookingID int
+	Status    string
+	Event     BookingEvent
+	Reason    string
+}
+
+func (e *TransitionError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("нельзя %s: %s", e.Event.Title(), e.Reason)
+	}
+	return fmt.Sprintf("нельзя %s: бронь в статусе «%s»", e.Event.Title(), BookingStatusTitle(e.Status))
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// Title возвращает действие в форме для сообщений ("нельзя заселить гостя")
+func (e BookingEvent) Title() string {
+	switch e {
+	case BookingEventCheckIn:
+		return "заселить гостя"
+	case BookingEventCheckOut:
+		return "выселить гостя"
+	case BookingEventCancel:
+		return "отменить бронь"
+	case BookingEventConfirm:
+		return "подтвердить бронь"
+	case BookingEventRelease:
+		return "снять удержание"
+	default:
+		return "изменить статус брони"
+	}
+}
+
+// BookingStatusTitle возвращает название статуса для пользователя
+func BookingStatusTitle(status string) string {
+	switch status {
+	case BookingStatusBooked:
+		return "Забронировано"
+	case BookingStatusCheckedIn:
+		return "Заселено"
+	case BookingStatusCancelled:
+		return "Отменено"
+	case BookingStatusTemporary:
+		return "Временное"
+	case BookingStatusBlocked:
+		return "Заблокировано"
+	case BookingStatusCompleted:
+		return "Завершено"
+	default:
+		return "Неизвестно"
+	}
+}
+
+// BookingEffects — изменения вне брони, сопровождающие переход
+type BookingEffects struct {
+	// CottageStatus — новый статус домика ("" — не менять)
+	CottageStatus string
+	// CreateGuest — записать гостя брони в таблицу заселенных
+	CreateGuest bool
+	// RemoveGuest — удалить заселенного гостя домика
+	RemoveGuest bool
+}
+
+// BookingTransition — разрешенный переход между статусами
+type BookingTransition struct {
+	From    string
+	Event   BookingEvent
+	To      string
+	Effects BookingEffects
+	// Guard дополнительно проверяет бронь; непустая строка — причина отказа
+	Guard func(b Booking, now time.Time) string
+}
+
+// BookingLifecycle — конечный автомат статусов брони
+type BookingLifecycle struct {
+	transitions []BookingTransition
+}
+
+// NewBookingLifecycle создает автомат из списка переходов
+func NewBookingLifecycle(transitions ...BookingTransition) *BookingLifecycle {
+	return &BookingLifecycle{transitions: transitions}
+}
+
+// BookingStateMachine — жизненный цикл брони базы отдыха:
+//
+//	temporary ─confirm→ booked ─check_in→ checked_in ─check_out→ completed
+//	temporary ─release→ cancelled
+//	temporary, booked, blocked ─cancel→ cancelled
+//
+// completed и cancelled — конечные статусы.
+var BookingStateMachine = NewBookingLifecycle(
+	BookingTransition{
+		From:  BookingStatusBooked,
+		Event: BookingEventCheckIn,
+		To:    BookingStatusCheckedIn,
+		Effects: BookingEffects{
+			CottageStatus: "occupied",
+			CreateGuest:   true,
+		},
+		Guard: guardCheckInDate,
+	},
+	BookingTransition{
+		From:  BookingStatusCheckedIn,
+		Event: BookingEventCheckOut,
+		To:    BookingStatusCompleted,
+		Effects: BookingEffects{
+			CottageStatus: "free",
+			RemoveGuest:   true,
+		},
+	},
+	BookingTransition{From: BookingStatusBooked, Event: BookingEventCancel, To: BookingStatusCancelled},
+	BookingTransition{From: BookingStatusTemporary, Event: BookingEventCancel, To: BookingStatusCancelled},
+	BookingTransition{From: BookingStatusBlocked, Event: BookingEventCancel, To: BookingStatusCancelled},
+	BookingTransition{
+		From:  BookingStatusTemporary,
+		Event: BookingEventConfirm,
+		To:    BookingStatusBooked,
+		Guard: guardHoldNotExpired,
+	},
+	BookingTransition{From: BookingStatusTemporary, Event: BookingEventRelease, To: BookingStatusCancelled},
+)
+
+// guardHoldNotExpired не дает подтвердить удержание, срок которого истек
+func guardHoldNotExpired(b Booking, now time.Time) string {
+	if !b.HoldExpiresAt.IsZero() && !now.Before(b.HoldExpiresAt) {
+		return fmt.Sprintf("удержание истекло в %s", b.HoldExpiresAt.Format("15:04"))
+	}
+	return ""
+}
+
+// guardCheckInDate не дает заселить гостя до дня заезда или после дня выезда
+func guardCheckInDate(b Booking, now time.Time) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	checkIn := time.Date(b.CheckInDate.Year(), b.CheckInDate.Month(), b.CheckInDate.Day(), 0, 0, 0, 0, time.Local)
+	checkOut := time.Date(b.CheckOutDate.Year(), b.CheckOutDate.Month(), b.CheckOutDate.Day(), 0, 0, 0, 0, time.Local)
+
+	if today.Before(checkIn) {
+		return fmt.Sprintf("заезд только с %s", checkIn.Format("02.01.2006"))
+	}
+	if today.After(checkOut) {
+		return fmt.Sprintf("дата выезда %s уже прошла", checkOut.Format("02.01.2006"))
+	}
+	return ""
+}
+
+// Transition проверяет, можно ли применить событие к брони, и возвращает
+// переход. Ошибка всегда имеет тип *TransitionError.
+func (m *BookingLifecycle) Transition(b Booking, event BookingEvent, now time.Time) (BookingTransition, error) {
+	for _, t := range m.transitions {
+		if t.From != b.Status || t.Event != event {
+			continue
+		}
+		if t.Guard != nil {
+			if reason := t.Guard(b, now); reason != "" {
+				return BookingTransition{}, &TransitionError{BookingID: b.ID, Status: b.Status, Event: event, Reason: reason}
+			}
+		}
+		return t, nil
+	}
+	return BookingTransition{}, &TransitionError{BookingID: b.ID, Status: b.Status, Event: event}
+}
+
+// Can сообщает, разрешено ли событие из статуса (без учета проверок Guard)
+func (m *BookingLifecycle) Can(status string, event BookingEvent) bool {
+	for _, t := range m.transitions {
+		if t.From == status && t.Event == event {
+			return true
+		}
+	}
+	return false
+}
+
+// EventTo возвращает событие, переводящее бронь из from в to
+func (m *BookingLifecycle) EventTo(from, to string) (BookingEvent, bool) {
+	for _, t := range m.transitions {
+		if t.From == from && t.To == to {
+			return t.Event, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// CancellationPolicy — правила отмены брони, назначаемые тарифу. Те же
+// правила действуют на ночи, от которых гость отказывается при раннем выезде.
+type CancellationPolicy struct {
+	ID   int    `db:"policy_id"`
+	Name string `db:"name"`
+	// FreeDays — за сколько дней до заезда отмена еще бесплатна
+	FreeDays int `db:"free_days"`
+	// PenaltyPercent — штраф в процентах от стоимости при более поздней отмене
+	PenaltyPercent float64 `db:"penalty_percent"`
+	// NonRefundable — невозвратный тариф: удерживается вся стоимость
+	NonRefundable bool `db:"non_refundable"`
+}
+
+// Penalty возвращает штраф с суммы amount при отмене за daysBefore дней
+// до заезда (отрицательное число — после даты заезда)
+func (p CancellationPolicy) Penalty(amount Money, daysBefore int) Money {
+	switch {
+	case !amount.IsPositive():
+		return NewMoney(0)
+	case p.NonRefundable:
+		return amount
+	case daysBefore >= p.FreeDays:
+		return NewMoney(0)
+	default:
+		return amount.Percent(p.PenaltyPercent)
+	}
+}
+
+// Terms описывает правила для гостя
+func (p CancellationPolicy) Terms() string {
+	if p.NonRefundable {
+		return "невозвратный: стоимость не возвращается"
+	}
+	penalty := strconv.FormatFloat(p.PenaltyPercent, 'f', -1, 64) + "%"
+	if p.FreeDays == 0 {
+		return "бесплатная отмена до дня заезда включительно, позже — штраф " + penalty
+	}
+	return fmt.Sprintf("бесплатная отмена не позднее чем за %d дн. до заезда, позже — штраф %s", p.FreeDays, penalty)
+}
+
+// CancellationQuote — расчет с гостем при отмене брони или раннем выезде
+type CancellationQuote struct {
+	// Policy — название правил отмены ("" — тариф без штрафов)
+	Policy string
+	// Released — стоимость ночей, от которых отказывается гость;
+	// Penalty — штраф с нее по правилам
+	Released Money
+	Penalty  Money
+	// Stay — стоимость проживания после изменения (0 при отмене)
+	Stay Money
+	// Total — сколько гость должен после изменения, включая штрафы;
+	// Paid — сколько он уже заплатил
+	Total Money
+	Paid  Money
+}
+
+// Refund возвращает переплату, которую нужно вернуть гостю
+func (q CancellationQuote) Refund() Money {
+	return q.Paid.Sub(q.Total).Max(NewMoney(0))
+}
+
+// Due возвращает, сколько гость еще должен доплатить
+func (q CancellationQuote) Due() Money {
+	return q.Total.Sub(q.Paid).Max(NewMoney(0))
+}
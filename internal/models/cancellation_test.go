@@ -0,0 +1,38 @@
+package models
+
+import "testing"
+
+func TestCancellationPolicyPenalty(t *testing.T) {
+	strict := CancellationPolicy{FreeDays: 7, PenaltyPercent: 50}
+	sameDay := CancellationPolicy{FreeDays: 0, PenaltyPercent: 100}
+	nonRefundable := CancellationPolicy{FreeDays: 7, PenaltyPercent: 50, NonRefundable: true}
+
+	tests := []struct {
+		name       string
+		policy     CancellationPolicy
+		amount     Money
+		daysBefore int
+		want       Money
+	}{
+		{"before free period", strict, Rubles(3000), 8, Rubles(0)},
+		{"last free day", strict, Rubles(3000), 7, Rubles(0)},
+		{"first day with penalty", strict, Rubles(3000), 6, Rubles(1500)},
+		{"on arrival day", strict, Rubles(3000), 0, Rubles(1500)},
+		{"after arrival day", strict, Rubles(3000), -2, Rubles(1500)},
+		{"percent rounds to kopecks", strict, Money{Kopecks: 100001}, 0, Money{Kopecks: 50001}},
+		{"no free days, day before arrival", sameDay, Rubles(3000), 1, Rubles(0)},
+		// Без дней бесплатной отмены отменить бесплатно можно и в день заезда
+		{"no free days, arrival day", sameDay, Rubles(3000), 0, Rubles(0)},
+		{"no free days, after arrival", sameDay, Rubles(3000), -1, Rubles(3000)},
+		{"non-refundable long before", nonRefundable, Rubles(3000), 30, Rubles(3000)},
+		{"non-refundable on arrival day", nonRefundable, Rubles(3000), 0, Rubles(3000)},
+		{"nothing to charge", nonRefundable, Rubles(0), 0, Rubles(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Penalty(tt.amount, tt.daysBefore); got.Cmp(tt.want) != 0 {
+				t.Errorf("Penalty(%s, %d) = %s, want %s", tt.amount, tt.daysBefore, got, tt.want)
+			}
+		})
+	}
+}
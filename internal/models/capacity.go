@@ -0,0 +1,60 @@
+package models
+
+import "fmt"
+
+// Guests возвращает число гостей брони
+func (b Booking) Guests() int {
+	return b.Adults + b.Children
+}
+
+// Fits сообщает, вмещает ли домик adults взрослых и children детей
+func (c Cottage) Fits(adults, children int) bool {
+	if c.MaxAdults > 0 && adults > c.MaxAdults {
+		return false
+	}
+	if c.MaxGuests > 0 && adults+children > c.MaxGuests {
+		return false
+	}
+	return true
+}
+
+// CapacityTitle описывает вместимость домика, например «до 4 гостей, взрослых до 2»
+func (c Cottage) CapacityTitle() string {
+	switch {
+	case c.MaxGuests > 0 && c.MaxAdults > 0:
+		return fmt.Sprintf("до %d гостей, взрослых до %d", c.MaxGuests, c.MaxAdults)
+	case c.MaxGuests > 0:
+		return fmt.Sprintf("до %d гостей", c.MaxGuests)
+	case c.MaxAdults > 0:
+		return fmt.Sprintf("взрослых до %d", c.MaxAdults)
+	default:
+		return "без ограничения"
+	}
+}
+
+// ExtraGuests считает гостей сверх включенных в цену тарифа: места
+// в цене занимают сначала взрослые, затем дети
+func (t Tariff) ExtraGuests(adults, children int) (extraAdults, extraChildren int) {
+	if t.BaseGuests <= 0 {
+		return 0, 0
+	}
+	extraAdults = adults - t.BaseGuests
+	free := t.BaseGuests - adults
+	if extraAdults < 0 {
+		extraAdults = 0
+	}
+	if free < 0 {
+		free = 0
+	}
+	extraChildren = children - free
+	if extraChildren < 0 {
+		extraChildren = 0
+	}
+	return extraAdults, extraChildren
+}
+
+// ExtraGuestsPrice возвращает доплату за гостей сверх включенных в цену за одну ночь
+func (t Tariff) ExtraGuestsPrice(adults, children int) Money {
+	extraAdults, extraChildren := t.ExtraGuests(adults, children)
+	return t.ExtraAdultPrice.Mul(extraAdults).Add(t.ExtraChildPrice.Mul(extraChildren))
+}
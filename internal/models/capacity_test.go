@@ -0,0 +1,64 @@
+package models
+
+import "testing"
+
+func TestCottageFits(t *testing.T) {
+	tests := []struct {
+		name             string
+		maxAdults        int
+		maxGuests        int
+		adults, children int
+		want             bool
+	}{
+		{"no limits", 0, 0, 10, 10, true},
+		{"guests at limit", 0, 4, 2, 2, true},
+		{"guests over limit", 0, 4, 2, 3, false},
+		{"adults at limit", 2, 0, 2, 5, true},
+		{"adults over limit", 2, 0, 3, 0, false},
+		{"children fill free places", 2, 4, 1, 3, true},
+		{"adults within guests but over adults", 2, 4, 3, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cottage := Cottage{MaxAdults: tt.maxAdults, MaxGuests: tt.maxGuests}
+			if got := cottage.Fits(tt.adults, tt.children); got != tt.want {
+				t.Errorf("Fits(%d, %d) = %v, want %v", tt.adults, tt.children, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTariffExtraGuests(t *testing.T) {
+	// В цену входят двое, доплата 1000 ₽ за взрослого и 500 ₽ за ребенка
+	tariff := Tariff{BaseGuests: 2, ExtraAdultPrice: Rubles(1000), ExtraChildPrice: Rubles(500)}
+
+	tests := []struct {
+		name             string
+		adults, children int
+		wantAdults       int
+		wantChildren     int
+		wantPrice        int64
+	}{
+		{"within base", 2, 0, 0, 0, 0},
+		{"extra child", 2, 1, 0, 1, 500},
+		{"adults take places first", 1, 3, 0, 2, 1000},
+		{"extra adult and children", 3, 2, 1, 2, 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adults, children := tariff.ExtraGuests(tt.adults, tt.children)
+			if adults != tt.wantAdults || children != tt.wantChildren {
+				t.Errorf("ExtraGuests(%d, %d) = %d, %d, want %d, %d",
+					tt.adults, tt.children, adults, children, tt.wantAdults, tt.wantChildren)
+			}
+			if got := tariff.ExtraGuestsPrice(tt.adults, tt.children); got.Cmp(Rubles(tt.wantPrice)) != 0 {
+				t.Errorf("ExtraGuestsPrice(%d, %d) = %s, want %s", tt.adults, tt.children, got, Rubles(tt.wantPrice))
+			}
+		})
+	}
+
+	// Без BaseGuests все гости входят в цену
+	if got := (Tariff{ExtraAdultPrice: Rubles(1000)}).ExtraGuestsPrice(5, 5); !got.IsZero() {
+		t.Errorf("price without base guests = %s, want 0", got)
+	}
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Виды скидок
+const (
+	DiscountPercent = "percent" // процент от стоимости проживания
+	DiscountFixed   = "fixed"   // сумма на всю бронь
+)
+
+// Discount — промокод на скидку. Промокод применяется при создании брони,
+// скидка хранится в брони отдельной строкой.
+type Discount struct {
+	ID   int    `db:"discount_id"`
+	Code string `db:"code"`
+	Name string `db:"name"`
+	// Kind — DiscountPercent или DiscountFixed. Value — процент скидки
+	// DiscountPercent, FixedAmount — сумма скидки DiscountFixed.
+	Kind        string  `db:"kind"`
+	Value       float64 `db:"value"`
+	FixedAmount Money   `db:"amount"`
+	// ValidFrom и ValidTo — первый и последний день, когда промокод можно
+	// применить (без даты — без ограничения)
+	ValidFrom time.Time `db:"valid_from"`
+	ValidTo   time.Time `db:"valid_to"`
+	// MaxUses — сколько броней можно оформить по промокоду (0 — без ограничения)
+	MaxUses   int  `db:"max_uses"`
+	UsedCount int  `db:"used_count"`
+	Active    bool `db:"active"`
+	// TariffIDs — тарифы, к которым применяется промокод (пусто — ко всем)
+	TariffIDs []int
+}
+
+// NormalizeDiscountCode приводит промокод к виду, в котором он хранится
+func NormalizeDiscountCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ValidOn сообщает, действует ли промокод в день day
+func (d Discount) ValidOn(day time.Time) bool {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+	if !d.ValidFrom.IsZero() {
+		from := time.Date(d.ValidFrom.Year(), d.ValidFrom.Month(), d.ValidFrom.Day(), 0, 0, 0, 0, time.Local)
+		if day.Before(from) {
+			return false
+		}
+	}
+	if !d.ValidTo.IsZero() {
+		to := time.Date(d.ValidTo.Year(), d.ValidTo.Month(), d.ValidTo.Day(), 0, 0, 0, 0, time.Local)
+		if day.After(to) {
+			return false
+		}
+	}
+	return true
+}
+
+// Exhausted сообщает, исчерпан ли лимит использований промокода
+func (d Discount) Exhausted() bool {
+	return d.MaxUses > 0 && d.UsedCount >= d.MaxUses
+}
+
+// AppliesTo сообщает, действует ли промокод для тарифа
+func (d Discount) AppliesTo(tariffID int) bool {
+	if len(d.TariffIDs) == 0 {
+		return true
+	}
+	for _, id := range d.TariffIDs {
+		if id == tariffID {
+			return true
+		}
+	}
+	return false
+}
+
+// Amount возвращает скидку с суммы total; скидка не больше самой суммы
+func (d Discount) Amount(total Money) Money {
+	amount := d.FixedAmount
+	if d.Kind == DiscountPercent {
+		amount = total.Percent(d.Value)
+	}
+	return amount.Min(total)
+}
+
+// Title описывает размер скидки, например «−10%» или «−500.00 ₽»
+func (d Discount) Title() string {
+	if d.Kind == DiscountPercent {
+		return "−" + strconv.FormatFloat(d.Value, 'f', -1, 64) + "%"
+	}
+	return "−" + d.FixedAmount.String()
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// Extra — платная услуга из справочника: дрова, час бани, прокат
+// велосипеда, поздний выезд
+type Extra struct {
+	ID   int    `db:"extra_id"`
+	Name string `db:"name"`
+	// Unit — единица услуги для гостя: «шт.», «час», «сутки»
+	Unit  string `db:"unit"`
+	Price Money  `db:"price"`
+	// Active — услугу можно заказать; снятая с продажи остается в фолио
+	Active bool `db:"active"`
+}
+
+// FolioItem — строка фолио брони: услуга, заказанная гостем. Название
+// и цена копируются из справочника, чтобы не меняться вместе с ним.
+type FolioItem struct {
+	ID        int `db:"item_id"`
+	BookingID int `db:"booking_id"`
+	// ExtraID — услуга справочника (0 — услуга удалена из справочника)
+	ExtraID   int    `db:"extra_id"`
+	Title     string `db:"title"`
+	Quantity  int    `db:"quantity"`
+	UnitPrice Money  `db:"unit_price"`
+	// ServiceDate — день оказания услуги
+	ServiceDate time.Time `db:"service_date"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// Amount возвращает стоимость строки фолио
+func (i FolioItem) Amount() Money {
+	return i.UnitPrice.Mul(i.Quantity)
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ResortName — название базы отдыха в шапке счетов и квитанций
+const ResortName = "Звуки Леса"
+
+// Invoice — выставленный гостю счет-квитанция. Номера идут подряд
+// в пределах года и не повторяются, даже если бронь потом удалена.
+type Invoice struct {
+	ID int `db:"invoice_id"`
+	// BookingID — бронь счета (0 — бронь удалена)
+	BookingID int `db:"booking_id"`
+	// Year и Seq — год выставления и порядковый номер счета в нем
+	Year      int       `db:"year"`
+	Seq       int       `db:"seq"`
+	Number    string    `db:"number"`
+	IssuedAt  time.Time `db:"issued_at"`
+	GuestName string    `db:"guest_name"`
+	// Total — сумма счета, Paid — сколько было оплачено на момент выставления
+	Total Money `db:"total"`
+	Paid  Money `db:"paid"`
+	// FilePath — PDF-файл счета в папке документов
+	FilePath string `db:"file_path"`
+}
+
+// InvoiceNumber возвращает номер счета вида «2026-0001»
+func InvoiceNumber(year, seq int) string {
+	return fmt.Sprintf("%d-%04d", year, seq)
+}
+
+// Balance возвращает остаток к оплате по счету; отрицательный — переплата
+func (i Invoice) Balance() Money {
+	return i.Total.Sub(i.Paid)
+}
+
+// InvoiceLine — строка счета: ночи проживания, услуга, скидка или штраф
+type InvoiceLine struct {
+	Title    string
+	Quantity int
+	Price    Money
+	Amount   Money
+}
+
+// LegalDetails — реквизиты продавца в счетах. Незаполненные реквизиты
+// в счет не выводятся.
+type LegalDetails struct {
+	// CompanyName — юридическое лицо или ИП, например «ИП Иванов И. И.»
+	CompanyName string
+	INN         string
+	KPP         string
+	OGRN        string
+	Address     string
+	Phone       string
+	Email       string
+	BankName    string
+	BIK         string
+	Account     string
+	CorrAccount string
+}
@@ -6,6 +6,10 @@ type Cottage struct {
 	ID     int
 	Name   string
 	Status string
+	// MaxAdults и MaxGuests — сколько взрослых и всего гостей вмещает домик
+	// (0 — без ограничения)
+	MaxAdults int
+	MaxGuests int
 }
 
 type Guest struct {
@@ -21,7 +25,18 @@ type Guest struct {
 }
 
 type Tariff struct {
-	ID          int     `db:"tariff_id"`
-	Name        string  `db:"name"`
-	PricePerDay float64 `db:"price_per_day"`
+	ID          int    `db:"tariff_id"`
+	Name        string `db:"name"`
+	PricePerDay Money  `db:"price_per_day"`
+	// WeekendSurcharge и HolidaySurcharge — наценка в процентах
+	// на ночи выходных и праздников
+	WeekendSurcharge float64 `db:"weekend_surcharge"`
+	HolidaySurcharge float64 `db:"holiday_surcharge"`
+	// BaseGuests — сколько гостей входит в цену ночи (0 — все);
+	// за остальных берется доплата за ночь ExtraAdultPrice или ExtraChildPrice
+	BaseGuests      int   `db:"base_guests"`
+	ExtraAdultPrice Money `db:"extra_adult_price"`
+	ExtraChildPrice Money `db:"extra_child_price"`
+	// CancellationPolicyID — правила отмены броней по тарифу (0 — без штрафов)
+	CancellationPolicyID int `db:"cancellation_policy_id"`
 }
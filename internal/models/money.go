@@ -0,0 +1,254 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency — валюта, в которой ведутся все расчеты базы отдыха
+const DefaultCurrency = "RUB"
+
+// Money — денежная сумма в копейках (сотых долях единицы валюты).
+// Все суммы базы ведутся в одной валюте; пустой код означает DefaultCurrency.
+// Суммы в разных валютах нельзя складывать, вычитать и сравнивать:
+// Add, Sub, Cmp, Min и Max паникуют — это ошибка программы, а не данных.
+//
+// Правило округления одно для всех расчетов: доля суммы (процент наценки,
+// скидки, предоплаты или штрафа) округляется до копейки, половина копейки —
+// от нуля. Суммы складываются и вычитаются точно, без округления.
+type Money struct {
+	Kopecks  int64
+	Currency string
+}
+
+// NewMoney создает сумму из копеек в валюте базы
+func NewMoney(kopecks int64) Money {
+	return Money{Kopecks: kopecks, Currency: DefaultCurrency}
+}
+
+// Rubles создает сумму из целого числа рублей
+func Rubles(rubles int64) Money {
+	return NewMoney(rubles * 100)
+}
+
+// MoneyFromFloat переводит сумму в рублях в копейки по общему правилу округления
+func MoneyFromFloat(rubles float64) Money {
+	return NewMoney(int64(math.Round(rubles * 100)))
+}
+
+// ParseMoney читает сумму, введенную пользователем: «1500», «1 500,50»,
+// «1500.5», «-200». Знак допускается только один, перед суммой; рубли
+// и копейки — только цифры. Пустая строка — ноль.
+func ParseMoney(s string) (Money, error) {
+	input := s
+	s = strings.NewReplacer(" ", "", " ", "", ",", ".", "₽", "").Replace(strings.TrimSpace(s))
+	if s == "" {
+		return NewMoney(0), nil
+	}
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || len(frac) > 2 || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("неверная сумма: %q", input)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	rubles, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || rubles > (math.MaxInt64-99)/100 {
+		return Money{}, fmt.Errorf("неверная сумма: %q", input)
+	}
+	var kopecks int64
+	if frac != "" {
+		kopecks, _ = strconv.ParseInt(frac, 10, 64)
+		if len(frac) == 1 {
+			kopecks *= 10
+		}
+	}
+
+	m := NewMoney(rubles*100 + kopecks)
+	if negative {
+		m = m.Neg()
+	}
+	return m, nil
+}
+
+// isDigits сообщает, состоит ли s только из цифр 0–9 (пустая строка — да)
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// currency возвращает код валюты суммы
+func (m Money) currency() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+// mustMatch паникует, если суммы m и o в разных валютах
+func (m Money) mustMatch(o Money) {
+	if m.currency() != o.currency() {
+		panic(fmt.Sprintf("money: currency mismatch: %s and %s", m.currency(), o.currency()))
+	}
+}
+
+// Add возвращает сумму m и o
+func (m Money) Add(o Money) Money {
+	m.mustMatch(o)
+	return Money{Kopecks: m.Kopecks + o.Kopecks, Currency: m.currency()}
+}
+
+// Sub возвращает разность m и o
+func (m Money) Sub(o Money) Money {
+	m.mustMatch(o)
+	return Money{Kopecks: m.Kopecks - o.Kopecks, Currency: m.currency()}
+}
+
+// Neg возвращает сумму с обратным знаком
+func (m Money) Neg() Money {
+	return Money{Kopecks: -m.Kopecks, Currency: m.currency()}
+}
+
+// Mul возвращает сумму, умноженную на целое n (например, цену за n ночей)
+func (m Money) Mul(n int) Money {
+	return Money{Kopecks: m.Kopecks * int64(n), Currency: m.currency()}
+}
+
+// Div возвращает n-ю долю суммы (например, среднее за ночь), округленную
+// до копейки; при n <= 0 — ноль
+func (m Money) Div(n int) Money {
+	if n <= 0 {
+		return Money{Currency: m.currency()}
+	}
+	return Money{Kopecks: int64(math.Round(float64(m.Kopecks) / float64(n))), Currency: m.currency()}
+}
+
+// Split делит сумму на n долей, отличающихся не больше чем на копейку,
+// так что в сумме доли дают ровно m (лишние копейки — первым долям);
+// при n <= 0 — nil
+func (m Money) Split(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+	parts := make([]Money, n)
+	base, rest := m.Kopecks/int64(n), m.Kopecks%int64(n)
+	for i := range parts {
+		parts[i] = Money{Kopecks: base, Currency: m.currency()}
+		if int64(i) < rest {
+			parts[i].Kopecks++
+		} else if int64(i) < -rest {
+			parts[i].Kopecks--
+		}
+	}
+	return parts
+}
+
+// Percent возвращает percent процентов суммы, округленные до копейки
+func (m Money) Percent(percent float64) Money {
+	return Money{Kopecks: int64(math.Round(float64(m.Kopecks) * percent / 100)), Currency: m.currency()}
+}
+
+// Cmp сравнивает суммы: -1, если m меньше o, 0 — если равны, 1 — если больше
+func (m Money) Cmp(o Money) int {
+	m.mustMatch(o)
+	switch {
+	case m.Kopecks < o.Kopecks:
+		return -1
+	case m.Kopecks > o.Kopecks:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Min возвращает меньшую из сумм
+func (m Money) Min(o Money) Money {
+	m.mustMatch(o)
+	if o.Kopecks < m.Kopecks {
+		return o
+	}
+	return m
+}
+
+// Max возвращает большую из сумм
+func (m Money) Max(o Money) Money {
+	m.mustMatch(o)
+	if o.Kopecks > m.Kopecks {
+		return o
+	}
+	return m
+}
+
+// IsZero сообщает, равна ли сумма нулю
+func (m Money) IsZero() bool { return m.Kopecks == 0 }
+
+// IsPositive сообщает, больше ли сумма нуля
+func (m Money) IsPositive() bool { return m.Kopecks > 0 }
+
+// IsNegative сообщает, меньше ли сумма нуля
+func (m Money) IsNegative() bool { return m.Kopecks < 0 }
+
+// Float64 возвращает сумму в рублях для отношений и средних величин;
+// для расчета денег не используется
+func (m Money) Float64() float64 {
+	return float64(m.Kopecks) / 100
+}
+
+// Decimal печатает сумму без знака валюты, например «1500.50», —
+// в таком виде она подставляется в поля ввода
+func (m Money) Decimal() string {
+	sign := ""
+	k := m.Kopecks
+	if k < 0 {
+		sign, k = "-", -k
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, k/100, k%100)
+}
+
+// String печатает сумму со знаком валюты, например «1500.50 ₽»
+func (m Money) String() string {
+	symbol := m.currency()
+	if symbol == DefaultCurrency {
+		symbol = "₽"
+	}
+	return m.Decimal() + " " + symbol
+}
+
+// Value сохраняет сумму в базе целым числом копеек
+func (m Money) Value() (driver.Value, error) {
+	return m.Kopecks, nil
+}
+
+// Scan читает сумму в копейках. SQLite возвращает копейки из столбцов REAL
+// числом с плавающей точкой, PostgreSQL — целым.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*m = NewMoney(v)
+	case float64:
+		*m = NewMoney(int64(math.Round(v)))
+	case []byte:
+		return m.Scan(string(v))
+	case string:
+		k, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid money value %q: %w", v, err)
+		}
+		*m = NewMoney(k)
+	case nil:
+		*m = NewMoney(0)
+	default:
+		return fmt.Errorf("unexpected money value %T", src)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package models
+
+import "testing"
+
+func TestMoneyRejectsCurrencyMismatch(t *testing.T) {
+	rub, eur := Rubles(100), Money{Kopecks: 10000, Currency: "EUR"}
+
+	tests := []struct {
+		name string
+		op   func()
+	}{
+		{"Add", func() { rub.Add(eur) }},
+		{"Sub", func() { rub.Sub(eur) }},
+		{"Cmp", func() { rub.Cmp(eur) }},
+		{"Min", func() { eur.Min(rub) }},
+		{"Max", func() { eur.Max(rub) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s of RUB and EUR did not panic", tt.name)
+				}
+			}()
+			tt.op()
+		})
+	}
+}
+
+func TestMoneyEmptyCurrencyIsDefault(t *testing.T) {
+	// Нулевая сумма без кода валюты — это рубли
+	var zero Money
+	if got := zero.Add(Rubles(5)); got.Cmp(Rubles(5)) != 0 || got.Currency != DefaultCurrency {
+		t.Errorf("zero + 5 ₽ = %+v, want 5 ₽", got)
+	}
+	if got := Rubles(5).Sub(zero); got.Cmp(Rubles(5)) != 0 {
+		t.Errorf("5 ₽ - zero = %s, want 5 ₽", got)
+	}
+}
+
+func TestDiscountAmount(t *testing.T) {
+	total := Rubles(6000)
+	tests := []struct {
+		name     string
+		discount Discount
+		want     Money
+	}{
+		{"percent", Discount{Kind: DiscountPercent, Value: 12.5}, Rubles(750)},
+		{"fixed in kopecks", Discount{Kind: DiscountFixed, FixedAmount: NewMoney(50050)}, NewMoney(50050)},
+		{"fixed ignores value", Discount{Kind: DiscountFixed, Value: 10, FixedAmount: Rubles(100)}, Rubles(100)},
+		{"fixed above total", Discount{Kind: DiscountFixed, FixedAmount: Rubles(7000)}, total},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.discount.Amount(total); got.Cmp(tt.want) != 0 {
+				t.Errorf("Amount = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"1500", 150000, false},
+		{"1 500,50", 150050, false},
+		{"1\u00a0500,50", 150050, false},
+		{"1500.5", 150050, false},
+		{",5", 50, false},
+		{"7.", 700, false},
+		{"-200", -20000, false},
+		{"-0,01", -1, false},
+		{"1500 ₽", 150000, false},
+		{"--5", 0, true},
+		{"-+5", 0, true},
+		{"+5", 0, true},
+		{"1.+5", 0, true},
+		{"1.-5", 0, true},
+		{"1.2.3", 0, true},
+		{"1.234", 0, true},
+		{"5-", 0, true},
+		{"1e3", 0, true},
+		{"abc", 0, true},
+		{"-", 0, true},
+		{".", 0, true},
+		{"999999999999999999", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMoney(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseMoney(%q) = %s, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMoney(%q): %v", tt.in, err)
+			}
+			if want := NewMoney(tt.want); got.Cmp(want) != 0 {
+				t.Errorf("ParseMoney(%q) = %s, want %s", tt.in, got, want)
+			}
+		})
+	}
+}
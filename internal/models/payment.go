@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+)
+
+// Способы оплаты
+const (
+	PaymentMethodCash     = "cash"     // наличные
+	PaymentMethodCard     = "card"     // банковская карта
+	PaymentMethodTransfer = "transfer" // банковский перевод
+)
+
+// Виды записей журнала оплат
+const (
+	PaymentKindPayment = "payment" // оплата гостя
+	PaymentKindRefund  = "refund"  // возврат гостю
+	PaymentKindPenalty = "penalty" // штраф по правилам отмены
+)
+
+// PaymentMethods перечисляет способы оплаты в порядке показа
+var PaymentMethods = []string{PaymentMethodCash, PaymentMethodCard, PaymentMethodTransfer}
+
+// DefaultDepositPercent — предоплата по умолчанию, процент от стоимости брони
+const DefaultDepositPercent = 30
+
+// PaymentMethodTitle возвращает название способа оплаты для пользователя
+func PaymentMethodTitle(method string) string {
+	switch method {
+	case PaymentMethodCash:
+		return "💵 Наличные"
+	case PaymentMethodCard:
+		return "💳 Карта"
+	case PaymentMethodTransfer:
+		return "🏦 Перевод"
+	default:
+		return method
+	}
+}
+
+// Payment — запись журнала оплат брони
+type Payment struct {
+	ID        int `db:"payment_id"`
+	BookingID int `db:"booking_id"`
+	// Kind — PaymentKindPayment, PaymentKindRefund или PaymentKindPenalty
+	Kind string `db:"kind"`
+	// Amount — полученная сумма; возврат гостю записывается отрицательной
+	// суммой, штраф — положительной суммой, которую гость должен
+	Amount   Money     `db:"amount"`
+	Method   string    `db:"method"`
+	PaidAt   time.Time `db:"paid_at"`
+	Operator string    `db:"operator"`
+	Note     string    `db:"note"`
+}
+
+// IsRefund сообщает, является ли запись возвратом гостю
+func (p Payment) IsRefund() bool {
+	return p.Kind == PaymentKindRefund
+}
+
+// IsPenalty сообщает, является ли запись штрафом: она увеличивает долг
+// гостя, а не сумму полученных денег
+func (p Payment) IsPenalty() bool {
+	return p.Kind == PaymentKindPenalty
+}
+
+// PaymentSummary — расчеты с гостем по брони
+type PaymentSummary struct {
+	// Total — стоимость брони вместе с услугами и штрафами (у отмененной
+	// брони — только штрафы), Deposit — требуемая предоплата
+	Total   Money
+	Deposit Money
+	// Penalty — начисленные штрафы, уже учтенные в Total
+	Penalty Money
+	// Paid — получено за вычетом возвратов
+	Paid Money
+}
+
+// Balance возвращает остаток к оплате; отрицательный остаток — переплата
+func (s PaymentSummary) Balance() Money {
+	return s.Total.Sub(s.Paid)
+}
+
+// DepositDue возвращает, сколько еще не внесено из предоплаты
+func (s PaymentSummary) DepositDue() Money {
+	return s.Deposit.Sub(s.Paid).Max(NewMoney(0))
+}
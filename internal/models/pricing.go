@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+)
+
+// TariffRate — цена тарифа за ночь для отдельного домика и/или сезона.
+// Без домика цена действует для всех домиков, без дат — круглый год.
+type TariffRate struct {
+	ID        int    `db:"rate_id"`
+	TariffID  int    `db:"tariff_id"`
+	CottageID int    `db:"cottage_id"`
+	Name      string `db:"name"`
+	// StartDate и EndDate — первая и последняя ночь сезона включительно
+	StartDate   time.Time `db:"start_date"`
+	EndDate     time.Time `db:"end_date"`
+	PricePerDay Money     `db:"price_per_day"`
+}
+
+// Seasonal сообщает, ограничена ли цена датами
+func (r TariffRate) Seasonal() bool {
+	return !r.StartDate.IsZero()
+}
+
+// Covers сообщает, действует ли цена в ночь, начинающуюся в день night
+func (r TariffRate) Covers(night time.Time) bool {
+	if !r.Seasonal() {
+		return true
+	}
+	day := time.Date(night.Year(), night.Month(), night.Day(), 0, 0, 0, 0, time.Local)
+	start := time.Date(r.StartDate.Year(), r.StartDate.Month(), r.StartDate.Day(), 0, 0, 0, 0, time.Local)
+	end := time.Date(r.EndDate.Year(), r.EndDate.Month(), r.EndDate.Day(), 0, 0, 0, 0, time.Local)
+	return !day.Before(start) && !day.After(end)
+}
+
+// Holiday — праздничный день, ночь с которого оплачивается с наценкой
+type Holiday struct {
+	ID   int       `db:"holiday_id"`
+	Date time.Time `db:"holiday_date"`
+	Name string    `db:"name"`
+}
+
+// IsWeekendNight сообщает, приходится ли ночь на выходные
+// (с пятницы на субботу и с субботы на воскресенье)
+func IsWeekendNight(night time.Time) bool {
+	wd := night.Weekday()
+	return wd == time.Friday || wd == time.Saturday
+}
+
+// NightPrice — цена одной ночи проживания
+type NightPrice struct {
+	// Date — день, с которого начинается ночь
+	Date time.Time
+	// RateName — откуда взята базовая цена: сезон, цена домика или тариф
+	RateName  string
+	BasePrice Money
+	// Surcharge — наценка в процентах, SurchargeName — ее причина
+	Surcharge     float64
+	SurchargeName string
+	// ExtraGuests — доплата за гостей сверх включенных в цену тарифа
+	ExtraGuests Money
+	Price       Money
+}
+
+// PriceQuote — расчет стоимости проживания с разбивкой по ночам
+type PriceQuote struct {
+	TariffID  int
+	CottageID int
+	Adults    int
+	Children  int
+	Nights    []NightPrice
+	Total     Money
+	// DiscountCode и Discount — промокод и скидка с Total
+	DiscountCode string
+	Discount     Money
+}
+
+// Due возвращает сумму к оплате с учетом скидки
+func (q PriceQuote) Due() Money {
+	return q.Total.Sub(q.Discount)
+}
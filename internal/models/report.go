@@ -0,0 +1,154 @@
+package models
+
+import (
+	"time"
+)
+
+// OccupancyStats — ночи домиков за период: проданные, закрытые блокировкой
+// и доступные для продажи (все ночи, кроме заблокированных)
+type OccupancyStats struct {
+	Sold      int
+	Blocked   int
+	Available int
+}
+
+// Add возвращает сумму ночей s и o
+func (s OccupancyStats) Add(o OccupancyStats) OccupancyStats {
+	return OccupancyStats{
+		Sold:      s.Sold + o.Sold,
+		Blocked:   s.Blocked + o.Blocked,
+		Available: s.Available + o.Available,
+	}
+}
+
+// Percent возвращает заполняемость — долю проданных ночей среди доступных,
+// в процентах; без доступных ночей — ноль
+func (s OccupancyStats) Percent() float64 {
+	if s.Available == 0 {
+		return 0
+	}
+	return float64(s.Sold) * 100 / float64(s.Available)
+}
+
+// CottageOccupancy — заполняемость домика за период отчета
+type CottageOccupancy struct {
+	CottageID int
+	Name      string
+	OccupancyStats
+}
+
+// PeriodOccupancy — заполняемость всех домиков за день или месяц
+type PeriodOccupancy struct {
+	// Start — день или первый день месяца
+	Start time.Time
+	OccupancyStats
+}
+
+// OccupancyReport — заполняемость домиков за ночи с From по To включительно
+type OccupancyReport struct {
+	From     time.Time
+	To       time.Time
+	Cottages []CottageOccupancy
+	Days     []PeriodOccupancy
+	Months   []PeriodOccupancy
+	Total    OccupancyStats
+}
+
+// RevenueStats — выручка и показатели продаж за период. Проживание
+// распределяется по ночам, услуги — по дням оказания, а заезды, длина
+// проживания, срок бронирования и отмены относятся к дню заезда.
+type RevenueStats struct {
+	// Revenue — выручка от проживания за проданные ночи периода
+	Revenue Money
+	// Extras — услуги по фолио, оказанные в периоде
+	Extras Money
+	// Sold и Available — проданные и доступные для продажи ночи домиков
+	Sold      int
+	Available int
+	// Arrivals — заезды периода (без отмененных), StayNights — их ночи,
+	// LeadDays — сумма дней от бронирования до заезда
+	Arrivals   int
+	StayNights int
+	LeadDays   int
+	// Bookings — брони с заездом в периоде вместе с отмененными,
+	// Cancelled — отмененные из них
+	Bookings  int
+	Cancelled int
+}
+
+// Add возвращает сумму показателей s и o
+func (s RevenueStats) Add(o RevenueStats) RevenueStats {
+	return RevenueStats{
+		Revenue:    s.Revenue.Add(o.Revenue),
+		Extras:     s.Extras.Add(o.Extras),
+		Sold:       s.Sold + o.Sold,
+		Available:  s.Available + o.Available,
+		Arrivals:   s.Arrivals + o.Arrivals,
+		StayNights: s.StayNights + o.StayNights,
+		LeadDays:   s.LeadDays + o.LeadDays,
+		Bookings:   s.Bookings + o.Bookings,
+		Cancelled:  s.Cancelled + o.Cancelled,
+	}
+}
+
+// ADR возвращает среднюю цену проданной ночи
+func (s RevenueStats) ADR() Money {
+	return s.Revenue.Div(s.Sold)
+}
+
+// RevPAR возвращает выручку от проживания на доступную ночь
+func (s RevenueStats) RevPAR() Money {
+	return s.Revenue.Div(s.Available)
+}
+
+// ALOS возвращает среднюю длину проживания заезда в ночах
+func (s RevenueStats) ALOS() float64 {
+	if s.Arrivals == 0 {
+		return 0
+	}
+	return float64(s.StayNights) / float64(s.Arrivals)
+}
+
+// LeadTime возвращает средний срок от бронирования до заезда в днях
+func (s RevenueStats) LeadTime() float64 {
+	if s.Arrivals == 0 {
+		return 0
+	}
+	return float64(s.LeadDays) / float64(s.Arrivals)
+}
+
+// CancellationRate возвращает долю отмененных броней в процентах
+func (s RevenueStats) CancellationRate() float64 {
+	if s.Bookings == 0 {
+		return 0
+	}
+	return float64(s.Cancelled) * 100 / float64(s.Bookings)
+}
+
+// RevenueGroup — показатели домика или тарифа за период отчета
+type RevenueGroup struct {
+	// ID — домик или тариф (у броней без тарифа — 0). Доступные ночи
+	// считаются только у домиков.
+	ID   int
+	Name string
+	RevenueStats
+}
+
+// PeriodRevenue — показатели всех домиков за день или месяц
+type PeriodRevenue struct {
+	// Start — день или первый день месяца
+	Start time.Time
+	RevenueStats
+}
+
+// RevenueReport — выручка и показатели продаж за дни с From по To
+// включительно
+type RevenueReport struct {
+	From     time.Time
+	To       time.Time
+	Cottages []RevenueGroup
+	Tariffs  []RevenueGroup
+	Days     []PeriodRevenue
+	Months   []PeriodRevenue
+	Total    RevenueStats
+}
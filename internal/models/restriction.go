@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// StayRestriction — ограничение сроков брони на период: минимальный срок
+// проживания, запрет заезда или выезда. Без домика действует для всех домиков.
+type StayRestriction struct {
+	ID        int    `db:"restriction_id"`
+	CottageID int    `db:"cottage_id"`
+	Name      string `db:"name"`
+	// StartDate и EndDate — первый и последний день периода включительно
+	StartDate time.Time `db:"start_date"`
+	EndDate   time.Time `db:"end_date"`
+	// MinNights — минимальный срок для броней с заездом в период (0 — нет)
+	MinNights         int  `db:"min_nights"`
+	ClosedToArrival   bool `db:"closed_to_arrival"`
+	ClosedToDeparture bool `db:"closed_to_departure"`
+}
+
+// Covers сообщает, действует ли ограничение в день day для домика cottageID
+func (r StayRestriction) Covers(cottageID int, day time.Time) bool {
+	if r.CottageID != 0 && r.CottageID != cottageID {
+		return false
+	}
+	d := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+	start := time.Date(r.StartDate.Year(), r.StartDate.Month(), r.StartDate.Day(), 0, 0, 0, 0, time.Local)
+	end := time.Date(r.EndDate.Year(), r.EndDate.Month(), r.EndDate.Day(), 0, 0, 0, 0, time.Local)
+	return !d.Before(start) && !d.After(end)
+}
+
+// DayRestriction — все ограничения, действующие в один день для домика
+type DayRestriction struct {
+	MinNights         int
+	ClosedToArrival   bool
+	ClosedToDeparture bool
+	// Name — название самого строгого ограничения для сообщений
+	Name string
+}
+
+// Any сообщает, есть ли в день хоть какое-то ограничение
+func (d DayRestriction) Any() bool {
+	return d.MinNights > 1 || d.ClosedToArrival || d.ClosedToDeparture
+}
+
+// RestrictionsOn объединяет ограничения restrictions, действующие в день day
+// для домика cottageID: берется наибольший минимальный срок и любые запреты
+func RestrictionsOn(restrictions []StayRestriction, cottageID int, day time.Time) DayRestriction {
+	var d DayRestriction
+	for _, r := range restrictions {
+		if !r.Covers(cottageID, day) {
+			continue
+		}
+		stricter := r.MinNights > d.MinNights ||
+			(r.ClosedToArrival && !d.ClosedToArrival) ||
+			(r.ClosedToDeparture && !d.ClosedToDeparture)
+		if stricter && r.Name != "" {
+			d.Name = r.Name
+		}
+		if r.MinNights > d.MinNights {
+			d.MinNights = r.MinNights
+		}
+		d.ClosedToArrival = d.ClosedToArrival || r.ClosedToArrival
+		d.ClosedToDeparture = d.ClosedToDeparture || r.ClosedToDeparture
+	}
+	return d
+}
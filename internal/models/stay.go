@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// StayPolicy — расчетный час базы: в какое время гость заезжает и выезжает.
+// Ночи проживания считаются по календарным датам, поэтому переход
+// на летнее время и границы месяцев не меняют их число.
+type StayPolicy struct {
+	CheckInHour  int
+	CheckOutHour int
+}
+
+// DefaultStayPolicy — заезд в 14:00, выезд в 12:00
+var DefaultStayPolicy = StayPolicy{CheckInHour: 14, CheckOutHour: 12}
+
+// CheckInTime возвращает время заезда в день day
+func (p StayPolicy) CheckInTime(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), p.CheckInHour, 0, 0, 0, time.Local)
+}
+
+// CheckOutTime возвращает время выезда в день day
+func (p StayPolicy) CheckOutTime(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), p.CheckOutHour, 0, 0, 0, time.Local)
+}
+
+// Normalize переносит заезд и выезд на расчетные часы тех же дней
+func (p StayPolicy) Normalize(checkIn, checkOut time.Time) (time.Time, time.Time) {
+	return p.CheckInTime(checkIn), p.CheckOutTime(checkOut)
+}
+
+// NightDates возвращает даты, с которых начинаются оплачиваемые ночи:
+// от дня заезда до дня выезда, не включая его. Заезд и выезд в один день
+// (или выезд раньше заезда) считаются одной ночью.
+func (p StayPolicy) NightDates(checkIn, checkOut time.Time) []time.Time {
+	first := time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 0, 0, 0, 0, time.Local)
+	last := time.Date(checkOut.Year(), checkOut.Month(), checkOut.Day(), 0, 0, 0, 0, time.Local)
+
+	nights := []time.Time{first}
+	for night := first.AddDate(0, 0, 1); night.Before(last); night = night.AddDate(0, 0, 1) {
+		nights = append(nights, night)
+	}
+	return nights
+}
+
+// Nights возвращает число оплачиваемых ночей
+func (p StayPolicy) Nights(checkIn, checkOut time.Time) int {
+	return len(p.NightDates(checkIn, checkOut))
+}
@@ -0,0 +1,189 @@
+package models
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+// useLocation подменяет местный часовой пояс на время теста. Берлин
+// переходит на летнее время 29.03.2026 и обратно 25.10.2026.
+func useLocation(t *testing.T, name string) {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+	local := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = local })
+}
+
+// at возвращает местное время
+func at(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, time.Local)
+}
+
+func TestStayPolicyNightDates(t *testing.T) {
+	useLocation(t, "Europe/Berlin")
+
+	tests := []struct {
+		name     string
+		checkIn  time.Time
+		checkOut time.Time
+		want     []string
+	}{
+		{
+			// Заезд и выезд в один день — намеренно одна ночь
+			name:     "same day is one night",
+			checkIn:  at(2026, time.January, 10, 10, 0),
+			checkOut: at(2026, time.January, 10, 18, 0),
+			want:     []string{"2026-01-10"},
+		},
+		{
+			name:     "check-out before check-in is one night",
+			checkIn:  at(2026, time.January, 10, 14, 0),
+			checkOut: at(2026, time.January, 9, 12, 0),
+			want:     []string{"2026-01-10"},
+		},
+		{
+			name:     "one night",
+			checkIn:  at(2026, time.January, 10, 14, 0),
+			checkOut: at(2026, time.January, 11, 12, 0),
+			want:     []string{"2026-01-10"},
+		},
+		{
+			// Ночи считаются по датам, а не по прошедшим часам
+			name:     "one night shorter than a day",
+			checkIn:  at(2026, time.January, 10, 23, 59),
+			checkOut: at(2026, time.January, 11, 0, 1),
+			want:     []string{"2026-01-10"},
+		},
+		{
+			name:     "month boundary",
+			checkIn:  at(2026, time.January, 30, 14, 0),
+			checkOut: at(2026, time.February, 2, 12, 0),
+			want:     []string{"2026-01-30", "2026-01-31", "2026-02-01"},
+		},
+		{
+			name:     "leap day",
+			checkIn:  at(2028, time.February, 28, 14, 0),
+			checkOut: at(2028, time.March, 1, 12, 0),
+			want:     []string{"2028-02-28", "2028-02-29"},
+		},
+		{
+			name:     "year boundary",
+			checkIn:  at(2026, time.December, 31, 14, 0),
+			checkOut: at(2027, time.January, 2, 12, 0),
+			want:     []string{"2026-12-31", "2027-01-01"},
+		},
+		{
+			// Ночь на 29.03 на час короче
+			name:     "spring DST transition",
+			checkIn:  at(2026, time.March, 28, 14, 0),
+			checkOut: at(2026, time.March, 30, 12, 0),
+			want:     []string{"2026-03-28", "2026-03-29"},
+		},
+		{
+			// Ночь на 25.10 на час длиннее
+			name:     "autumn DST transition",
+			checkIn:  at(2026, time.October, 24, 14, 0),
+			checkOut: at(2026, time.October, 26, 12, 0),
+			want:     []string{"2026-10-24", "2026-10-25"},
+		},
+		{
+			name:     "DST day is check-out day",
+			checkIn:  at(2026, time.March, 28, 14, 0),
+			checkOut: at(2026, time.March, 29, 12, 0),
+			want:     []string{"2026-03-28"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nights := DefaultStayPolicy.NightDates(tt.checkIn, tt.checkOut)
+
+			got := make([]string, len(nights))
+			for i, night := range nights {
+				got[i] = night.Format("2006-01-02")
+				if night.Hour() != 0 || night.Minute() != 0 || night.Location() != time.Local {
+					t.Errorf("night %s starts at %s, want local midnight", got[i], night.Format(time.RFC3339))
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("nights = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("nights = %v, want %v", got, tt.want)
+				}
+			}
+
+			if n := DefaultStayPolicy.Nights(tt.checkIn, tt.checkOut); n != len(tt.want) {
+				t.Errorf("Nights = %d, want %d", n, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestStayPolicyNormalize(t *testing.T) {
+	useLocation(t, "Europe/Berlin")
+
+	tests := []struct {
+		name         string
+		checkIn      time.Time
+		checkOut     time.Time
+		wantCheckIn  time.Time
+		wantCheckOut time.Time
+	}{
+		{
+			name:         "ordinary days",
+			checkIn:      at(2026, time.January, 10, 9, 30),
+			checkOut:     at(2026, time.January, 12, 20, 0),
+			wantCheckIn:  at(2026, time.January, 10, 14, 0),
+			wantCheckOut: at(2026, time.January, 12, 12, 0),
+		},
+		{
+			name:         "same day",
+			checkIn:      at(2026, time.January, 10, 0, 0),
+			checkOut:     at(2026, time.January, 10, 0, 0),
+			wantCheckIn:  at(2026, time.January, 10, 14, 0),
+			wantCheckOut: at(2026, time.January, 10, 12, 0),
+		},
+		{
+			// Расчетные часы — по местным часам и в день перехода
+			name:         "spring DST transition",
+			checkIn:      at(2026, time.March, 29, 0, 0),
+			checkOut:     at(2026, time.March, 30, 0, 0),
+			wantCheckIn:  at(2026, time.March, 29, 14, 0),
+			wantCheckOut: at(2026, time.March, 30, 12, 0),
+		},
+		{
+			name:         "autumn DST transition",
+			checkIn:      at(2026, time.October, 24, 0, 0),
+			checkOut:     at(2026, time.October, 25, 0, 0),
+			wantCheckIn:  at(2026, time.October, 24, 14, 0),
+			wantCheckOut: at(2026, time.October, 25, 12, 0),
+		},
+		{
+			// Время в другом поясе переносится на тот же календарный день
+			name:         "foreign location",
+			checkIn:      time.Date(2026, time.January, 31, 23, 0, 0, 0, time.UTC),
+			checkOut:     time.Date(2026, time.February, 1, 1, 0, 0, 0, time.UTC),
+			wantCheckIn:  at(2026, time.January, 31, 14, 0),
+			wantCheckOut: at(2026, time.February, 1, 12, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in, out := DefaultStayPolicy.Normalize(tt.checkIn, tt.checkOut)
+			if !in.Equal(tt.wantCheckIn) {
+				t.Errorf("check-in = %s, want %s", in.Format(time.RFC3339), tt.wantCheckIn.Format(time.RFC3339))
+			}
+			if !out.Equal(tt.wantCheckOut) {
+				t.Errorf("check-out = %s, want %s", out.Format(time.RFC3339), tt.wantCheckOut.Format(time.RFC3339))
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// internal/repository/memory/bookings.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+type bookingRepo struct {
+	s *Store
+}
+
+func (r *bookingRepo) Create(booking *models.Booking) error {
+	defer r.s.lock()()
+
+	if r.overlaps(*booking) {
+		return repository.ErrOverlap
+	}
+
+	booking.ID = r.s.data.nextBookingID
+	r.s.data.nextBookingID++
+	r.s.data.bookings[booking.ID] = *booking
+	return nil
+}
+
+func (r *bookingRepo) GetByID(bookingID int) (*models.Booking, error) {
+	defer r.s.lock()()
+
+	b, ok := r.s.data.bookings[bookingID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	b.ExtrasTotal = r.s.data.extrasTotal(bookingID)
+	return &b, nil
+}
+
+func (r *bookingRepo) CountOverlapping(cottageID int, checkIn, checkOut time.Time, statuses []string, exceptBookingID int) (int, error) {
+	defer r.s.lock()()
+
+	count := 0
+	for _, b := range r.s.data.bookings {
+		if b.ID == exceptBookingID || b.CottageID != cottageID || !containsStatus(statuses, b.Status) {
+			continue
+		}
+		if !b.CheckOutDate.After(checkIn) || !b.CheckInDate.Before(checkOut) {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (r *bookingRepo) CountByCottage(cottageID int, statuses []string) (int, error) {
+	defer r.s.lock()()
+
+	count := 0
+	for _, b := range r.s.data.bookings {
+		if b.CottageID == cottageID && containsStatus(statuses, b.Status) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *bookingRepo) ListByDateRange(start, end time.Time, excludeStatuses []string) ([]models.Booking, error) {
+	return r.list(func(b models.Booking) bool {
+		return !b.CheckInDate.After(end) && !b.CheckOutDate.Before(start) &&
+			!containsStatus(excludeStatuses, b.Status)
+	}), nil
+}
+
+func (r *bookingRepo) ListByStatus(status string, checkInFrom time.Time) ([]models.Booking, error) {
+	return r.list(func(b models.Booking) bool {
+		return b.Status == status && !b.CheckInDate.Before(checkInFrom)
+	}), nil
+}
+
+func (r *bookingRepo) ListByGroup(groupID int) ([]models.Booking, error) {
+	bookings := r.list(func(b models.Booking) bool {
+		return b.GroupID == groupID
+	})
+	sort.SliceStable(bookings, func(i, j int) bool {
+		return bookings[i].CottageID < bookings[j].CottageID
+	})
+	return bookings, nil
+}
+
+// list возвращает подходящие брони, упорядоченные по дате заезда
+func (r *bookingRepo) list(match func(models.Booking) bool) []models.Booking {
+	defer r.s.lock()()
+
+	var bookings []models.Booking
+	for _, id := range sortedIDs(r.s.data.bookings) {
+		if b := r.s.data.bookings[id]; match(b) {
+			b.ExtrasTotal = r.s.data.extrasTotal(id)
+			bookings = append(bookings, b)
+		}
+	}
+	sort.SliceStable(bookings, func(i, j int) bool {
+		return bookings[i].CheckInDate.Before(bookings[j].CheckInDate)
+	})
+	return bookings
+}
+
+func (r *bookingRepo) Update(booking models.Booking) error {
+	return r.update(booking.ID, func(b *models.Booking) {
+		status, createdAt, groupID := b.Status, b.CreatedAt, b.GroupID
+		discountID, discountCode := b.DiscountID, b.DiscountCode
+		*b = booking
+		b.Status, b.CreatedAt, b.GroupID = status, createdAt, groupID
+		b.DiscountID, b.DiscountCode = discountID, discountCode
+	})
+}
+
+func (r *bookingRepo) UpdateStatus(bookingID int, from, to string) error {
+	defer r.s.lock()()
+
+	b, ok := r.s.data.bookings[bookingID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if b.Status != from {
+		return repository.ErrConflict
+	}
+	b.Status = to
+	if r.overlaps(b) {
+		return repository.ErrOverlap
+	}
+	r.s.data.bookings[bookingID] = b
+	return nil
+}
+
+func (r *bookingRepo) UpdateCheckOut(bookingID int, checkOut time.Time, totalCost, discount models.Money, note string) error {
+	return r.update(bookingID, func(b *models.Booking) {
+		b.CheckOutDate = checkOut
+		b.TotalCost = totalCost
+		b.DiscountAmount = discount
+		b.Notes += note
+	})
+}
+
+func (r *bookingRepo) update(bookingID int, fn func(b *models.Booking)) error {
+	defer r.s.lock()()
+
+	b, ok := r.s.data.bookings[bookingID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	fn(&b)
+	if r.overlaps(b) {
+		return repository.ErrOverlap
+	}
+	r.s.data.bookings[bookingID] = b
+	return nil
+}
+
+// overlaps повторяет ограничение bookings_no_overlap: действующая бронь
+// не может пересекаться с другой действующей бронью того же домика
+func (r *bookingRepo) overlaps(booking models.Booking) bool {
+	if !models.IsActiveBookingStatus(booking.Status) {
+		return false
+	}
+	for id, b := range r.s.data.bookings {
+		if id == booking.ID || b.CottageID != booking.CottageID || !models.IsActiveBookingStatus(b.Status) {
+			continue
+		}
+		if b.CheckInDate.Before(booking.CheckOutDate) && b.CheckOutDate.After(booking.CheckInDate) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *bookingRepo) DeleteReleasedHoldsBefore(before time.Time) (int64, error) {
+	defer r.s.lock()()
+
+	// Брони с оплатами и услугами не удаляются, как ON DELETE RESTRICT;
+	// брони со счетами тоже остаются
+	kept := make(map[int]bool)
+	for _, p := range r.s.data.payments {
+		kept[p.BookingID] = true
+	}
+	for _, item := range r.s.data.folio {
+		kept[item.BookingID] = true
+	}
+	for _, inv := range r.s.data.invoices {
+		kept[inv.BookingID] = true
+	}
+
+	var deleted int64
+	for id, b := range r.s.data.bookings {
+		if b.Status == models.BookingStatusCancelled && !b.HoldExpiresAt.IsZero() &&
+			b.CheckOutDate.Before(before) && !kept[id] {
+			delete(r.s.data.bookings, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,89 @@
+// internal/repository/memory/cancellation.go
+package memory
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// errDuplicatePolicy повторяет ограничение UNIQUE на cancellation_policies.name
+var errDuplicatePolicy = errors.New("memory: duplicate cancellation policy name")
+
+type policyRepo struct {
+	s *Store
+}
+
+func (r *policyRepo) Create(policy *models.CancellationPolicy) error {
+	defer r.s.lock()()
+
+	if r.nameTaken(policy.Name, 0) {
+		return errDuplicatePolicy
+	}
+	policy.ID = r.s.data.nextPolicyID
+	r.s.data.nextPolicyID++
+	r.s.data.policies[policy.ID] = *policy
+	return nil
+}
+
+func (r *policyRepo) List() ([]models.CancellationPolicy, error) {
+	defer r.s.lock()()
+
+	var policies []models.CancellationPolicy
+	for _, id := range sortedIDs(r.s.data.policies) {
+		policies = append(policies, r.s.data.policies[id])
+	}
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+	return policies, nil
+}
+
+func (r *policyRepo) GetByID(policyID int) (*models.CancellationPolicy, error) {
+	defer r.s.lock()()
+
+	p, ok := r.s.data.policies[policyID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &p, nil
+}
+
+func (r *policyRepo) Update(policy models.CancellationPolicy) error {
+	defer r.s.lock()()
+
+	if _, ok := r.s.data.policies[policy.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	if r.nameTaken(policy.Name, policy.ID) {
+		return errDuplicatePolicy
+	}
+	r.s.data.policies[policy.ID] = policy
+	return nil
+}
+
+func (r *policyRepo) Delete(policyID int) error {
+	defer r.s.lock()()
+
+	delete(r.s.data.policies, policyID)
+	// Тарифы с этими правилами остаются без штрафов, как ON DELETE SET NULL
+	for id, t := range r.s.data.tariffs {
+		if t.CancellationPolicyID == policyID {
+			t.CancellationPolicyID = 0
+			r.s.data.tariffs[id] = t
+		}
+	}
+	return nil
+}
+
+// nameTaken сообщает, занято ли название другими правилами
+func (r *policyRepo) nameTaken(name string, exceptID int) bool {
+	for id, p := range r.s.data.policies {
+		if id != exceptID && p.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+// internal/repository/memory/changes.go
+package memory
+
+import (
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+type changeRepo struct {
+	s *Store
+}
+
+func (r *changeRepo) Create(change *models.BookingChange) error {
+	defer r.s.lock()()
+
+	change.ID = r.s.data.nextChangeID
+	r.s.data.nextChangeID++
+	r.s.data.changes[change.ID] = *change
+	return nil
+}
+
+func (r *changeRepo) ListByBooking(bookingID int) ([]models.BookingChange, error) {
+	defer r.s.lock()()
+
+	var changes []models.BookingChange
+	for _, id := range sortedIDs(r.s.data.changes) {
+		if c := r.s.data.changes[id]; c.BookingID == bookingID {
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,121 @@
+// internal/repository/memory/cottages.go
+package memory
+
+import (
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+type cottageRepo struct {
+	s *Store
+}
+
+func (r *cottageRepo) Create(cottage *models.Cottage) error {
+	defer r.s.lock()()
+
+	cottage.ID = r.s.data.nextCottageID
+	r.s.data.nextCottageID++
+	r.s.data.cottages[cottage.ID] = *cottage
+	return nil
+}
+
+func (r *cottageRepo) List() ([]models.Cottage, error) {
+	return r.list(func(models.Cottage) bool { return true }), nil
+}
+
+func (r *cottageRepo) GetByID(cottageID int) (*models.Cottage, error) {
+	defer r.s.lock()()
+
+	c, ok := r.s.data.cottages[cottageID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &c, nil
+}
+
+func (r *cottageRepo) ListByStatus(status string) ([]models.Cottage, error) {
+	return r.list(func(c models.Cottage) bool { return c.Status == status }), nil
+}
+
+func (r *cottageRepo) ListAvailable(checkIn, checkOut time.Time) ([]models.Cottage, error) {
+	defer r.s.lock()()
+
+	busy := make(map[int]bool)
+	for _, b := range r.s.data.bookings {
+		if b.Status == models.BookingStatusCancelled || b.Status == models.BookingStatusCompleted {
+			continue
+		}
+		if between(checkIn, b.CheckInDate, b.CheckOutDate) ||
+			between(checkOut, b.CheckInDate, b.CheckOutDate) ||
+			between(b.CheckInDate, checkIn, checkOut) {
+			busy[b.CottageID] = true
+		}
+	}
+
+	var cottages []models.Cottage
+	for _, id := range sortedIDs(r.s.data.cottages) {
+		if !busy[id] {
+			cottages = append(cottages, r.s.data.cottages[id])
+		}
+	}
+	return cottages, nil
+}
+
+func (r *cottageRepo) list(match func(models.Cottage) bool) []models.Cottage {
+	defer r.s.lock()()
+
+	var cottages []models.Cottage
+	for _, id := range sortedIDs(r.s.data.cottages) {
+		if c := r.s.data.cottages[id]; match(c) {
+			cottages = append(cottages, c)
+		}
+	}
+	return cottages
+}
+
+func (r *cottageRepo) UpdateStatus(cottageID int, status string) error {
+	return r.update(cottageID, func(c *models.Cottage) { c.Status = status })
+}
+
+func (r *cottageRepo) UpdateName(cottageID int, name string) error {
+	return r.update(cottageID, func(c *models.Cottage) { c.Name = name })
+}
+
+func (r *cottageRepo) UpdateCapacity(cottageID, maxAdults, maxGuests int) error {
+	return r.update(cottageID, func(c *models.Cottage) { c.MaxAdults, c.MaxGuests = maxAdults, maxGuests })
+}
+
+func (r *cottageRepo) update(cottageID int, fn func(c *models.Cottage)) error {
+	defer r.s.lock()()
+
+	c, ok := r.s.data.cottages[cottageID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	fn(&c)
+	r.s.data.cottages[cottageID] = c
+	return nil
+}
+
+func (r *cottageRepo) Delete(cottageID int) error {
+	defer r.s.lock()()
+
+	if _, ok := r.s.data.cottages[cottageID]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.s.data.cottages, cottageID)
+	// Цены и ограничения домика удаляются вместе с ним, как ON DELETE CASCADE
+	for id, rate := range r.s.data.rates {
+		if rate.CottageID == cottageID {
+			delete(r.s.data.rates, id)
+		}
+	}
+	for id, restriction := range r.s.data.restrictions {
+		if restriction.CottageID == cottageID {
+			delete(r.s.data.restrictions, id)
+		}
+	}
+	return nil
+}
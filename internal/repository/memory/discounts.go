@@ -0,0 +1,126 @@
+// internal/repository/memory/discounts.go
+package memory
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// errDuplicateCode повторяет ограничение UNIQUE на discounts.code
+var errDuplicateCode = errors.New("memory: duplicate discount code")
+
+type discountRepo struct {
+	s *Store
+}
+
+func (r *discountRepo) Create(discount *models.Discount) error {
+	defer r.s.lock()()
+
+	if r.codeTaken(discount.Code, 0) {
+		return errDuplicateCode
+	}
+	discount.ID = r.s.data.nextDiscountID
+	r.s.data.nextDiscountID++
+	discount.UsedCount = 0
+	stored := *discount
+	stored.TariffIDs = sortedTariffIDs(discount.TariffIDs)
+	r.s.data.discounts[discount.ID] = stored
+	return nil
+}
+
+func (r *discountRepo) GetByID(discountID int) (*models.Discount, error) {
+	defer r.s.lock()()
+
+	d, ok := r.s.data.discounts[discountID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	d.TariffIDs = sortedTariffIDs(d.TariffIDs)
+	return &d, nil
+}
+
+func (r *discountRepo) GetByCode(code string) (*models.Discount, error) {
+	defer r.s.lock()()
+
+	for _, d := range r.s.data.discounts {
+		if d.Code == code {
+			d.TariffIDs = sortedTariffIDs(d.TariffIDs)
+			return &d, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *discountRepo) List() ([]models.Discount, error) {
+	defer r.s.lock()()
+
+	var discounts []models.Discount
+	for _, id := range sortedIDs(r.s.data.discounts) {
+		d := r.s.data.discounts[id]
+		d.TariffIDs = sortedTariffIDs(d.TariffIDs)
+		discounts = append(discounts, d)
+	}
+	sort.SliceStable(discounts, func(i, j int) bool {
+		return discounts[i].Code < discounts[j].Code
+	})
+	return discounts, nil
+}
+
+func (r *discountRepo) Update(discount models.Discount) error {
+	defer r.s.lock()()
+
+	old, ok := r.s.data.discounts[discount.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if r.codeTaken(discount.Code, discount.ID) {
+		return errDuplicateCode
+	}
+	discount.UsedCount = old.UsedCount
+	discount.TariffIDs = sortedTariffIDs(discount.TariffIDs)
+	r.s.data.discounts[discount.ID] = discount
+	return nil
+}
+
+func (r *discountRepo) Redeem(discountID int) error {
+	defer r.s.lock()()
+
+	d, ok := r.s.data.discounts[discountID]
+	if !ok || !d.Active || d.Exhausted() {
+		return repository.ErrNotFound
+	}
+	d.UsedCount++
+	r.s.data.discounts[discountID] = d
+	return nil
+}
+
+func (r *discountRepo) Delete(discountID int) error {
+	defer r.s.lock()()
+
+	delete(r.s.data.discounts, discountID)
+	return nil
+}
+
+// codeTaken сообщает, занят ли код другим промокодом
+func (r *discountRepo) codeTaken(code string, exceptID int) bool {
+	for id, d := range r.s.data.discounts {
+		if id != exceptID && d.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedTariffIDs копирует список тарифов по возрастанию, чтобы хранилище
+// не делило срез с вызывающим кодом
+func sortedTariffIDs(ids []int) []int {
+	if len(ids) == 0 {
+		return nil
+	}
+	c := append([]int(nil), ids...)
+	sort.Ints(c)
+	return c
+}
@@ -0,0 +1,146 @@
+// internal/repository/memory/extras.go
+package memory
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// errDuplicateExtra повторяет ограничение UNIQUE на extras.name
+var errDuplicateExtra = errors.New("memory: duplicate extra name")
+
+type extraRepo struct {
+	s *Store
+}
+
+func (r *extraRepo) Create(extra *models.Extra) error {
+	defer r.s.lock()()
+
+	if r.nameTaken(extra.Name, 0) {
+		return errDuplicateExtra
+	}
+	extra.ID = r.s.data.nextExtraID
+	r.s.data.nextExtraID++
+	r.s.data.extras[extra.ID] = *extra
+	return nil
+}
+
+func (r *extraRepo) List() ([]models.Extra, error) {
+	defer r.s.lock()()
+
+	var extras []models.Extra
+	for _, id := range sortedIDs(r.s.data.extras) {
+		extras = append(extras, r.s.data.extras[id])
+	}
+	sort.SliceStable(extras, func(i, j int) bool {
+		return extras[i].Name < extras[j].Name
+	})
+	return extras, nil
+}
+
+func (r *extraRepo) GetByID(extraID int) (*models.Extra, error) {
+	defer r.s.lock()()
+
+	e, ok := r.s.data.extras[extraID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &e, nil
+}
+
+func (r *extraRepo) Update(extra models.Extra) error {
+	defer r.s.lock()()
+
+	if _, ok := r.s.data.extras[extra.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	if r.nameTaken(extra.Name, extra.ID) {
+		return errDuplicateExtra
+	}
+	r.s.data.extras[extra.ID] = extra
+	return nil
+}
+
+func (r *extraRepo) Delete(extraID int) error {
+	defer r.s.lock()()
+
+	delete(r.s.data.extras, extraID)
+	// Строки фолио остаются без ссылки на услугу, как ON DELETE SET NULL
+	for id, item := range r.s.data.folio {
+		if item.ExtraID == extraID {
+			item.ExtraID = 0
+			r.s.data.folio[id] = item
+		}
+	}
+	return nil
+}
+
+// nameTaken сообщает, занято ли название другой услугой
+func (r *extraRepo) nameTaken(name string, exceptID int) bool {
+	for id, e := range r.s.data.extras {
+		if id != exceptID && e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+type folioRepo struct {
+	s *Store
+}
+
+func (r *folioRepo) Create(item *models.FolioItem) error {
+	defer r.s.lock()()
+
+	item.ID = r.s.data.nextFolioID
+	r.s.data.nextFolioID++
+	r.s.data.folio[item.ID] = *item
+	return nil
+}
+
+func (r *folioRepo) GetByID(itemID int) (*models.FolioItem, error) {
+	defer r.s.lock()()
+
+	item, ok := r.s.data.folio[itemID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &item, nil
+}
+
+// ListByBooking возвращает услуги брони, как ORDER BY service_date, item_id
+func (r *folioRepo) ListByBooking(bookingID int) ([]models.FolioItem, error) {
+	defer r.s.lock()()
+
+	var items []models.FolioItem
+	for _, id := range sortedIDs(r.s.data.folio) {
+		if item := r.s.data.folio[id]; item.BookingID == bookingID {
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ServiceDate.Before(items[j].ServiceDate)
+	})
+	return items, nil
+}
+
+func (r *folioRepo) Delete(itemID int) error {
+	defer r.s.lock()()
+
+	delete(r.s.data.folio, itemID)
+	return nil
+}
+
+// extrasTotal считает стоимость услуг брони, как подзапрос в bookingColumns
+func (d *data) extrasTotal(bookingID int) models.Money {
+	total := models.NewMoney(0)
+	for _, item := range d.folio {
+		if item.BookingID == bookingID {
+			total = total.Add(item.Amount())
+		}
+	}
+	return total
+}
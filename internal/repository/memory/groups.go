@@ -0,0 +1,42 @@
+// internal/repository/memory/groups.go
+package memory
+
+import (
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+type groupRepo struct {
+	s *Store
+}
+
+func (r *groupRepo) Create(group *models.BookingGroup) error {
+	defer r.s.lock()()
+
+	group.ID = r.s.data.nextGroupID
+	r.s.data.nextGroupID++
+	r.s.data.groups[group.ID] = *group
+	return nil
+}
+
+func (r *groupRepo) GetByID(groupID int) (*models.BookingGroup, error) {
+	defer r.s.lock()()
+
+	g, ok := r.s.data.groups[groupID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &g, nil
+}
+
+func (r *groupRepo) Update(group models.BookingGroup) error {
+	defer r.s.lock()()
+
+	existing, ok := r.s.data.groups[group.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	group.CreatedAt = existing.CreatedAt
+	r.s.data.groups[group.ID] = group
+	return nil
+}
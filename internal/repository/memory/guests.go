@@ -0,0 +1,88 @@
+// internal/repository/memory/guests.go
+package memory
+
+import (
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+type guestRepo struct {
+	s *Store
+}
+
+func (r *guestRepo) Create(guest *models.Guest) error {
+	defer r.s.lock()()
+
+	guest.ID = r.s.data.nextGuestID
+	r.s.data.nextGuestID++
+	r.s.data.guests[guest.ID] = *guest
+	return nil
+}
+
+func (r *guestRepo) GetByCottageID(cottageID int) (*models.Guest, error) {
+	defer r.s.lock()()
+
+	for _, id := range sortedIDs(r.s.data.guests) {
+		if g := r.s.data.guests[id]; g.CottageID == cottageID {
+			return &g, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *guestRepo) List() ([]models.Guest, error) {
+	defer r.s.lock()()
+
+	guests := make([]models.Guest, 0, len(r.s.data.guests))
+	for _, id := range sortedIDs(r.s.data.guests) {
+		guests = append(guests, r.s.data.guests[id])
+	}
+	return guests, nil
+}
+
+func (r *guestRepo) CountByCottage(cottageID int) (int, error) {
+	return r.count(func(g models.Guest) bool { return g.CottageID == cottageID }), nil
+}
+
+func (r *guestRepo) CountByTariff(tariffID int) (int, error) {
+	return r.count(func(g models.Guest) bool { return g.TariffID == tariffID }), nil
+}
+
+func (r *guestRepo) count(match func(models.Guest) bool) int {
+	defer r.s.lock()()
+
+	count := 0
+	for _, g := range r.s.data.guests {
+		if match(g) {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *guestRepo) DeleteByCottageID(cottageID int) error {
+	r.deleteWhere(func(g models.Guest) bool { return g.CottageID == cottageID })
+	return nil
+}
+
+func (r *guestRepo) DeleteCheckedOutBefore(before time.Time) (int64, error) {
+	// Как и в SQL, гости без даты выезда (NULL) не удаляются
+	return r.deleteWhere(func(g models.Guest) bool {
+		return !g.CheckOutDate.IsZero() && !g.CheckOutDate.After(before)
+	}), nil
+}
+
+func (r *guestRepo) deleteWhere(match func(models.Guest) bool) int64 {
+	defer r.s.lock()()
+
+	var deleted int64
+	for id, g := range r.s.data.guests {
+		if match(g) {
+			delete(r.s.data.guests, id)
+			deleted++
+		}
+	}
+	return deleted
+}
@@ -0,0 +1,55 @@
+// internal/repository/memory/invoices.go
+package memory
+
+import (
+	"sort"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+type invoiceRepo struct {
+	s *Store
+}
+
+func (r *invoiceRepo) Create(invoice *models.Invoice) error {
+	defer r.s.lock()()
+
+	for _, inv := range r.s.data.invoices {
+		// Как ограничения UNIQUE на номер счета
+		if inv.Number == invoice.Number || (inv.Year == invoice.Year && inv.Seq == invoice.Seq) {
+			return repository.ErrDuplicate
+		}
+	}
+	invoice.ID = r.s.data.nextInvoiceID
+	r.s.data.nextInvoiceID++
+	r.s.data.invoices[invoice.ID] = *invoice
+	return nil
+}
+
+func (r *invoiceRepo) ListByBooking(bookingID int) ([]models.Invoice, error) {
+	defer r.s.lock()()
+
+	var invoices []models.Invoice
+	for _, id := range sortedIDs(r.s.data.invoices) {
+		if inv := r.s.data.invoices[id]; inv.BookingID == bookingID && bookingID != 0 {
+			invoices = append(invoices, inv)
+		}
+	}
+	sort.SliceStable(invoices, func(i, j int) bool {
+		return invoices[i].IssuedAt.Before(invoices[j].IssuedAt)
+	})
+	return invoices, nil
+}
+
+func (r *invoiceRepo) LastSeq(year int) (int, error) {
+	defer r.s.lock()()
+
+	last := 0
+	for _, inv := range r.s.data.invoices {
+		if inv.Year == year && inv.Seq > last {
+			last = inv.Seq
+		}
+	}
+	return last, nil
+}
@@ -0,0 +1,48 @@
+// internal/repository/memory/payments.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+type paymentRepo struct {
+	s *Store
+}
+
+func (r *paymentRepo) Create(payment *models.Payment) error {
+	defer r.s.lock()()
+
+	payment.ID = r.s.data.nextPaymentID
+	r.s.data.nextPaymentID++
+	r.s.data.payments[payment.ID] = *payment
+	return nil
+}
+
+func (r *paymentRepo) ListByBooking(bookingID int) ([]models.Payment, error) {
+	return r.list(func(p models.Payment) bool { return p.BookingID == bookingID }), nil
+}
+
+func (r *paymentRepo) ListBetween(from, to time.Time) ([]models.Payment, error) {
+	return r.list(func(p models.Payment) bool {
+		return !p.PaidAt.Before(from) && !p.PaidAt.After(to)
+	}), nil
+}
+
+// list возвращает подходящие оплаты по времени, как ORDER BY paid_at, payment_id
+func (r *paymentRepo) list(match func(models.Payment) bool) []models.Payment {
+	defer r.s.lock()()
+
+	var payments []models.Payment
+	for _, id := range sortedIDs(r.s.data.payments) {
+		if p := r.s.data.payments[id]; match(p) {
+			payments = append(payments, p)
+		}
+	}
+	sort.SliceStable(payments, func(i, j int) bool {
+		return payments[i].PaidAt.Before(payments[j].PaidAt)
+	})
+	return payments
+}
@@ -0,0 +1,95 @@
+// internal/repository/memory/rates.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+type rateRepo struct {
+	s *Store
+}
+
+func (r *rateRepo) Create(rate *models.TariffRate) error {
+	defer r.s.lock()()
+
+	rate.ID = r.s.data.nextRateID
+	r.s.data.nextRateID++
+	r.s.data.rates[rate.ID] = *rate
+	return nil
+}
+
+func (r *rateRepo) ListByTariff(tariffID int) ([]models.TariffRate, error) {
+	defer r.s.lock()()
+
+	var rates []models.TariffRate
+	for _, id := range sortedIDs(r.s.data.rates) {
+		if rate := r.s.data.rates[id]; rate.TariffID == tariffID {
+			rates = append(rates, rate)
+		}
+	}
+	sort.SliceStable(rates, func(i, j int) bool {
+		if rates[i].Seasonal() != rates[j].Seasonal() {
+			return !rates[i].Seasonal()
+		}
+		return rates[i].StartDate.Before(rates[j].StartDate)
+	})
+	return rates, nil
+}
+
+func (r *rateRepo) Update(rate models.TariffRate) error {
+	defer r.s.lock()()
+
+	old, ok := r.s.data.rates[rate.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	rate.TariffID = old.TariffID
+	r.s.data.rates[rate.ID] = rate
+	return nil
+}
+
+func (r *rateRepo) Delete(rateID int) error {
+	defer r.s.lock()()
+
+	delete(r.s.data.rates, rateID)
+	return nil
+}
+
+type holidayRepo struct {
+	s *Store
+}
+
+func (r *holidayRepo) Create(holiday *models.Holiday) error {
+	defer r.s.lock()()
+
+	holiday.ID = r.s.data.nextHolidayID
+	r.s.data.nextHolidayID++
+	r.s.data.holidays[holiday.ID] = *holiday
+	return nil
+}
+
+func (r *holidayRepo) ListBetween(from, to time.Time) ([]models.Holiday, error) {
+	defer r.s.lock()()
+
+	var holidays []models.Holiday
+	for _, h := range r.s.data.holidays {
+		if between(h.Date, from, to) {
+			holidays = append(holidays, h)
+		}
+	}
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays, nil
+}
+
+func (r *holidayRepo) Delete(holidayID int) error {
+	defer r.s.lock()()
+
+	delete(r.s.data.holidays, holidayID)
+	return nil
+}
@@ -0,0 +1,55 @@
+// internal/repository/memory/restrictions.go
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+type restrictionRepo struct {
+	s *Store
+}
+
+func (r *restrictionRepo) Create(restriction *models.StayRestriction) error {
+	defer r.s.lock()()
+
+	restriction.ID = r.s.data.nextRestrictionID
+	r.s.data.nextRestrictionID++
+	r.s.data.restrictions[restriction.ID] = *restriction
+	return nil
+}
+
+func (r *restrictionRepo) ListBetween(from, to time.Time) ([]models.StayRestriction, error) {
+	defer r.s.lock()()
+
+	var restrictions []models.StayRestriction
+	for _, id := range sortedIDs(r.s.data.restrictions) {
+		if s := r.s.data.restrictions[id]; !s.StartDate.After(to) && !s.EndDate.Before(from) {
+			restrictions = append(restrictions, s)
+		}
+	}
+	sort.SliceStable(restrictions, func(i, j int) bool {
+		return restrictions[i].StartDate.Before(restrictions[j].StartDate)
+	})
+	return restrictions, nil
+}
+
+func (r *restrictionRepo) Update(restriction models.StayRestriction) error {
+	defer r.s.lock()()
+
+	if _, ok := r.s.data.restrictions[restriction.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	r.s.data.restrictions[restriction.ID] = restriction
+	return nil
+}
+
+func (r *restrictionRepo) Delete(restrictionID int) error {
+	defer r.s.lock()()
+
+	delete(r.s.data.restrictions, restrictionID)
+	return nil
+}
@@ -0,0 +1,202 @@
+// internal/repository/memory/store.go
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// Store — хранилище в памяти с той же семантикой, что и PostgreSQL.
+// Предназначено для тестов и демонстрации без сервера БД.
+type Store struct {
+	mu   *sync.Mutex
+	data *data
+	inTx bool
+}
+
+// data — содержимое всех таблиц и счетчики ID (аналог SERIAL)
+type data struct {
+	bookings map[int]models.Booking
+	cottages map[int]models.Cottage
+	guests   map[int]models.Guest
+	tariffs  map[int]models.Tariff
+	groups   map[int]models.BookingGroup
+	changes  map[int]models.BookingChange
+	rates    map[int]models.TariffRate
+	holidays map[int]models.Holiday
+	// restrictions — ограничения сроков брони
+	restrictions map[int]models.StayRestriction
+	// discounts — промокоды вместе с их тарифами
+	discounts map[int]models.Discount
+	// payments — журнал оплат броней
+	payments map[int]models.Payment
+	// policies — правила отмены броней
+	policies map[int]models.CancellationPolicy
+	// invoices — выставленные счета
+	invoices map[int]models.Invoice
+	// extras — справочник платных услуг, folio — услуги по броням
+	extras map[int]models.Extra
+	folio  map[int]models.FolioItem
+
+	nextBookingID     int
+	nextCottageID     int
+	nextGuestID       int
+	nextTariffID      int
+	nextGroupID       int
+	nextChangeID      int
+	nextRateID        int
+	nextHolidayID     int
+	nextRestrictionID int
+	nextDiscountID    int
+	nextPaymentID     int
+	nextPolicyID      int
+	nextInvoiceID     int
+	nextExtraID       int
+	nextFolioID       int
+}
+
+// NewStore создает пустое хранилище
+func NewStore() *Store {
+	return &Store{
+		mu: &sync.Mutex{},
+		data: &data{
+			bookings:          make(map[int]models.Booking),
+			cottages:          make(map[int]models.Cottage),
+			guests:            make(map[int]models.Guest),
+			tariffs:           make(map[int]models.Tariff),
+			groups:            make(map[int]models.BookingGroup),
+			changes:           make(map[int]models.BookingChange),
+			rates:             make(map[int]models.TariffRate),
+			holidays:          make(map[int]models.Holiday),
+			restrictions:      make(map[int]models.StayRestriction),
+			discounts:         make(map[int]models.Discount),
+			payments:          make(map[int]models.Payment),
+			policies:          make(map[int]models.CancellationPolicy),
+			invoices:          make(map[int]models.Invoice),
+			extras:            make(map[int]models.Extra),
+			folio:             make(map[int]models.FolioItem),
+			nextBookingID:     1,
+			nextCottageID:     1,
+			nextGuestID:       1,
+			nextTariffID:      1,
+			nextGroupID:       1,
+			nextChangeID:      1,
+			nextRateID:        1,
+			nextHolidayID:     1,
+			nextRestrictionID: 1,
+			nextDiscountID:    1,
+			nextPaymentID:     1,
+			nextPolicyID:      1,
+			nextInvoiceID:     1,
+			nextExtraID:       1,
+			nextFolioID:       1,
+		},
+	}
+}
+
+var _ repository.Store = (*Store)(nil)
+
+func (s *Store) Bookings() repository.BookingRepository    { return &bookingRepo{s: s} }
+func (s *Store) Cottages() repository.CottageRepository    { return &cottageRepo{s: s} }
+func (s *Store) Guests() repository.GuestRepository        { return &guestRepo{s: s} }
+func (s *Store) Tariffs() repository.TariffRepository      { return &tariffRepo{s: s} }
+func (s *Store) Groups() repository.BookingGroupRepository { return &groupRepo{s: s} }
+func (s *Store) BookingChanges() repository.BookingChangeRepository {
+	return &changeRepo{s: s}
+}
+func (s *Store) TariffRates() repository.TariffRateRepository { return &rateRepo{s: s} }
+func (s *Store) Holidays() repository.HolidayRepository       { return &holidayRepo{s: s} }
+func (s *Store) StayRestrictions() repository.StayRestrictionRepository {
+	return &restrictionRepo{s: s}
+}
+func (s *Store) Discounts() repository.DiscountRepository { return &discountRepo{s: s} }
+func (s *Store) Payments() repository.PaymentRepository   { return &paymentRepo{s: s} }
+func (s *Store) CancellationPolicies() repository.CancellationPolicyRepository {
+	return &policyRepo{s: s}
+}
+func (s *Store) Invoices() repository.InvoiceRepository { return &invoiceRepo{s: s} }
+func (s *Store) Extras() repository.ExtraRepository     { return &extraRepo{s: s} }
+func (s *Store) Folio() repository.FolioRepository      { return &folioRepo{s: s} }
+
+// WithTx выполняет fn над копией данных и публикует ее при успехе.
+// Транзакции выполняются последовательно, как SERIALIZABLE.
+func (s *Store) WithTx(fn func(tx repository.Store) error) error {
+	if s.inTx {
+		return fn(s)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := s.data.clone()
+	if err := fn(&Store{mu: s.mu, data: snapshot, inTx: true}); err != nil {
+		return err
+	}
+	*s.data = *snapshot
+	return nil
+}
+
+// lock блокирует хранилище вне транзакции и возвращает функцию разблокировки
+func (s *Store) lock() func() {
+	if s.inTx {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+func (d *data) clone() *data {
+	c := *d
+	c.bookings = cloneMap(d.bookings)
+	c.cottages = cloneMap(d.cottages)
+	c.guests = cloneMap(d.guests)
+	c.tariffs = cloneMap(d.tariffs)
+	c.groups = cloneMap(d.groups)
+	c.changes = cloneMap(d.changes)
+	c.rates = cloneMap(d.rates)
+	c.holidays = cloneMap(d.holidays)
+	c.restrictions = cloneMap(d.restrictions)
+	c.discounts = cloneMap(d.discounts)
+	c.payments = cloneMap(d.payments)
+	c.policies = cloneMap(d.policies)
+	c.invoices = cloneMap(d.invoices)
+	c.extras = cloneMap(d.extras)
+	c.folio = cloneMap(d.folio)
+	return &c
+}
+
+func cloneMap[V any](m map[int]V) map[int]V {
+	c := make(map[int]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// sortedIDs возвращает ключи по возрастанию, чтобы порядок был стабильным
+func sortedIDs[V any](m map[int]V) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// between повторяет семантику SQL BETWEEN (включительно)
+func between(t, from, to time.Time) bool {
+	return !t.Before(from) && !t.After(to)
+}
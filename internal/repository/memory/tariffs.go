@@ -0,0 +1,79 @@
+// internal/repository/memory/tariffs.go
+package memory
+
+import (
+	"sort"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+type tariffRepo struct {
+	s *Store
+}
+
+func (r *tariffRepo) Create(tariff *models.Tariff) error {
+	defer r.s.lock()()
+
+	tariff.ID = r.s.data.nextTariffID
+	r.s.data.nextTariffID++
+	r.s.data.tariffs[tariff.ID] = *tariff
+	return nil
+}
+
+func (r *tariffRepo) List() ([]models.Tariff, error) {
+	defer r.s.lock()()
+
+	var tariffs []models.Tariff
+	for _, id := range sortedIDs(r.s.data.tariffs) {
+		tariffs = append(tariffs, r.s.data.tariffs[id])
+	}
+	sort.SliceStable(tariffs, func(i, j int) bool {
+		return tariffs[i].Name < tariffs[j].Name
+	})
+	return tariffs, nil
+}
+
+func (r *tariffRepo) GetByID(tariffID int) (*models.Tariff, error) {
+	defer r.s.lock()()
+
+	t, ok := r.s.data.tariffs[tariffID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &t, nil
+}
+
+func (r *tariffRepo) Update(tariff models.Tariff) error {
+	defer r.s.lock()()
+
+	if _, ok := r.s.data.tariffs[tariff.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	r.s.data.tariffs[tariff.ID] = tariff
+	return nil
+}
+
+func (r *tariffRepo) Delete(tariffID int) error {
+	defer r.s.lock()()
+
+	delete(r.s.data.tariffs, tariffID)
+	// Цены тарифа и его привязки к промокодам удаляются вместе с ним,
+	// как ON DELETE CASCADE
+	for id, rate := range r.s.data.rates {
+		if rate.TariffID == tariffID {
+			delete(r.s.data.rates, id)
+		}
+	}
+	for id, d := range r.s.data.discounts {
+		var kept []int
+		for _, t := range d.TariffIDs {
+			if t != tariffID {
+				kept = append(kept, t)
+			}
+		}
+		d.TariffIDs = kept
+		r.s.data.discounts[id] = d
+	}
+	return nil
+}
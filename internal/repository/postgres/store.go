@@ -0,0 +1,65 @@
+// internal/repository/postgres/store.go
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/repository"
+	"github.com/VallfIK/bazaotdx/internal/repository/sqlstore"
+	"github.com/lib/pq"
+)
+
+// Коды ошибок PostgreSQL при нарушении EXCLUDE- и UNIQUE-ограничений
+const (
+	exclusionViolation = "23P01"
+	uniqueViolation    = "23505"
+)
+
+// NewStore создает хранилище на PostgreSQL (схема lesbaza) поверх
+// открытого подключения
+func NewStore(db *sql.DB) *sqlstore.Store {
+	return sqlstore.New(db, dialect{})
+}
+
+// dialect — параметры $1, $2…, таблицы схемы lesbaza, даты типом TIMESTAMP
+type dialect struct{}
+
+func (dialect) Placeholder(n int) string { return "$" + strconv.Itoa(n) }
+func (dialect) TablePrefix() string      { return "lesbaza." }
+
+func (dialect) EncodeTime(t time.Time) any { return t }
+
+func (dialect) ScanTime(t *time.Time) sql.Scanner {
+	return timeValue{t}
+}
+
+// TranslateError превращает нарушение ограничения bookings_no_overlap
+// в repository.ErrOverlap, а нарушение UNIQUE — в repository.ErrDuplicate
+func (dialect) TranslateError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == exclusionViolation &&
+		pqErr.Constraint == "bookings_no_overlap" {
+		return repository.ErrOverlap
+	}
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return repository.ErrDuplicate
+	}
+	return err
+}
+
+// timeValue читает TIMESTAMP, допускающий NULL
+type timeValue struct {
+	t *time.Time
+}
+
+func (v timeValue) Scan(src any) error {
+	var nt sql.NullTime
+	if err := nt.Scan(src); err != nil {
+		return err
+	}
+	*v.t = nt.Time
+	return nil
+}
@@ -0,0 +1,246 @@
+// internal/repository/repository.go
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// ErrNotFound возвращается, когда запись с указанным ID отсутствует
+var ErrNotFound = errors.New("запись не найдена")
+
+// ErrOverlap возвращается, когда действующая бронь пересекается с другой
+// бронью того же домика (ограничение bookings_no_overlap в базе)
+var ErrOverlap = errors.New("бронь пересекается с другой бронью домика")
+
+// ErrConflict возвращается, когда запись успели изменить с другого рабочего места
+var ErrConflict = errors.New("запись изменена другим пользователем, обновите данные")
+
+// ErrDuplicate возвращается, когда запись нарушает ограничение уникальности,
+// например номер счета уже занял параллельный запрос
+var ErrDuplicate = errors.New("такая запись уже есть")
+
+// Store объединяет хранилища всех сущностей базы отдыха
+type Store interface {
+	Bookings() BookingRepository
+	Cottages() CottageRepository
+	Guests() GuestRepository
+	Tariffs() TariffRepository
+	Groups() BookingGroupRepository
+	BookingChanges() BookingChangeRepository
+	TariffRates() TariffRateRepository
+	Holidays() HolidayRepository
+	StayRestrictions() StayRestrictionRepository
+	Discounts() DiscountRepository
+	Payments() PaymentRepository
+	CancellationPolicies() CancellationPolicyRepository
+	Invoices() InvoiceRepository
+	Extras() ExtraRepository
+	Folio() FolioRepository
+
+	// WithTx выполняет fn в одной транзакции: изменения сохраняются,
+	// только если fn вернула nil. Вложенный вызов использует ту же транзакцию.
+	WithTx(fn func(tx Store) error) error
+}
+
+// BookingRepository хранит брони (lesbaza.bookings)
+type BookingRepository interface {
+	// Create сохраняет бронь и заполняет booking.ID.
+	// Пересечение с действующей бронью домика дает ErrOverlap.
+	Create(booking *models.Booking) error
+	GetByID(bookingID int) (*models.Booking, error)
+
+	// CountOverlapping считает брони домика с указанными статусами,
+	// пересекающиеся с периодом [checkIn, checkOut), кроме брони
+	// exceptBookingID (0 — учитывать все)
+	CountOverlapping(cottageID int, checkIn, checkOut time.Time, statuses []string, exceptBookingID int) (int, error)
+	// CountByCottage считает брони домика с указанными статусами
+	CountByCottage(cottageID int, statuses []string) (int, error)
+
+	// ListByDateRange возвращает брони, затрагивающие период [start, end],
+	// кроме броней с исключенными статусами, по дате заезда
+	ListByDateRange(start, end time.Time, excludeStatuses []string) ([]models.Booking, error)
+	// ListByStatus возвращает брони со статусом и заездом не раньше checkInFrom
+	ListByStatus(status string, checkInFrom time.Time) ([]models.Booking, error)
+	// ListByGroup возвращает брони групповой брони по номеру домика
+	ListByGroup(groupID int) ([]models.Booking, error)
+
+	// Update сохраняет домик, гостя, состав гостей, даты, тариф, стоимость,
+	// скидку и предоплату, примечания, срок удержания и причину блокировки.
+	// Статус, дата создания, группа и промокод не меняются.
+	Update(booking models.Booking) error
+	// UpdateStatus меняет статус брони с from на to. Если текущий статус
+	// уже не from, возвращает ErrConflict.
+	UpdateStatus(bookingID int, from, to string) error
+	// UpdateCheckOut меняет дату выезда, стоимость и скидку, дописывая note к примечаниям
+	UpdateCheckOut(bookingID int, checkOut time.Time, totalCost, discount models.Money, note string) error
+
+	// DeleteReleasedHoldsBefore удаляет удержания, снятые без подтверждения
+	// (отмененные брони со сроком удержания), с выездом до before. Брони
+	// с оплатами, услугами и счетами не удаляются.
+	DeleteReleasedHoldsBefore(before time.Time) (int64, error)
+}
+
+// CottageRepository хранит домики (lesbaza.cottages)
+type CottageRepository interface {
+	// Create сохраняет домик и заполняет cottage.ID
+	Create(cottage *models.Cottage) error
+	List() ([]models.Cottage, error)
+	GetByID(cottageID int) (*models.Cottage, error)
+	ListByStatus(status string) ([]models.Cottage, error)
+	// ListAvailable возвращает домики без действующих броней на период
+	ListAvailable(checkIn, checkOut time.Time) ([]models.Cottage, error)
+
+	UpdateStatus(cottageID int, status string) error
+	UpdateName(cottageID int, name string) error
+	// UpdateCapacity меняет вместимость домика (0 — без ограничения)
+	UpdateCapacity(cottageID, maxAdults, maxGuests int) error
+	Delete(cottageID int) error
+}
+
+// GuestRepository хранит заселенных гостей (lesbaza.guests)
+type GuestRepository interface {
+	// Create сохраняет гостя и заполняет guest.ID
+	Create(guest *models.Guest) error
+	GetByCottageID(cottageID int) (*models.Guest, error)
+	// List возвращает всех гостей по порядку регистрации
+	List() ([]models.Guest, error)
+	CountByCottage(cottageID int) (int, error)
+	CountByTariff(tariffID int) (int, error)
+
+	DeleteByCottageID(cottageID int) error
+	// DeleteCheckedOutBefore удаляет гостей с датой выезда не позже before
+	DeleteCheckedOutBefore(before time.Time) (int64, error)
+}
+
+// TariffRepository хранит тарифы (lesbaza.tariffs)
+type TariffRepository interface {
+	// Create сохраняет тариф и заполняет tariff.ID
+	Create(tariff *models.Tariff) error
+	List() ([]models.Tariff, error)
+	GetByID(tariffID int) (*models.Tariff, error)
+	Update(tariff models.Tariff) error
+	Delete(tariffID int) error
+}
+
+// TariffRateRepository хранит сезонные цены и цены домиков (lesbaza.tariff_rates)
+type TariffRateRepository interface {
+	// Create сохраняет цену и заполняет rate.ID
+	Create(rate *models.TariffRate) error
+	// ListByTariff возвращает цены тарифа: сначала круглогодичные, затем сезоны по дате начала
+	ListByTariff(tariffID int) ([]models.TariffRate, error)
+	Update(rate models.TariffRate) error
+	Delete(rateID int) error
+}
+
+// HolidayRepository хранит праздничные дни (lesbaza.holidays)
+type HolidayRepository interface {
+	// Create сохраняет праздник и заполняет holiday.ID
+	Create(holiday *models.Holiday) error
+	// ListBetween возвращает праздники с from по to включительно по дате
+	ListBetween(from, to time.Time) ([]models.Holiday, error)
+	Delete(holidayID int) error
+}
+
+// StayRestrictionRepository хранит ограничения сроков брони (lesbaza.stay_restrictions)
+type StayRestrictionRepository interface {
+	// Create сохраняет ограничение и заполняет restriction.ID
+	Create(restriction *models.StayRestriction) error
+	// ListBetween возвращает ограничения, период которых пересекается
+	// с периодом [from, to], по дате начала
+	ListBetween(from, to time.Time) ([]models.StayRestriction, error)
+	Update(restriction models.StayRestriction) error
+	Delete(restrictionID int) error
+}
+
+// DiscountRepository хранит промокоды (lesbaza.discounts) и тарифы,
+// к которым они применяются (lesbaza.discount_tariffs)
+type DiscountRepository interface {
+	// Create сохраняет промокод с тарифами и заполняет discount.ID
+	Create(discount *models.Discount) error
+	GetByID(discountID int) (*models.Discount, error)
+	// GetByCode возвращает промокод по коду или ErrNotFound
+	GetByCode(code string) (*models.Discount, error)
+	// List возвращает все промокоды по коду
+	List() ([]models.Discount, error)
+	// Update сохраняет промокод и заменяет его тарифы; счетчик использований не меняется
+	Update(discount models.Discount) error
+	// Redeem засчитывает одно использование включенного промокода. Если
+	// лимит исчерпан или промокод отключен, возвращает ErrNotFound.
+	Redeem(discountID int) error
+	Delete(discountID int) error
+}
+
+// PaymentRepository хранит журнал оплат броней (lesbaza.payments)
+type PaymentRepository interface {
+	// Create сохраняет запись и заполняет payment.ID
+	Create(payment *models.Payment) error
+	// ListByBooking возвращает оплаты брони по времени
+	ListByBooking(bookingID int) ([]models.Payment, error)
+	// ListBetween возвращает оплаты с from по to включительно по времени
+	ListBetween(from, to time.Time) ([]models.Payment, error)
+}
+
+// CancellationPolicyRepository хранит правила отмены броней (lesbaza.cancellation_policies)
+type CancellationPolicyRepository interface {
+	// Create сохраняет правила и заполняет policy.ID
+	Create(policy *models.CancellationPolicy) error
+	// List возвращает правила по названию
+	List() ([]models.CancellationPolicy, error)
+	GetByID(policyID int) (*models.CancellationPolicy, error)
+	Update(policy models.CancellationPolicy) error
+	// Delete удаляет правила; тарифы с ними остаются без штрафов
+	Delete(policyID int) error
+}
+
+// InvoiceRepository хранит выставленные счета (lesbaza.invoices)
+type InvoiceRepository interface {
+	// Create сохраняет счет и заполняет invoice.ID; номер года и Seq
+	// должны быть уникальны, занятый номер дает ErrDuplicate
+	Create(invoice *models.Invoice) error
+	// ListByBooking возвращает счета брони по порядку выставления
+	ListByBooking(bookingID int) ([]models.Invoice, error)
+	// LastSeq возвращает последний номер счета за год (0 — счетов не было)
+	LastSeq(year int) (int, error)
+}
+
+// ExtraRepository хранит справочник платных услуг (lesbaza.extras)
+type ExtraRepository interface {
+	// Create сохраняет услугу и заполняет extra.ID
+	Create(extra *models.Extra) error
+	// List возвращает услуги по названию
+	List() ([]models.Extra, error)
+	GetByID(extraID int) (*models.Extra, error)
+	Update(extra models.Extra) error
+	// Delete удаляет услугу; строки фолио с ней остаются без ссылки на справочник
+	Delete(extraID int) error
+}
+
+// FolioRepository хранит услуги, заказанные по броням (lesbaza.folio_items)
+type FolioRepository interface {
+	// Create сохраняет строку фолио и заполняет item.ID
+	Create(item *models.FolioItem) error
+	GetByID(itemID int) (*models.FolioItem, error)
+	// ListByBooking возвращает услуги брони по дате оказания
+	ListByBooking(bookingID int) ([]models.FolioItem, error)
+	Delete(itemID int) error
+}
+
+// BookingGroupRepository хранит групповые брони (lesbaza.booking_groups)
+type BookingGroupRepository interface {
+	// Create сохраняет группу и заполняет group.ID
+	Create(group *models.BookingGroup) error
+	GetByID(groupID int) (*models.BookingGroup, error)
+	// Update сохраняет название, плательщика и примечания группы
+	Update(group models.BookingGroup) error
+}
+
+// BookingChangeRepository хранит журнал изменений броней (lesbaza.booking_changes)
+type BookingChangeRepository interface {
+	// Create сохраняет запись и заполняет change.ID
+	Create(change *models.BookingChange) error
+	// ListByBooking возвращает изменения брони в порядке их внесения
+	ListByBooking(bookingID int) ([]models.BookingChange, error)
+}
@@ -0,0 +1,81 @@
+// internal/repository/sqlite/store.go
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/repository"
+	"github.com/VallfIK/bazaotdx/internal/repository/sqlstore"
+)
+
+// timeLayout — формат хранения дат. Время пишется в UTC с фиксированной
+// шириной, поэтому строки сравниваются в SQL так же, как сами даты.
+const timeLayout = "2006-01-02 15:04:05.000000"
+
+// NewStore создает хранилище в файле SQLite для работы на одной машине
+// без сервера БД поверх открытого подключения
+func NewStore(db *sql.DB) *sqlstore.Store {
+	return sqlstore.New(db, dialect{})
+}
+
+// dialect — параметры "?", таблицы без схемы, даты строками timeLayout
+type dialect struct{}
+
+func (dialect) Placeholder(int) string { return "?" }
+func (dialect) TablePrefix() string    { return "" }
+
+// EncodeTime приводит время к формату хранения
+func (dialect) EncodeTime(t time.Time) any {
+	return t.UTC().Format(timeLayout)
+}
+
+func (dialect) ScanTime(t *time.Time) sql.Scanner {
+	return timeValue{t}
+}
+
+// TranslateError превращает отказ триггеров bookings_no_overlap
+// в repository.ErrOverlap, а нарушение UNIQUE — в repository.ErrDuplicate
+func (dialect) TranslateError(err error) error {
+	if strings.Contains(err.Error(), "bookings_no_overlap") {
+		return repository.ErrOverlap
+	}
+	if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return repository.ErrDuplicate
+	}
+	return err
+}
+
+// timeValue читает дату, сохраненную EncodeTime. Драйвер может вернуть
+// её строкой или уже разобранной, в зависимости от типа столбца.
+type timeValue struct {
+	t *time.Time
+}
+
+func (v timeValue) Scan(src any) error {
+	switch x := src.(type) {
+	case nil:
+		*v.t = time.Time{}
+		return nil
+	case time.Time:
+		*v.t = x.Local()
+		return nil
+	case string:
+		return v.parse(x)
+	case []byte:
+		return v.parse(string(x))
+	default:
+		return fmt.Errorf("sqlite: cannot scan %T into time", src)
+	}
+}
+
+func (v timeValue) parse(s string) error {
+	t, err := time.ParseInLocation(timeLayout, s, time.UTC)
+	if err != nil {
+		return fmt.Errorf("sqlite: invalid time %q: %w", s, err)
+	}
+	*v.t = t.Local()
+	return nil
+}
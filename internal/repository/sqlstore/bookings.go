@@ -0,0 +1,255 @@
+// internal/repository/sqlstore/bookings.go
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+const bookingColumns = `b.booking_id, b.cottage_id, b.guest_name, b.phone, b.email,
+	b.check_in_date, b.check_out_date, b.status, b.created_at,
+	b.notes, COALESCE(b.tariff_id, 0), COALESCE(b.total_cost, 0), b.hold_expires_at,
+	b.block_reason, COALESCE(b.group_id, 0), b.adults, b.children,
+	COALESCE(b.discount_id, 0), b.discount_code, b.discount_amount, b.deposit_amount,
+	(SELECT CAST(COALESCE(SUM(f.quantity * f.unit_price), 0) AS BIGINT) FROM {folio_items} f WHERE f.booking_id = b.booking_id)`
+
+type bookingRepo struct {
+	q conn
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *bookingRepo) scanBooking(row rowScanner) (models.Booking, error) {
+	var b models.Booking
+	err := row.Scan(
+		&b.ID, &b.CottageID, &b.GuestName, &b.Phone, &b.Email,
+		r.q.scanTime(&b.CheckInDate), r.q.scanTime(&b.CheckOutDate), &b.Status, r.q.scanTime(&b.CreatedAt),
+		&b.Notes, &b.TariffID, &b.TotalCost, r.q.scanTime(&b.HoldExpiresAt),
+		&b.BlockReason, &b.GroupID, &b.Adults, &b.Children,
+		&b.DiscountID, &b.DiscountCode, &b.DiscountAmount, &b.DepositAmount,
+		&b.ExtrasTotal,
+	)
+	return b, err
+}
+
+func (r *bookingRepo) queryBookings(query string, args ...any) ([]models.Booking, error) {
+	rows, err := r.q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookings []models.Booking
+	for rows.Next() {
+		b, err := r.scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+func (r *bookingRepo) Create(booking *models.Booking) error {
+	err := r.q.QueryRow(`
+		INSERT INTO {bookings} 
+		(cottage_id, guest_name, phone, email, check_in_date, check_out_date, 
+		 status, created_at, notes, tariff_id, total_cost, hold_expires_at, block_reason, group_id,
+		 adults, children, discount_id, discount_code, discount_amount, deposit_amount)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING booking_id`,
+		booking.CottageID,
+		booking.GuestName,
+		booking.Phone,
+		booking.Email,
+		r.q.time(booking.CheckInDate),
+		r.q.time(booking.CheckOutDate),
+		booking.Status,
+		r.q.time(booking.CreatedAt),
+		booking.Notes,
+		nullInt(booking.TariffID),
+		booking.TotalCost,
+		r.q.nullTime(booking.HoldExpiresAt),
+		booking.BlockReason,
+		nullInt(booking.GroupID),
+		booking.Adults,
+		booking.Children,
+		nullInt(booking.DiscountID),
+		booking.DiscountCode,
+		booking.DiscountAmount,
+		booking.DepositAmount,
+	).Scan(&booking.ID)
+	return r.q.translate(err)
+}
+
+func (r *bookingRepo) GetByID(bookingID int) (*models.Booking, error) {
+	booking, err := r.scanBooking(r.q.QueryRow(`
+		SELECT `+bookingColumns+`
+		FROM {bookings} b
+		WHERE b.booking_id = ?`,
+		bookingID,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &booking, nil
+}
+
+func (r *bookingRepo) CountOverlapping(cottageID int, checkIn, checkOut time.Time, statuses []string, exceptBookingID int) (int, error) {
+	cond, args := statusIn("status", statuses, false)
+	var count int
+	err := r.q.QueryRow(`
+		SELECT COUNT(*) FROM {bookings}
+		WHERE cottage_id = ?
+		AND NOT (check_out_date <= ? OR check_in_date >= ?)
+		AND booking_id <> ?
+		AND `+cond,
+		append([]any{cottageID, r.q.time(checkIn), r.q.time(checkOut), exceptBookingID}, args...)...,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *bookingRepo) CountByCottage(cottageID int, statuses []string) (int, error) {
+	cond, args := statusIn("status", statuses, false)
+	var count int
+	err := r.q.QueryRow(`
+		SELECT COUNT(*) 
+		FROM {bookings} 
+		WHERE cottage_id = ? 
+		AND `+cond,
+		append([]any{cottageID}, args...)...,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *bookingRepo) ListByDateRange(start, end time.Time, excludeStatuses []string) ([]models.Booking, error) {
+	cond, args := statusIn("b.status", excludeStatuses, true)
+	return r.queryBookings(`
+		SELECT `+bookingColumns+`
+		FROM {bookings} b
+		WHERE (b.check_in_date <= ? AND b.check_out_date >= ?)
+		AND `+cond+`
+		ORDER BY b.check_in_date`,
+		append([]any{r.q.time(end), r.q.time(start)}, args...)...,
+	)
+}
+
+func (r *bookingRepo) ListByStatus(status string, checkInFrom time.Time) ([]models.Booking, error) {
+	return r.queryBookings(`
+		SELECT `+bookingColumns+`
+		FROM {bookings} b
+		WHERE b.status = ? AND b.check_in_date >= ?
+		ORDER BY b.check_in_date ASC`,
+		status, r.q.time(checkInFrom),
+	)
+}
+
+func (r *bookingRepo) ListByGroup(groupID int) ([]models.Booking, error) {
+	return r.queryBookings(`
+		SELECT `+bookingColumns+`
+		FROM {bookings} b
+		WHERE b.group_id = ?
+		ORDER BY b.cottage_id`,
+		groupID,
+	)
+}
+
+func (r *bookingRepo) Update(booking models.Booking) error {
+	result, err := r.q.Exec(`
+		UPDATE {bookings} 
+		SET cottage_id = ?, guest_name = ?, phone = ?, email = ?,
+			check_in_date = ?, check_out_date = ?, notes = ?,
+			tariff_id = ?, total_cost = ?, hold_expires_at = ?, block_reason = ?,
+			adults = ?, children = ?, discount_amount = ?, deposit_amount = ?
+		WHERE booking_id = ?`,
+		booking.CottageID,
+		booking.GuestName,
+		booking.Phone,
+		booking.Email,
+		r.q.time(booking.CheckInDate),
+		r.q.time(booking.CheckOutDate),
+		booking.Notes,
+		nullInt(booking.TariffID),
+		booking.TotalCost,
+		r.q.nullTime(booking.HoldExpiresAt),
+		booking.BlockReason,
+		booking.Adults,
+		booking.Children,
+		booking.DiscountAmount,
+		booking.DepositAmount,
+		booking.ID,
+	)
+	if err != nil {
+		return r.q.translate(err)
+	}
+	return requireAffected(result)
+}
+
+func (r *bookingRepo) UpdateStatus(bookingID int, from, to string) error {
+	result, err := r.q.Exec(
+		"UPDATE {bookings} SET status = ? WHERE booking_id = ? AND status = ?",
+		to, bookingID, from,
+	)
+	if err != nil {
+		return r.q.translate(err)
+	}
+	return r.requireStatusChanged(result, bookingID)
+}
+
+// requireStatusChanged отличает отсутствующую бронь от брони,
+// статус которой успели изменить
+func (r *bookingRepo) requireStatusChanged(result sql.Result, bookingID int) error {
+	if err := requireAffected(result); !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	var exists bool
+	err := r.q.QueryRow("SELECT EXISTS (SELECT 1 FROM {bookings} WHERE booking_id = ?)", bookingID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return repository.ErrConflict
+	}
+	return repository.ErrNotFound
+}
+
+func (r *bookingRepo) UpdateCheckOut(bookingID int, checkOut time.Time, totalCost, discount models.Money, note string) error {
+	result, err := r.q.Exec(`
+		UPDATE {bookings} 
+		SET check_out_date = ?, total_cost = ?, discount_amount = ?, notes = COALESCE(notes, '') || ?
+		WHERE booking_id = ?`,
+		r.q.time(checkOut), totalCost, discount, note, bookingID,
+	)
+	if err != nil {
+		return r.q.translate(err)
+	}
+	return requireAffected(result)
+}
+
+func (r *bookingRepo) DeleteReleasedHoldsBefore(before time.Time) (int64, error) {
+	result, err := r.q.Exec(`
+		DELETE FROM {bookings}
+		WHERE status = ? AND hold_expires_at IS NOT NULL AND check_out_date < ?
+		  AND NOT EXISTS (SELECT 1 FROM {payments} p WHERE p.booking_id = {bookings}.booking_id)
+		  AND NOT EXISTS (SELECT 1 FROM {folio_items} f WHERE f.booking_id = {bookings}.booking_id)
+		  AND NOT EXISTS (SELECT 1 FROM {invoices} i WHERE i.booking_id = {bookings}.booking_id)`,
+		models.BookingStatusCancelled, r.q.time(before),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
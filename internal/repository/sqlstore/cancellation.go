@@ -0,0 +1,84 @@
+// internal/repository/sqlstore/cancellation.go
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+const policyColumns = `policy_id, name, free_days, penalty_percent, non_refundable`
+
+type policyRepo struct {
+	q conn
+}
+
+func (r *policyRepo) Create(policy *models.CancellationPolicy) error {
+	return r.q.QueryRow(`
+		INSERT INTO {cancellation_policies} (name, free_days, penalty_percent, non_refundable)
+		VALUES (?, ?, ?, ?)
+		RETURNING policy_id`,
+		policy.Name, policy.FreeDays, policy.PenaltyPercent, policy.NonRefundable,
+	).Scan(&policy.ID)
+}
+
+func (r *policyRepo) List() ([]models.CancellationPolicy, error) {
+	rows, err := r.q.Query("SELECT " + policyColumns + " FROM {cancellation_policies} ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var policies []models.CancellationPolicy
+	for rows.Next() {
+		p, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (r *policyRepo) GetByID(policyID int) (*models.CancellationPolicy, error) {
+	p, err := scanPolicy(r.q.QueryRow(
+		"SELECT "+policyColumns+" FROM {cancellation_policies} WHERE policy_id = ?",
+		policyID,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *policyRepo) Update(policy models.CancellationPolicy) error {
+	result, err := r.q.Exec(`
+		UPDATE {cancellation_policies}
+		SET name = ?, free_days = ?, penalty_percent = ?, non_refundable = ?
+		WHERE policy_id = ?`,
+		policy.Name, policy.FreeDays, policy.PenaltyPercent, policy.NonRefundable, policy.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func (r *policyRepo) Delete(policyID int) error {
+	_, err := r.q.Exec("DELETE FROM {cancellation_policies} WHERE policy_id = ?", policyID)
+	return err
+}
+
+func scanPolicy(row rowScanner) (models.CancellationPolicy, error) {
+	var p models.CancellationPolicy
+	err := row.Scan(&p.ID, &p.Name, &p.FreeDays, &p.PenaltyPercent, &p.NonRefundable)
+	return p, err
+}
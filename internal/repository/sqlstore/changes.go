@@ -0,0 +1,72 @@
+// internal/repository/sqlstore/changes.go
+package sqlstore
+
+import (
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+type changeRepo struct {
+	q conn
+}
+
+func (r *changeRepo) Create(change *models.BookingChange) error {
+	return r.q.QueryRow(`
+		INSERT INTO {booking_changes}
+		(booking_id, changed_at, old_cottage_id, new_cottage_id,
+		 old_check_in_date, new_check_in_date, old_check_out_date, new_check_out_date,
+		 old_tariff_id, new_tariff_id, old_total_cost, new_total_cost, reason)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING change_id`,
+		change.BookingID,
+		r.q.time(change.ChangedAt),
+		change.OldCottageID,
+		change.NewCottageID,
+		r.q.time(change.OldCheckInDate),
+		r.q.time(change.NewCheckInDate),
+		r.q.time(change.OldCheckOutDate),
+		r.q.time(change.NewCheckOutDate),
+		nullInt(change.OldTariffID),
+		nullInt(change.NewTariffID),
+		change.OldTotalCost,
+		change.NewTotalCost,
+		change.Reason,
+	).Scan(&change.ID)
+}
+
+func (r *changeRepo) ListByBooking(bookingID int) ([]models.BookingChange, error) {
+	rows, err := r.q.Query(`
+		SELECT change_id, booking_id, changed_at, old_cottage_id, new_cottage_id,
+			old_check_in_date, new_check_in_date, old_check_out_date, new_check_out_date,
+			COALESCE(old_tariff_id, 0), COALESCE(new_tariff_id, 0),
+			old_total_cost, new_total_cost, reason
+		FROM {booking_changes}
+		WHERE booking_id = ?
+		ORDER BY changed_at, change_id`,
+		bookingID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []models.BookingChange
+	for rows.Next() {
+		var c models.BookingChange
+		err := rows.Scan(
+			&c.ID, &c.BookingID, r.q.scanTime(&c.ChangedAt), &c.OldCottageID, &c.NewCottageID,
+			r.q.scanTime(&c.OldCheckInDate), r.q.scanTime(&c.NewCheckInDate),
+			r.q.scanTime(&c.OldCheckOutDate), r.q.scanTime(&c.NewCheckOutDate),
+			&c.OldTariffID, &c.NewTariffID,
+			&c.OldTotalCost, &c.NewTotalCost, &c.Reason,
+		)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
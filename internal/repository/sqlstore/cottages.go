@@ -0,0 +1,127 @@
+// internal/repository/sqlstore/cottages.go
+package sqlstore
+
+import (
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+type cottageRepo struct {
+	q conn
+}
+
+func (r *cottageRepo) queryCottages(query string, args ...any) ([]models.Cottage, error) {
+	rows, err := r.q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cottages []models.Cottage
+	for rows.Next() {
+		var c models.Cottage
+		if err := rows.Scan(&c.ID, &c.Name, &c.Status, &c.MaxAdults, &c.MaxGuests); err != nil {
+			return nil, err
+		}
+		cottages = append(cottages, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cottages, nil
+}
+
+func (r *cottageRepo) Create(cottage *models.Cottage) error {
+	return r.q.QueryRow(
+		"INSERT INTO {cottages} (name, status, max_adults, max_guests) VALUES (?, ?, ?, ?) RETURNING cottage_id",
+		cottage.Name, cottage.Status, cottage.MaxAdults, cottage.MaxGuests,
+	).Scan(&cottage.ID)
+}
+
+func (r *cottageRepo) List() ([]models.Cottage, error) {
+	return r.queryCottages("SELECT cottage_id, name, status, max_adults, max_guests FROM {cottages} ORDER BY cottage_id")
+}
+
+func (r *cottageRepo) GetByID(cottageID int) (*models.Cottage, error) {
+	cottages, err := r.queryCottages(
+		"SELECT cottage_id, name, status, max_adults, max_guests FROM {cottages} WHERE cottage_id = ?",
+		cottageID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(cottages) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return &cottages[0], nil
+}
+
+func (r *cottageRepo) ListByStatus(status string) ([]models.Cottage, error) {
+	return r.queryCottages(
+		"SELECT cottage_id, name, status, max_adults, max_guests FROM {cottages} WHERE status = ? ORDER BY cottage_id",
+		status,
+	)
+}
+
+func (r *cottageRepo) ListAvailable(checkIn, checkOut time.Time) ([]models.Cottage, error) {
+	return r.queryCottages(`
+		SELECT c.cottage_id, c.name, c.status, c.max_adults, c.max_guests
+		FROM {cottages} c
+		WHERE c.cottage_id NOT IN (
+			SELECT b.cottage_id
+			FROM {bookings} b
+			WHERE b.status NOT IN ('cancelled', 'completed')
+			AND (
+				(? BETWEEN b.check_in_date AND b.check_out_date)
+				OR (? BETWEEN b.check_in_date AND b.check_out_date)
+				OR (b.check_in_date BETWEEN ? AND ?)
+			)
+		)
+		ORDER BY c.cottage_id`,
+		r.q.time(checkIn), r.q.time(checkOut), r.q.time(checkIn), r.q.time(checkOut),
+	)
+}
+
+func (r *cottageRepo) UpdateStatus(cottageID int, status string) error {
+	result, err := r.q.Exec(
+		"UPDATE {cottages} SET status = ? WHERE cottage_id = ?",
+		status, cottageID,
+	)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func (r *cottageRepo) UpdateName(cottageID int, name string) error {
+	result, err := r.q.Exec(
+		"UPDATE {cottages} SET name = ? WHERE cottage_id = ?",
+		name, cottageID,
+	)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func (r *cottageRepo) UpdateCapacity(cottageID, maxAdults, maxGuests int) error {
+	result, err := r.q.Exec(
+		"UPDATE {cottages} SET max_adults = ?, max_guests = ? WHERE cottage_id = ?",
+		maxAdults, maxGuests, cottageID,
+	)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func (r *cottageRepo) Delete(cottageID int) error {
+	result, err := r.q.Exec("DELETE FROM {cottages} WHERE cottage_id = ?", cottageID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
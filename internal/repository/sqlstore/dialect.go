@@ -0,0 +1,132 @@
+// internal/repository/sqlstore/dialect.go
+package sqlstore
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// Dialect описывает отличия конкретной базы данных. Запросы хранилища
+// пишутся один раз: параметры обозначаются "?", таблицы — "{bookings}",
+// а время передается и читается через хуки диалекта.
+type Dialect interface {
+	// Placeholder возвращает обозначение n-го параметра запроса (с 1),
+	// например "?" или "$1"
+	Placeholder(n int) string
+	// TablePrefix — префикс имен таблиц, например схема "lesbaza."
+	TablePrefix() string
+	// EncodeTime приводит время к виду, в котором оно хранится в базе
+	EncodeTime(t time.Time) any
+	// ScanTime читает в t время, записанное EncodeTime; NULL дает нулевое время
+	ScanTime(t *time.Time) sql.Scanner
+	// TranslateError превращает нарушения ограничений базы
+	// в repository.ErrOverlap и repository.ErrDuplicate
+	TranslateError(err error) error
+}
+
+// querier — общее подмножество *sql.DB и *sql.Tx
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// conn выполняет общие запросы через подключение или транзакцию,
+// переводя их на язык диалекта
+type conn struct {
+	q querier
+	d Dialect
+}
+
+func (c conn) Exec(query string, args ...any) (sql.Result, error) {
+	return c.q.Exec(rebind(c.d, query), args...)
+}
+
+func (c conn) Query(query string, args ...any) (*sql.Rows, error) {
+	return c.q.Query(rebind(c.d, query), args...)
+}
+
+func (c conn) QueryRow(query string, args ...any) *sql.Row {
+	return c.q.QueryRow(rebind(c.d, query), args...)
+}
+
+// time приводит время к формату хранения
+func (c conn) time(t time.Time) any {
+	return c.d.EncodeTime(t)
+}
+
+// nullTime превращает нулевое время в NULL
+func (c conn) nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return c.d.EncodeTime(t)
+}
+
+// scanTime читает дату или NULL в t
+func (c conn) scanTime(t *time.Time) sql.Scanner {
+	return c.d.ScanTime(t)
+}
+
+// translate превращает ошибку базы в ошибку репозитория
+func (c conn) translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	return c.d.TranslateError(err)
+}
+
+// rebind подставляет в запрос префикс таблиц и параметры диалекта.
+// Строковые литералы в кавычках не меняются.
+func rebind(d Dialect, query string) string {
+	var sb strings.Builder
+	sb.Grow(len(query) + 16)
+	n := 0
+	inString := false
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case ch == '\'':
+			inString = !inString
+			sb.WriteByte(ch)
+		case inString:
+			sb.WriteByte(ch)
+		case ch == '?':
+			n++
+			sb.WriteString(d.Placeholder(n))
+		case ch == '{':
+			end := strings.IndexByte(query[i:], '}')
+			if end < 0 {
+				sb.WriteString(query[i:])
+				return sb.String()
+			}
+			sb.WriteString(d.TablePrefix())
+			sb.WriteString(query[i+1 : i+end])
+			i += end
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return sb.String()
+}
+
+// statusIn строит условие "column IN (?, ?)" или "column NOT IN (?, ?)"
+// по списку статусов. Пустой список не совпадает ни с чем.
+func statusIn(column string, statuses []string, not bool) (string, []any) {
+	if len(statuses) == 0 {
+		if not {
+			return "1 = 1", nil
+		}
+		return "1 = 0", nil
+	}
+	args := make([]any, len(statuses))
+	for i, s := range statuses {
+		args[i] = s
+	}
+	op := " IN ("
+	if not {
+		op = " NOT IN ("
+	}
+	return column + op + strings.TrimSuffix(strings.Repeat("?, ", len(statuses)), ", ") + ")", args
+}
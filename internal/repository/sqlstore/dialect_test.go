@@ -0,0 +1,72 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// numberedDialect переписывает запросы так же, как диалект PostgreSQL
+type numberedDialect struct{}
+
+func (numberedDialect) Placeholder(n int) string          { return "$" + strconv.Itoa(n) }
+func (numberedDialect) TablePrefix() string               { return "lesbaza." }
+func (numberedDialect) EncodeTime(t time.Time) any        { return t }
+func (numberedDialect) ScanTime(t *time.Time) sql.Scanner { return nil }
+func (numberedDialect) TranslateError(err error) error    { return err }
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			"placeholders and tables",
+			"SELECT b.booking_id FROM {bookings} b WHERE b.cottage_id = ? AND b.status = ?",
+			"SELECT b.booking_id FROM lesbaza.bookings b WHERE b.cottage_id = $1 AND b.status = $2",
+		},
+		{
+			"qualified column",
+			"DELETE FROM {bookings} WHERE NOT EXISTS (SELECT 1 FROM {payments} p WHERE p.booking_id = {bookings}.booking_id) AND status = ?",
+			"DELETE FROM lesbaza.bookings WHERE NOT EXISTS (SELECT 1 FROM lesbaza.payments p WHERE p.booking_id = lesbaza.bookings.booking_id) AND status = $1",
+		},
+		{
+			// Знаки внутри строковых литералов — не параметры и не таблицы
+			"string literals",
+			"UPDATE {bookings} SET notes = COALESCE(notes, '') || '?{x}' || ? WHERE booking_id = ?",
+			"UPDATE lesbaza.bookings SET notes = COALESCE(notes, '') || '?{x}' || $1 WHERE booking_id = $2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rebind(numberedDialect{}, tt.query); got != tt.want {
+				t.Errorf("rebind =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		not      bool
+		want     string
+		wantArgs int
+	}{
+		{"in", []string{"booked", "checked_in"}, false, "status IN (?, ?)", 2},
+		{"not in", []string{"cancelled"}, true, "status NOT IN (?)", 1},
+		{"empty in", nil, false, "1 = 0", 0},
+		{"empty not in", nil, true, "1 = 1", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := statusIn("status", tt.statuses, tt.not)
+			if got != tt.want || len(args) != tt.wantArgs {
+				t.Errorf("statusIn = %q with %d args, want %q with %d", got, len(args), tt.want, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// internal/repository/sqlstore/discounts.go
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+const discountColumns = `discount_id, code, name, kind, value, amount, valid_from, valid_to,
+	max_uses, used_count, active`
+
+type discountRepo struct {
+	q conn
+}
+
+func (r *discountRepo) scanDiscount(row rowScanner) (models.Discount, error) {
+	var d models.Discount
+	err := row.Scan(
+		&d.ID, &d.Code, &d.Name, &d.Kind, &d.Value, &d.FixedAmount, r.q.scanTime(&d.ValidFrom), r.q.scanTime(&d.ValidTo),
+		&d.MaxUses, &d.UsedCount, &d.Active,
+	)
+	return d, err
+}
+
+func (r *discountRepo) Create(discount *models.Discount) error {
+	err := r.q.QueryRow(`
+		INSERT INTO {discounts} (code, name, kind, value, amount, valid_from, valid_to, max_uses, active)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING discount_id`,
+		discount.Code,
+		discount.Name,
+		discount.Kind,
+		discount.Value,
+		discount.FixedAmount,
+		r.q.nullTime(discount.ValidFrom),
+		r.q.nullTime(discount.ValidTo),
+		discount.MaxUses,
+		discount.Active,
+	).Scan(&discount.ID)
+	if err != nil {
+		return err
+	}
+	return r.saveTariffs(discount.ID, discount.TariffIDs)
+}
+
+func (r *discountRepo) GetByID(discountID int) (*models.Discount, error) {
+	return r.get("SELECT "+discountColumns+" FROM {discounts} WHERE discount_id = ?", discountID)
+}
+
+func (r *discountRepo) GetByCode(code string) (*models.Discount, error) {
+	return r.get("SELECT "+discountColumns+" FROM {discounts} WHERE code = ?", code)
+}
+
+func (r *discountRepo) get(query string, arg any) (*models.Discount, error) {
+	discount, err := r.scanDiscount(r.q.QueryRow(query, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if discount.TariffIDs, err = r.loadTariffs(discount.ID); err != nil {
+		return nil, err
+	}
+	return &discount, nil
+}
+
+func (r *discountRepo) List() ([]models.Discount, error) {
+	rows, err := r.q.Query("SELECT " + discountColumns + " FROM {discounts} ORDER BY code")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var discounts []models.Discount
+	for rows.Next() {
+		d, err := r.scanDiscount(rows)
+		if err != nil {
+			return nil, err
+		}
+		discounts = append(discounts, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range discounts {
+		if discounts[i].TariffIDs, err = r.loadTariffs(discounts[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return discounts, nil
+}
+
+func (r *discountRepo) Update(discount models.Discount) error {
+	result, err := r.q.Exec(`
+		UPDATE {discounts}
+		SET code = ?, name = ?, kind = ?, value = ?, amount = ?, valid_from = ?, valid_to = ?,
+			max_uses = ?, active = ?
+		WHERE discount_id = ?`,
+		discount.Code,
+		discount.Name,
+		discount.Kind,
+		discount.Value,
+		discount.FixedAmount,
+		r.q.nullTime(discount.ValidFrom),
+		r.q.nullTime(discount.ValidTo),
+		discount.MaxUses,
+		discount.Active,
+		discount.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+	if _, err := r.q.Exec("DELETE FROM {discount_tariffs} WHERE discount_id = ?", discount.ID); err != nil {
+		return err
+	}
+	return r.saveTariffs(discount.ID, discount.TariffIDs)
+}
+
+func (r *discountRepo) Redeem(discountID int) error {
+	result, err := r.q.Exec(`
+		UPDATE {discounts} SET used_count = used_count + 1
+		WHERE discount_id = ? AND active AND (max_uses = 0 OR used_count < max_uses)`,
+		discountID,
+	)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func (r *discountRepo) Delete(discountID int) error {
+	_, err := r.q.Exec("DELETE FROM {discounts} WHERE discount_id = ?", discountID)
+	return err
+}
+
+// loadTariffs возвращает тарифы промокода по возрастанию ID
+func (r *discountRepo) loadTariffs(discountID int) ([]int, error) {
+	rows, err := r.q.Query(
+		"SELECT tariff_id FROM {discount_tariffs} WHERE discount_id = ? ORDER BY tariff_id",
+		discountID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// saveTariffs привязывает промокод к тарифам
+func (r *discountRepo) saveTariffs(discountID int, tariffIDs []int) error {
+	for _, id := range tariffIDs {
+		_, err := r.q.Exec("INSERT INTO {discount_tariffs} (discount_id, tariff_id) VALUES (?, ?)", discountID, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
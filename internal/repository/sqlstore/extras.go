@@ -0,0 +1,161 @@
+// internal/repository/sqlstore/extras.go
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+const extraColumns = `extra_id, name, unit, price, active`
+
+type extraRepo struct {
+	q conn
+}
+
+func (r *extraRepo) Create(extra *models.Extra) error {
+	return r.q.QueryRow(`
+		INSERT INTO {extras} (name, unit, price, active)
+		VALUES (?, ?, ?, ?)
+		RETURNING extra_id`,
+		extra.Name, extra.Unit, extra.Price, extra.Active,
+	).Scan(&extra.ID)
+}
+
+func (r *extraRepo) List() ([]models.Extra, error) {
+	rows, err := r.q.Query("SELECT " + extraColumns + " FROM {extras} ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var extras []models.Extra
+	for rows.Next() {
+		e, err := scanExtra(rows)
+		if err != nil {
+			return nil, err
+		}
+		extras = append(extras, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return extras, nil
+}
+
+func (r *extraRepo) GetByID(extraID int) (*models.Extra, error) {
+	e, err := scanExtra(r.q.QueryRow(
+		"SELECT "+extraColumns+" FROM {extras} WHERE extra_id = ?",
+		extraID,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *extraRepo) Update(extra models.Extra) error {
+	result, err := r.q.Exec(`
+		UPDATE {extras}
+		SET name = ?, unit = ?, price = ?, active = ?
+		WHERE extra_id = ?`,
+		extra.Name, extra.Unit, extra.Price, extra.Active, extra.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func (r *extraRepo) Delete(extraID int) error {
+	_, err := r.q.Exec("DELETE FROM {extras} WHERE extra_id = ?", extraID)
+	return err
+}
+
+func scanExtra(row rowScanner) (models.Extra, error) {
+	var e models.Extra
+	err := row.Scan(&e.ID, &e.Name, &e.Unit, &e.Price, &e.Active)
+	return e, err
+}
+
+const folioColumns = `item_id, booking_id, COALESCE(extra_id, 0), title, quantity, unit_price,
+	service_date, created_at`
+
+type folioRepo struct {
+	q conn
+}
+
+func (r *folioRepo) Create(item *models.FolioItem) error {
+	return r.q.QueryRow(`
+		INSERT INTO {folio_items} (booking_id, extra_id, title, quantity, unit_price, service_date, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING item_id`,
+		item.BookingID,
+		nullInt(item.ExtraID),
+		item.Title,
+		item.Quantity,
+		item.UnitPrice,
+		r.q.time(item.ServiceDate),
+		r.q.time(item.CreatedAt),
+	).Scan(&item.ID)
+}
+
+func (r *folioRepo) GetByID(itemID int) (*models.FolioItem, error) {
+	item, err := r.scanFolioItem(r.q.QueryRow(
+		"SELECT "+folioColumns+" FROM {folio_items} WHERE item_id = ?",
+		itemID,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *folioRepo) ListByBooking(bookingID int) ([]models.FolioItem, error) {
+	rows, err := r.q.Query(`
+		SELECT `+folioColumns+`
+		FROM {folio_items}
+		WHERE booking_id = ?
+		ORDER BY service_date, item_id`,
+		bookingID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.FolioItem
+	for rows.Next() {
+		item, err := r.scanFolioItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *folioRepo) Delete(itemID int) error {
+	_, err := r.q.Exec("DELETE FROM {folio_items} WHERE item_id = ?", itemID)
+	return err
+}
+
+func (r *folioRepo) scanFolioItem(row rowScanner) (models.FolioItem, error) {
+	var item models.FolioItem
+	err := row.Scan(
+		&item.ID, &item.BookingID, &item.ExtraID, &item.Title, &item.Quantity, &item.UnitPrice,
+		r.q.scanTime(&item.ServiceDate), r.q.scanTime(&item.CreatedAt),
+	)
+	return item, err
+}
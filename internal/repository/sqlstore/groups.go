@@ -0,0 +1,53 @@
+// internal/repository/sqlstore/groups.go
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+type groupRepo struct {
+	q conn
+}
+
+func (r *groupRepo) Create(group *models.BookingGroup) error {
+	return r.q.QueryRow(`
+		INSERT INTO {booking_groups} (name, payer_name, phone, email, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING group_id`,
+		group.Name, group.PayerName, group.Phone, group.Email, group.Notes, r.q.time(group.CreatedAt),
+	).Scan(&group.ID)
+}
+
+func (r *groupRepo) GetByID(groupID int) (*models.BookingGroup, error) {
+	var g models.BookingGroup
+	err := r.q.QueryRow(`
+		SELECT group_id, name, payer_name, phone, email, notes, created_at
+		FROM {booking_groups}
+		WHERE group_id = ?`,
+		groupID,
+	).Scan(&g.ID, &g.Name, &g.PayerName, &g.Phone, &g.Email, &g.Notes, r.q.scanTime(&g.CreatedAt))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *groupRepo) Update(group models.BookingGroup) error {
+	result, err := r.q.Exec(`
+		UPDATE {booking_groups}
+		SET name = ?, payer_name = ?, phone = ?, email = ?, notes = ?
+		WHERE group_id = ?`,
+		group.Name, group.PayerName, group.Phone, group.Email, group.Notes, group.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
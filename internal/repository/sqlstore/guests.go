@@ -0,0 +1,107 @@
+// internal/repository/sqlstore/guests.go
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+type guestRepo struct {
+	q conn
+}
+
+func (r *guestRepo) Create(guest *models.Guest) error {
+	return r.q.QueryRow(`
+		INSERT INTO {guests} 
+			(full_name, email, phone, cottage_id, document_scan_path, check_in_date, check_out_date, tariff_id) 
+		VALUES 
+			(?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING guest_id`,
+		guest.FullName,
+		guest.Email,
+		guest.Phone,
+		guest.CottageID,
+		guest.DocumentScanPath,
+		r.q.nullTime(guest.CheckInDate),
+		r.q.nullTime(guest.CheckOutDate),
+		nullInt(guest.TariffID),
+	).Scan(&guest.ID)
+}
+
+func (r *guestRepo) GetByCottageID(cottageID int) (*models.Guest, error) {
+	row := r.q.QueryRow(`
+		SELECT guest_id, full_name, email, phone, cottage_id, document_scan_path 
+		FROM {guests} 
+		WHERE cottage_id = ?`, cottageID)
+
+	guest := &models.Guest{}
+	err := row.Scan(
+		&guest.ID,
+		&guest.FullName,
+		&guest.Email,
+		&guest.Phone,
+		&guest.CottageID,
+		&guest.DocumentScanPath,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return guest, nil
+}
+
+func (r *guestRepo) List() ([]models.Guest, error) {
+	rows, err := r.q.Query(`
+		SELECT guest_id, full_name, email, phone, cottage_id, document_scan_path,
+			check_in_date, check_out_date, COALESCE(tariff_id, 0)
+		FROM {guests}
+		ORDER BY guest_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var guests []models.Guest
+	for rows.Next() {
+		var g models.Guest
+		if err := rows.Scan(
+			&g.ID, &g.FullName, &g.Email, &g.Phone, &g.CottageID, &g.DocumentScanPath,
+			r.q.scanTime(&g.CheckInDate), r.q.scanTime(&g.CheckOutDate), &g.TariffID,
+		); err != nil {
+			return nil, err
+		}
+		guests = append(guests, g)
+	}
+	return guests, rows.Err()
+}
+
+func (r *guestRepo) CountByCottage(cottageID int) (int, error) {
+	var count int
+	err := r.q.QueryRow("SELECT COUNT(*) FROM {guests} WHERE cottage_id = ?", cottageID).Scan(&count)
+	return count, err
+}
+
+func (r *guestRepo) CountByTariff(tariffID int) (int, error) {
+	var count int
+	err := r.q.QueryRow("SELECT COUNT(*) FROM {guests} WHERE tariff_id = ?", tariffID).Scan(&count)
+	return count, err
+}
+
+func (r *guestRepo) DeleteByCottageID(cottageID int) error {
+	_, err := r.q.Exec("DELETE FROM {guests} WHERE cottage_id = ?", cottageID)
+	return err
+}
+
+func (r *guestRepo) DeleteCheckedOutBefore(before time.Time) (int64, error) {
+	result, err := r.q.Exec("DELETE FROM {guests} WHERE check_out_date <= ?", r.q.time(before))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
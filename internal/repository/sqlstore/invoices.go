@@ -0,0 +1,68 @@
+// internal/repository/sqlstore/invoices.go
+package sqlstore
+
+import (
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+type invoiceRepo struct {
+	q conn
+}
+
+const invoiceColumns = `invoice_id, COALESCE(booking_id, 0), year, seq, number, issued_at,
+	guest_name, total, paid, file_path`
+
+func (r *invoiceRepo) Create(invoice *models.Invoice) error {
+	err := r.q.QueryRow(`
+		INSERT INTO {invoices} (booking_id, year, seq, number, issued_at, guest_name, total, paid, file_path)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING invoice_id`,
+		nullInt(invoice.BookingID),
+		invoice.Year,
+		invoice.Seq,
+		invoice.Number,
+		r.q.time(invoice.IssuedAt),
+		invoice.GuestName,
+		invoice.Total,
+		invoice.Paid,
+		invoice.FilePath,
+	).Scan(&invoice.ID)
+	return r.q.translate(err)
+}
+
+func (r *invoiceRepo) ListByBooking(bookingID int) ([]models.Invoice, error) {
+	rows, err := r.q.Query(`
+		SELECT `+invoiceColumns+`
+		FROM {invoices}
+		WHERE booking_id = ?
+		ORDER BY issued_at, invoice_id`,
+		bookingID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invoices []models.Invoice
+	for rows.Next() {
+		var inv models.Invoice
+		err := rows.Scan(
+			&inv.ID, &inv.BookingID, &inv.Year, &inv.Seq, &inv.Number, r.q.scanTime(&inv.IssuedAt),
+			&inv.GuestName, &inv.Total, &inv.Paid, &inv.FilePath,
+		)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+func (r *invoiceRepo) LastSeq(year int) (int, error) {
+	var seq int
+	err := r.q.QueryRow("SELECT COALESCE(MAX(seq), 0) FROM {invoices} WHERE year = ?", year).Scan(&seq)
+	return seq, err
+}
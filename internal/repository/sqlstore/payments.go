@@ -0,0 +1,74 @@
+// internal/repository/sqlstore/payments.go
+package sqlstore
+
+import (
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+type paymentRepo struct {
+	q conn
+}
+
+const paymentColumns = `payment_id, booking_id, kind, amount, method, paid_at, operator, note`
+
+func (r *paymentRepo) Create(payment *models.Payment) error {
+	return r.q.QueryRow(`
+		INSERT INTO {payments} (booking_id, kind, amount, method, paid_at, operator, note)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING payment_id`,
+		payment.BookingID,
+		payment.Kind,
+		payment.Amount,
+		payment.Method,
+		r.q.time(payment.PaidAt),
+		payment.Operator,
+		payment.Note,
+	).Scan(&payment.ID)
+}
+
+func (r *paymentRepo) ListByBooking(bookingID int) ([]models.Payment, error) {
+	return r.list(`
+		SELECT `+paymentColumns+`
+		FROM {payments}
+		WHERE booking_id = ?
+		ORDER BY paid_at, payment_id`,
+		bookingID,
+	)
+}
+
+func (r *paymentRepo) ListBetween(from, to time.Time) ([]models.Payment, error) {
+	return r.list(`
+		SELECT `+paymentColumns+`
+		FROM {payments}
+		WHERE paid_at >= ? AND paid_at <= ?
+		ORDER BY paid_at, payment_id`,
+		r.q.time(from), r.q.time(to),
+	)
+}
+
+func (r *paymentRepo) list(query string, args ...any) ([]models.Payment, error) {
+	rows, err := r.q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []models.Payment
+	for rows.Next() {
+		var p models.Payment
+		err := rows.Scan(
+			&p.ID, &p.BookingID, &p.Kind, &p.Amount, &p.Method,
+			r.q.scanTime(&p.PaidAt), &p.Operator, &p.Note,
+		)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
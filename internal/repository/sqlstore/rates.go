@@ -0,0 +1,125 @@
+// internal/repository/sqlstore/rates.go
+package sqlstore
+
+import (
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+type rateRepo struct {
+	q conn
+}
+
+func (r *rateRepo) Create(rate *models.TariffRate) error {
+	return r.q.QueryRow(`
+		INSERT INTO {tariff_rates} (tariff_id, cottage_id, name, start_date, end_date, price_per_day)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING rate_id`,
+		rate.TariffID,
+		nullInt(rate.CottageID),
+		rate.Name,
+		r.q.nullTime(rate.StartDate),
+		r.q.nullTime(rate.EndDate),
+		rate.PricePerDay,
+	).Scan(&rate.ID)
+}
+
+func (r *rateRepo) ListByTariff(tariffID int) ([]models.TariffRate, error) {
+	rows, err := r.q.Query(`
+		SELECT rate_id, tariff_id, COALESCE(cottage_id, 0), name, start_date, end_date, price_per_day
+		FROM {tariff_rates}
+		WHERE tariff_id = ?
+		ORDER BY start_date IS NOT NULL, start_date, rate_id`,
+		tariffID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []models.TariffRate
+	for rows.Next() {
+		var rate models.TariffRate
+		err := rows.Scan(
+			&rate.ID, &rate.TariffID, &rate.CottageID, &rate.Name,
+			r.q.scanTime(&rate.StartDate), r.q.scanTime(&rate.EndDate), &rate.PricePerDay,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (r *rateRepo) Update(rate models.TariffRate) error {
+	result, err := r.q.Exec(`
+		UPDATE {tariff_rates}
+		SET cottage_id = ?, name = ?, start_date = ?, end_date = ?, price_per_day = ?
+		WHERE rate_id = ?`,
+		nullInt(rate.CottageID),
+		rate.Name,
+		r.q.nullTime(rate.StartDate),
+		r.q.nullTime(rate.EndDate),
+		rate.PricePerDay,
+		rate.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func (r *rateRepo) Delete(rateID int) error {
+	_, err := r.q.Exec("DELETE FROM {tariff_rates} WHERE rate_id = ?", rateID)
+	return err
+}
+
+type holidayRepo struct {
+	q conn
+}
+
+func (r *holidayRepo) Create(holiday *models.Holiday) error {
+	return r.q.QueryRow(
+		"INSERT INTO {holidays} (holiday_date, name) VALUES (?, ?) RETURNING holiday_id",
+		r.q.time(holiday.Date), holiday.Name,
+	).Scan(&holiday.ID)
+}
+
+func (r *holidayRepo) ListBetween(from, to time.Time) ([]models.Holiday, error) {
+	rows, err := r.q.Query(`
+		SELECT holiday_id, holiday_date, name
+		FROM {holidays}
+		WHERE holiday_date BETWEEN ? AND ?
+		ORDER BY holiday_date`,
+		r.q.time(from), r.q.time(to),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holidays []models.Holiday
+	for rows.Next() {
+		var h models.Holiday
+		if err := rows.Scan(&h.ID, r.q.scanTime(&h.Date), &h.Name); err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return holidays, nil
+}
+
+func (r *holidayRepo) Delete(holidayID int) error {
+	_, err := r.q.Exec("DELETE FROM {holidays} WHERE holiday_id = ?", holidayID)
+	return err
+}
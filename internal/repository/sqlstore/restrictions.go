@@ -0,0 +1,87 @@
+// internal/repository/sqlstore/restrictions.go
+package sqlstore
+
+import (
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+type restrictionRepo struct {
+	q conn
+}
+
+func (r *restrictionRepo) Create(restriction *models.StayRestriction) error {
+	return r.q.QueryRow(`
+		INSERT INTO {stay_restrictions}
+		(cottage_id, name, start_date, end_date, min_nights, closed_to_arrival, closed_to_departure)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING restriction_id`,
+		nullInt(restriction.CottageID),
+		restriction.Name,
+		r.q.time(restriction.StartDate),
+		r.q.time(restriction.EndDate),
+		restriction.MinNights,
+		restriction.ClosedToArrival,
+		restriction.ClosedToDeparture,
+	).Scan(&restriction.ID)
+}
+
+func (r *restrictionRepo) ListBetween(from, to time.Time) ([]models.StayRestriction, error) {
+	rows, err := r.q.Query(`
+		SELECT restriction_id, COALESCE(cottage_id, 0), name, start_date, end_date,
+			min_nights, closed_to_arrival, closed_to_departure
+		FROM {stay_restrictions}
+		WHERE start_date <= ? AND end_date >= ?
+		ORDER BY start_date, restriction_id`,
+		r.q.time(to), r.q.time(from),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restrictions []models.StayRestriction
+	for rows.Next() {
+		var s models.StayRestriction
+		err := rows.Scan(
+			&s.ID, &s.CottageID, &s.Name, r.q.scanTime(&s.StartDate), r.q.scanTime(&s.EndDate),
+			&s.MinNights, &s.ClosedToArrival, &s.ClosedToDeparture,
+		)
+		if err != nil {
+			return nil, err
+		}
+		restrictions = append(restrictions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return restrictions, nil
+}
+
+func (r *restrictionRepo) Update(restriction models.StayRestriction) error {
+	result, err := r.q.Exec(`
+		UPDATE {stay_restrictions}
+		SET cottage_id = ?, name = ?, start_date = ?, end_date = ?,
+			min_nights = ?, closed_to_arrival = ?, closed_to_departure = ?
+		WHERE restriction_id = ?`,
+		nullInt(restriction.CottageID),
+		restriction.Name,
+		r.q.time(restriction.StartDate),
+		r.q.time(restriction.EndDate),
+		restriction.MinNights,
+		restriction.ClosedToArrival,
+		restriction.ClosedToDeparture,
+		restriction.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func (r *restrictionRepo) Delete(restrictionID int) error {
+	_, err := r.q.Exec("DELETE FROM {stay_restrictions} WHERE restriction_id = ?", restrictionID)
+	return err
+}
@@ -0,0 +1,94 @@
+// internal/repository/sqlstore/store.go
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// Store — хранилище на SQL-базе. Запросы общие для всех баз,
+// отличия задает Dialect.
+type Store struct {
+	db *sql.DB
+	q  conn
+}
+
+// New создает хранилище поверх открытого подключения
+func New(db *sql.DB, dialect Dialect) *Store {
+	return &Store{db: db, q: conn{q: db, d: dialect}}
+}
+
+var _ repository.Store = (*Store)(nil)
+
+func (s *Store) Bookings() repository.BookingRepository    { return &bookingRepo{q: s.q} }
+func (s *Store) Cottages() repository.CottageRepository    { return &cottageRepo{q: s.q} }
+func (s *Store) Guests() repository.GuestRepository        { return &guestRepo{q: s.q} }
+func (s *Store) Tariffs() repository.TariffRepository      { return &tariffRepo{q: s.q} }
+func (s *Store) Groups() repository.BookingGroupRepository { return &groupRepo{q: s.q} }
+func (s *Store) BookingChanges() repository.BookingChangeRepository {
+	return &changeRepo{q: s.q}
+}
+func (s *Store) TariffRates() repository.TariffRateRepository { return &rateRepo{q: s.q} }
+func (s *Store) Holidays() repository.HolidayRepository       { return &holidayRepo{q: s.q} }
+func (s *Store) StayRestrictions() repository.StayRestrictionRepository {
+	return &restrictionRepo{q: s.q}
+}
+func (s *Store) Discounts() repository.DiscountRepository { return &discountRepo{q: s.q} }
+func (s *Store) Payments() repository.PaymentRepository   { return &paymentRepo{q: s.q} }
+func (s *Store) CancellationPolicies() repository.CancellationPolicyRepository {
+	return &policyRepo{q: s.q}
+}
+func (s *Store) Invoices() repository.InvoiceRepository { return &invoiceRepo{q: s.q} }
+func (s *Store) Extras() repository.ExtraRepository     { return &extraRepo{q: s.q} }
+func (s *Store) Folio() repository.FolioRepository      { return &folioRepo{q: s.q} }
+
+// WithTx выполняет fn в транзакции
+func (s *Store) WithTx(fn func(tx repository.Store) error) error {
+	if _, inTx := s.q.q.(*sql.Tx); inTx {
+		return fn(s)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(&Store{db: s.db, q: conn{q: tx, d: s.q.d}}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+	return nil
+}
+
+// requireAffected возвращает ErrNotFound, если запрос не затронул строк
+func requireAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества строк: %w", err)
+	}
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// nullInt превращает нулевой ID в NULL
+func nullInt(v int) any {
+	if v == 0 {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,87 @@
+// internal/repository/sqlstore/tariffs.go
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+const tariffColumns = `tariff_id, name, price_per_day, weekend_surcharge, holiday_surcharge,
+	base_guests, extra_adult_price, extra_child_price, COALESCE(cancellation_policy_id, 0)`
+
+type tariffRepo struct {
+	q conn
+}
+
+func (r *tariffRepo) Create(tariff *models.Tariff) error {
+	return r.q.QueryRow(
+		`INSERT INTO {tariffs} (name, price_per_day, weekend_surcharge, holiday_surcharge,
+			base_guests, extra_adult_price, extra_child_price, cancellation_policy_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?) RETURNING tariff_id`,
+		tariff.Name, tariff.PricePerDay, tariff.WeekendSurcharge, tariff.HolidaySurcharge,
+		tariff.BaseGuests, tariff.ExtraAdultPrice, tariff.ExtraChildPrice, nullInt(tariff.CancellationPolicyID),
+	).Scan(&tariff.ID)
+}
+
+func (r *tariffRepo) List() ([]models.Tariff, error) {
+	rows, err := r.q.Query("SELECT " + tariffColumns + " FROM {tariffs} ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tariffs []models.Tariff
+	for rows.Next() {
+		var t models.Tariff
+		if err := rows.Scan(&t.ID, &t.Name, &t.PricePerDay, &t.WeekendSurcharge, &t.HolidaySurcharge,
+			&t.BaseGuests, &t.ExtraAdultPrice, &t.ExtraChildPrice, &t.CancellationPolicyID); err != nil {
+			return nil, err
+		}
+		tariffs = append(tariffs, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tariffs, nil
+}
+
+func (r *tariffRepo) GetByID(tariffID int) (*models.Tariff, error) {
+	var t models.Tariff
+	err := r.q.QueryRow(
+		"SELECT "+tariffColumns+" FROM {tariffs} WHERE tariff_id = ?",
+		tariffID,
+	).Scan(&t.ID, &t.Name, &t.PricePerDay, &t.WeekendSurcharge, &t.HolidaySurcharge,
+		&t.BaseGuests, &t.ExtraAdultPrice, &t.ExtraChildPrice, &t.CancellationPolicyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *tariffRepo) Update(tariff models.Tariff) error {
+	result, err := r.q.Exec(
+		`UPDATE {tariffs} SET name = ?, price_per_day = ?, weekend_surcharge = ?, holiday_surcharge = ?,
+			base_guests = ?, extra_adult_price = ?, extra_child_price = ?,
+			cancellation_policy_id = ?
+		WHERE tariff_id = ?`,
+		tariff.Name, tariff.PricePerDay, tariff.WeekendSurcharge, tariff.HolidaySurcharge,
+		tariff.BaseGuests, tariff.ExtraAdultPrice, tariff.ExtraChildPrice,
+		nullInt(tariff.CancellationPolicyID), tariff.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result)
+}
+
+func (r *tariffRepo) Delete(tariffID int) error {
+	_, err := r.q.Exec("DELETE FROM {tariffs} WHERE tariff_id = ?", tariffID)
+	return err
+}
@@ -1,135 +1,513 @@
 package service
 
 import (
-	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
 )
 
+// ErrCottageUnavailable возвращается, когда домик уже занят на выбранные даты
+var ErrCottageUnavailable = errors.New("домик недоступен на выбранные даты")
+
+// ErrOverCapacity возвращается, когда гостей больше, чем вмещает домик
+var ErrOverCapacity = errors.New("гостей больше, чем вмещает домик")
+
 type BookingService struct {
-	db            *sql.DB
-	tariffService *TariffService
+	store repository.Store
+	stay  models.StayPolicy
 }
 
-func NewBookingService(db *sql.DB) *BookingService {
-	return &BookingService{
-		db:            db,
-		tariffService: NewTariffService(db),
-	}
+// NewBookingService создает сервис броней с расчетным часом stay
+func NewBookingService(store repository.Store, stay models.StayPolicy) *BookingService {
+	return &BookingService{store: store, stay: stay}
 }
 
-// CreateBooking создает новую бронь
+// StayPolicy возвращает расчетный час: время заезда и выезда
+func (s *BookingService) StayPolicy() models.StayPolicy {
+	return s.stay
+}
+
+// CreateBooking создает новую бронь. Проверка занятости и вставка выполняются
+// в одной транзакции, а пересечение, пропущенное параллельным запросом,
+// отсекает ограничение базы; в обоих случаях возвращается ErrCottageUnavailable.
 func (s *BookingService) CreateBooking(booking models.Booking) (*models.Booking, error) {
-	// Проверяем доступность домика на эти даты
-	available, err := s.IsCottageAvailable(booking.CottageID, booking.CheckInDate, booking.CheckOutDate)
-	if err != nil {
+	booking.CheckInDate, booking.CheckOutDate = s.stay.Normalize(booking.CheckInDate, booking.CheckOutDate)
+	if !booking.CheckOutDate.After(booking.CheckInDate) {
+		return nil, fmt.Errorf("дата выезда должна быть позже даты заезда")
+	}
+	if err := checkGuests(s.store, &booking); err != nil {
 		return nil, err
 	}
-	if !available {
-		return nil, fmt.Errorf("домик недоступен на выбранные даты")
+	if err := s.checkRestrictions(s.store, booking.CottageID, booking.CheckInDate, booking.CheckOutDate); err != nil {
+		return nil, err
 	}
 
-	// Получаем тариф
-	tariff, err := s.tariffService.GetTariffByID(booking.TariffID)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка получения тарифа: %w", err)
+	// Промокод проверяется сейчас, а засчитывается вместе с сохранением брони
+	booking.DiscountID = 0
+	if booking.DiscountCode != "" {
+		discount, err := findDiscount(s.store, booking.DiscountCode, booking.TariffID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		booking.DiscountID, booking.DiscountCode = discount.ID, discount.Code
 	}
 
 	// Рассчитываем стоимость
-	checkInDateOnly := time.Date(booking.CheckInDate.Year(), booking.CheckInDate.Month(), booking.CheckInDate.Day(), 0, 0, 0, 0, time.Local)
-	checkOutDateOnly := time.Date(booking.CheckOutDate.Year(), booking.CheckOutDate.Month(), booking.CheckOutDate.Day(), 0, 0, 0, 0, time.Local)
-	days := int(checkOutDateOnly.Sub(checkInDateOnly).Hours()/24) + 1
-	totalCost := float64(days) * tariff.PricePerDay
-
-	// Создаем бронь
-	var bookingID int
-	err = s.db.QueryRow(`
-		INSERT INTO lesbaza.bookings 
-		(cottage_id, guest_name, phone, email, check_in_date, check_out_date, 
-		 status, created_at, notes, tariff_id, total_cost)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING booking_id`,
-		booking.CottageID,
-		booking.GuestName,
-		booking.Phone,
-		booking.Email,
-		booking.CheckInDate,
-		booking.CheckOutDate,
-		models.BookingStatusBooked,
-		time.Now(),
-		booking.Notes,
-		booking.TariffID,
-		totalCost,
-	).Scan(&bookingID)
-
-	if err != nil {
-		return nil, fmt.Errorf("ошибка создания брони: %w", err)
+	if err := s.priceBooking(s.store, &booking); err != nil {
+		return nil, err
 	}
 
-	booking.ID = bookingID
+	defaultDeposit(&booking)
+
 	booking.Status = models.BookingStatusBooked
 	booking.CreatedAt = time.Now()
 
+	if err := s.insertBooking(&booking); err != nil {
+		return nil, err
+	}
 	return &booking, nil
 }
 
-// IsCottageAvailable проверяет доступность домика на даты
-func (s *BookingService) IsCottageAvailable(cottageID int, checkIn, checkOut time.Time) (bool, error) {
-	var count int
-	err := s.db.QueryRow(`
-		SELECT COUNT(*) FROM lesbaza.bookings
-		WHERE cottage_id = $1
-		AND status IN ($2, $3, $4)
-		AND NOT (check_out_date <= $5 OR check_in_date >= $6)`,
-		cottageID,
-		models.BookingStatusBooked,
-		models.BookingStatusCheckedIn,
-		models.BookingStatusTemporary,
-		checkIn,
-		checkOut,
-	).Scan(&count)
+// priceBooking считает стоимость брони (домик, тариф, состав гостей
+// и даты) движком цен и вычитает скидку по промокоду брони, заполняя
+// TotalCost и DiscountAmount. Внутри транзакции передается её хранилище,
+// иначе s.store.
+func (s *BookingService) priceBooking(store repository.Store, booking *models.Booking) error {
+	quote, err := quoteStay(store, s.stay, booking.TariffID, booking.CottageID, booking.Adults, booking.Children,
+		booking.CheckInDate, booking.CheckOutDate)
+	if err != nil {
+		return err
+	}
 
+	booking.TotalCost, booking.DiscountAmount = quote.Total, models.NewMoney(0)
+	if booking.DiscountID == 0 {
+		return nil
+	}
+	// Примененный промокод пересчитывается при изменении брони, даже если
+	// он уже истек или исчерпан
+	discount, err := store.Discounts().GetByID(booking.DiscountID)
 	if err != nil {
-		return false, err
+		return fmt.Errorf("ошибка получения промокода: %w", err)
 	}
+	booking.DiscountAmount = discount.Amount(quote.Total)
+	booking.TotalCost = quote.Total.Sub(booking.DiscountAmount)
+	return nil
+}
 
-	return count == 0, nil
+// checkGuests проверяет состав гостей брони и вместимость домика.
+// Если состав не указан, считается один взрослый.
+func checkGuests(store repository.Store, booking *models.Booking) error {
+	if booking.Adults < 0 || booking.Children < 0 {
+		return fmt.Errorf("число гостей не может быть отрицательным")
+	}
+	if booking.Adults == 0 && booking.Children == 0 {
+		booking.Adults = 1
+	}
+	if booking.Adults == 0 {
+		return fmt.Errorf("в брони должен быть хотя бы один взрослый")
+	}
+
+	cottage, err := store.Cottages().GetByID(booking.CottageID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения домика: %w", err)
+	}
+	if !cottage.Fits(booking.Adults, booking.Children) {
+		return fmt.Errorf("%w: домик %s — %s", ErrOverCapacity, cottage.Name, cottage.CapacityTitle())
+	}
+	return nil
 }
 
-// GetBookingsByDateRange получает все брони за период
-func (s *BookingService) GetBookingsByDateRange(startDate, endDate time.Time) ([]models.Booking, error) {
-	rows, err := s.db.Query(`
-		SELECT booking_id, cottage_id, guest_name, phone, email, 
-		       check_in_date, check_out_date, status, created_at, notes,
-		       COALESCE(tariff_id, 0), COALESCE(total_cost, 0)
-		FROM lesbaza.bookings
-		WHERE (check_in_date <= $2 AND check_out_date >= $1)
-		AND status NOT IN ($3, $4)
-		ORDER BY check_in_date`,
-		startDate, endDate, models.BookingStatusCancelled, models.BookingStatusCompleted,
-	)
+// insertBooking проверяет занятость домика и сохраняет бронь в одной транзакции
+func (s *BookingService) insertBooking(booking *models.Booking) error {
+	err := s.store.WithTx(func(tx repository.Store) error {
+		return insertBookingTx(tx, booking)
+	})
+	if errors.Is(err, ErrCottageUnavailable) || errors.Is(err, repository.ErrOverlap) {
+		return ErrCottageUnavailable
+	}
+	if errors.Is(err, ErrDiscountInvalid) {
+		return err
+	}
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("ошибка создания брони: %w", err)
+	}
+	return nil
+}
+
+// insertBookingTx проверяет занятость домика и сохраняет бронь внутри транзакции tx
+func insertBookingTx(tx repository.Store, booking *models.Booking) error {
+	count, err := tx.Bookings().CountOverlapping(booking.CottageID, booking.CheckInDate, booking.CheckOutDate, models.ActiveBookingStatuses, 0)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrCottageUnavailable
 	}
-	defer rows.Close()
 
-	var bookings []models.Booking
-	for rows.Next() {
-		var b models.Booking
-		err := rows.Scan(
-			&b.ID, &b.CottageID, &b.GuestName, &b.Phone, &b.Email,
-			&b.CheckInDate, &b.CheckOutDate, &b.Status, &b.CreatedAt, &b.Notes,
-			&b.TariffID, &b.TotalCost,
-		)
+	if booking.DiscountID != 0 {
+		err := tx.Discounts().Redeem(booking.DiscountID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%w: промокод «%s» уже израсходован или отключен", ErrDiscountInvalid, booking.DiscountCode)
+		}
 		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Bookings().Create(booking)
+}
+
+// CreateHold временно удерживает домик на даты hold.CheckInDate–CheckOutDate,
+// пока администратор договаривается с гостем. Удержание занимает домик
+// как обычная бронь и снимается автоматически через duration.
+func (s *BookingService) CreateHold(hold models.Booking, duration time.Duration) (*models.Booking, error) {
+	if duration <= 0 {
+		return nil, fmt.Errorf("срок удержания должен быть больше нуля")
+	}
+	hold.CheckInDate, hold.CheckOutDate = s.stay.Normalize(hold.CheckInDate, hold.CheckOutDate)
+	if !hold.CheckOutDate.After(hold.CheckInDate) {
+		return nil, fmt.Errorf("дата выезда должна быть позже даты заезда")
+	}
+	if err := checkGuests(s.store, &hold); err != nil {
+		return nil, err
+	}
+	if err := s.checkRestrictions(s.store, hold.CottageID, hold.CheckInDate, hold.CheckOutDate); err != nil {
+		return nil, err
+	}
+
+	hold.DiscountID, hold.DiscountCode = 0, ""
+	if hold.TariffID != 0 {
+		if err := s.priceBooking(s.store, &hold); err != nil {
 			return nil, err
 		}
-		bookings = append(bookings, b)
 	}
 
-	return bookings, nil
+	now := time.Now()
+	hold.Status = models.BookingStatusTemporary
+	hold.CreatedAt = now
+	hold.HoldExpiresAt = now.Add(duration)
+
+	if err := s.insertBooking(&hold); err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// ConfirmHold превращает удержание в обычную бронь. Данные гостя, состав
+// гостей, тариф и примечания берутся из details; пустые поля остаются
+// как в удержании.
+func (s *BookingService) ConfirmHold(holdID int, details models.Booking) (*models.Booking, error) {
+	var confirmed *models.Booking
+	err := s.store.WithTx(func(tx repository.Store) error {
+		booking, err := tx.Bookings().GetByID(holdID)
+		if err != nil {
+			return err
+		}
+
+		transition, err := models.BookingStateMachine.Transition(*booking, models.BookingEventConfirm, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if details.GuestName != "" {
+			booking.GuestName = details.GuestName
+		}
+		if details.Phone != "" {
+			booking.Phone = details.Phone
+		}
+		if details.Email != "" {
+			booking.Email = details.Email
+		}
+		if details.Notes != "" {
+			booking.Notes = details.Notes
+		}
+		if details.TariffID != 0 {
+			booking.TariffID = details.TariffID
+		}
+		if details.Adults != 0 || details.Children != 0 {
+			booking.Adults, booking.Children = details.Adults, details.Children
+		}
+		if booking.GuestName == "" || booking.TariffID == 0 {
+			return fmt.Errorf("для подтверждения укажите гостя и тариф")
+		}
+		if err := checkGuests(tx, booking); err != nil {
+			return err
+		}
+
+		if err := s.priceBooking(tx, booking); err != nil {
+			return err
+		}
+		booking.DepositAmount = details.DepositAmount
+		defaultDeposit(booking)
+		booking.HoldExpiresAt = time.Time{}
+
+		if err := tx.Bookings().Update(*booking); err != nil {
+			return err
+		}
+		if err := tx.Bookings().UpdateStatus(holdID, transition.From, transition.To); err != nil {
+			return err
+		}
+
+		booking.Status = transition.To
+		confirmed = booking
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return confirmed, nil
+}
+
+// ReleaseHold снимает удержание, освобождая домик
+func (s *BookingService) ReleaseHold(holdID int) error {
+	return s.applyEvent(holdID, models.BookingEventRelease)
+}
+
+// ReleaseExpiredHolds снимает удержания, срок которых истек к now,
+// и возвращает их число
+func (s *BookingService) ReleaseExpiredHolds(now time.Time) (int, error) {
+	holds, err := s.store.Bookings().ListByStatus(models.BookingStatusTemporary, time.Time{})
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, hold := range holds {
+		if hold.HoldExpiresAt.IsZero() || hold.HoldExpiresAt.After(now) {
+			continue
+		}
+		err := s.ReleaseHold(hold.ID)
+		if errors.Is(err, repository.ErrConflict) || errors.Is(err, models.ErrInvalidTransition) {
+			// Удержание успели подтвердить или снять вручную
+			continue
+		}
+		if err != nil {
+			return released, fmt.Errorf("ошибка снятия удержания %d: %w", hold.ID, err)
+		}
+		released++
+	}
+	return released, nil
+}
+
+// BlockCottage закрывает домик на обслуживание с первого по последний день
+// включительно. Блокировка начинается в расчетный час выезда первого дня
+// и заканчивается в расчетный час заезда следующего за последним дня,
+// чтобы не мешать выезду и заезду соседних броней. Выручки блокировка
+// не дает.
+func (s *BookingService) BlockCottage(cottageID int, firstDay, lastDay time.Time, reason, note string) (*models.Booking, error) {
+	first := time.Date(firstDay.Year(), firstDay.Month(), firstDay.Day(), 0, 0, 0, 0, time.Local)
+	last := time.Date(lastDay.Year(), lastDay.Month(), lastDay.Day(), 0, 0, 0, 0, time.Local)
+	if last.Before(first) {
+		return nil, fmt.Errorf("последний день блокировки раньше первого")
+	}
+
+	validReason := false
+	for _, r := range models.BlockReasons {
+		if r == reason {
+			validReason = true
+			break
+		}
+	}
+	if !validReason {
+		return nil, fmt.Errorf("неизвестная причина блокировки %q", reason)
+	}
+
+	block := models.Booking{
+		CottageID:    cottageID,
+		CheckInDate:  s.stay.CheckOutTime(first),
+		CheckOutDate: s.stay.CheckInTime(last.AddDate(0, 0, 1)),
+		Status:       models.BookingStatusBlocked,
+		CreatedAt:    time.Now(),
+		Notes:        note,
+		BlockReason:  reason,
+	}
+
+	if err := s.insertBooking(&block); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// UnblockCottage снимает блокировку домика
+func (s *BookingService) UnblockCottage(blockID int) error {
+	return s.applyEvent(blockID, models.BookingEventCancel)
+}
+
+// QuoteBooking считает стоимость проживания adults взрослых и children детей
+// в домике по тарифу с разбивкой по ночам, не сохраняя бронь
+func (s *BookingService) QuoteBooking(tariffID, cottageID, adults, children int, checkIn, checkOut time.Time) (*models.PriceQuote, error) {
+	return quoteStay(s.store, s.stay, tariffID, cottageID, adults, children, checkIn, checkOut)
+}
+
+// ModifyBooking переносит бронь на другие даты, в другой домик или меняет
+// тариф и состав гостей. Занятость проверяется без учета самой брони,
+// стоимость пересчитывается по тарифу, а в журнал изменений добавляется
+// запись "было/стало". У заселенного гостя нельзя менять домик и дату заезда.
+func (s *BookingService) ModifyBooking(bookingID int, mod models.BookingModification) (*models.Booking, error) {
+	mod.CheckInDate, mod.CheckOutDate = s.stay.Normalize(mod.CheckInDate, mod.CheckOutDate)
+	if !mod.CheckOutDate.After(mod.CheckInDate) {
+		return nil, fmt.Errorf("дата выезда должна быть позже даты заезда")
+	}
+
+	var modified *models.Booking
+	err := s.store.WithTx(func(tx repository.Store) error {
+		booking, err := tx.Bookings().GetByID(bookingID)
+		if err != nil {
+			return err
+		}
+
+		updated, err := s.checkModification(tx, *booking, mod)
+		if err != nil {
+			return err
+		}
+
+		change := models.BookingChange{
+			BookingID:       booking.ID,
+			ChangedAt:       time.Now(),
+			OldCottageID:    booking.CottageID,
+			NewCottageID:    mod.CottageID,
+			OldCheckInDate:  booking.CheckInDate,
+			NewCheckInDate:  mod.CheckInDate,
+			OldCheckOutDate: booking.CheckOutDate,
+			NewCheckOutDate: mod.CheckOutDate,
+			OldTariffID:     booking.TariffID,
+			NewTariffID:     mod.TariffID,
+			OldTotalCost:    booking.TotalCost,
+			NewTotalCost:    updated.TotalCost,
+			Reason:          mod.Reason,
+		}
+
+		if err := tx.Bookings().Update(updated); err != nil {
+			return err
+		}
+		if err := tx.BookingChanges().Create(&change); err != nil {
+			return err
+		}
+
+		modified = &updated
+		return nil
+	})
+	if errors.Is(err, ErrCottageUnavailable) || errors.Is(err, repository.ErrOverlap) {
+		return nil, ErrCottageUnavailable
+	}
+	if err != nil {
+		return nil, err
+	}
+	return modified, nil
+}
+
+// PreviewModification проверяет изменение брони так же, как ModifyBooking,
+// но ничего не сохраняет. Возвращает новую стоимость; занятый домик дает
+// ErrCottageUnavailable.
+func (s *BookingService) PreviewModification(bookingID int, mod models.BookingModification) (models.Money, error) {
+	mod.CheckInDate, mod.CheckOutDate = s.stay.Normalize(mod.CheckInDate, mod.CheckOutDate)
+	if !mod.CheckOutDate.After(mod.CheckInDate) {
+		return models.Money{}, fmt.Errorf("дата выезда должна быть позже даты заезда")
+	}
+
+	booking, err := s.store.Bookings().GetByID(bookingID)
+	if err != nil {
+		return models.Money{}, err
+	}
+	updated, err := s.checkModification(s.store, *booking, mod)
+	if err != nil {
+		return models.Money{}, err
+	}
+	return updated.TotalCost, nil
+}
+
+// checkModification проверяет, можно ли так изменить бронь, и возвращает
+// бронь с изменениями и новой стоимостью. Без состава гостей в mod
+// остается прежний состав. Даты mod уже перенесены на расчетные часы.
+func (s *BookingService) checkModification(store repository.Store, booking models.Booking, mod models.BookingModification) (models.Booking, error) {
+	// Старые брони могли сохраниться без расчетных часов — сравниваем по ним же
+	checkIn, checkOut := s.stay.Normalize(booking.CheckInDate, booking.CheckOutDate)
+
+	switch booking.Status {
+	case models.BookingStatusBooked:
+	case models.BookingStatusCheckedIn:
+		if mod.CottageID != booking.CottageID || !mod.CheckInDate.Equal(checkIn) {
+			return booking, fmt.Errorf("гость уже заселен: можно изменить только дату выезда, тариф и состав гостей")
+		}
+	default:
+		return booking, fmt.Errorf("нельзя изменить бронь в статусе «%s»", models.BookingStatusTitle(booking.Status))
+	}
+
+	count, err := store.Bookings().CountOverlapping(mod.CottageID, mod.CheckInDate, mod.CheckOutDate,
+		models.ActiveBookingStatuses, booking.ID)
+	if err != nil {
+		return booking, err
+	}
+	if count > 0 {
+		return booking, ErrCottageUnavailable
+	}
+
+	// Ограничения проверяются только при переносе: смена тарифа или состава
+	// гостей не должна упираться в правила, введенные после бронирования
+	if mod.CottageID != booking.CottageID || !mod.CheckInDate.Equal(checkIn) ||
+		!mod.CheckOutDate.Equal(checkOut) {
+		if err := s.checkRestrictions(store, mod.CottageID, mod.CheckInDate, mod.CheckOutDate); err != nil {
+			return booking, err
+		}
+	}
+
+	// Примененный промокод остается при смене тарифа, только если действует
+	// и для нового тарифа
+	if booking.DiscountID != 0 && mod.TariffID != booking.TariffID {
+		discount, err := store.Discounts().GetByID(booking.DiscountID)
+		if err != nil {
+			return booking, fmt.Errorf("ошибка получения промокода: %w", err)
+		}
+		if !discount.AppliesTo(mod.TariffID) {
+			return booking, fmt.Errorf("%w: промокод «%s» не действует для нового тарифа", ErrDiscountInvalid, discount.Code)
+		}
+	}
+
+	updated := booking
+	updated.CottageID = mod.CottageID
+	updated.CheckInDate = mod.CheckInDate
+	updated.CheckOutDate = mod.CheckOutDate
+	updated.TariffID = mod.TariffID
+	if mod.Adults != 0 || mod.Children != 0 {
+		updated.Adults, updated.Children = mod.Adults, mod.Children
+	}
+	// У старых броней состав гостей не указан — их вместимость не проверяем
+	if updated.Guests() > 0 {
+		if err := checkGuests(store, &updated); err != nil {
+			return booking, err
+		}
+	}
+
+	if err := s.priceBooking(store, &updated); err != nil {
+		return booking, err
+	}
+	return updated, nil
+}
+
+// GetBookingChanges возвращает журнал изменений брони
+func (s *BookingService) GetBookingChanges(bookingID int) ([]models.BookingChange, error) {
+	return s.store.BookingChanges().ListByBooking(bookingID)
+}
+
+// IsCottageAvailable проверяет доступность домика на даты
+func (s *BookingService) IsCottageAvailable(cottageID int, checkIn, checkOut time.Time) (bool, error) {
+	count, err := s.store.Bookings().CountOverlapping(cottageID, checkIn, checkOut, models.ActiveBookingStatuses, 0)
+	if err != nil {
+		return false, err
+	}
+
+	return count == 0, nil
+}
+
+// GetBookingsByDateRange получает все брони за период
+func (s *BookingService) GetBookingsByDateRange(startDate, endDate time.Time) ([]models.Booking, error) {
+	return s.store.Bookings().ListByDateRange(startDate, endDate, []string{
+		models.BookingStatusCancelled,
+		models.BookingStatusCompleted,
+	})
 }
 
 // GetCalendarData получает данные для календаря
@@ -166,12 +544,13 @@ func (s *BookingService) GetCalendarData(startDate, endDate time.Time) (map[time
 
 				// Добавляем статус для дня брони
 				dayMap[booking.CottageID] = models.BookingStatus{
-					Status:     booking.Status,
-					BookingID:  booking.ID,
-					GuestName:  booking.GuestName,
-					IsPartDay:  isCheckIn || isCheckOut,
-					IsCheckIn:  isCheckIn,
-					IsCheckOut: isCheckOut,
+					Status:      booking.Status,
+					BookingID:   booking.ID,
+					GuestName:   booking.GuestName,
+					IsPartDay:   isCheckIn || isCheckOut,
+					IsCheckIn:   isCheckIn,
+					IsCheckOut:  isCheckOut,
+					BlockReason: booking.BlockReason,
 				}
 			}
 		}
@@ -180,12 +559,13 @@ func (s *BookingService) GetCalendarData(startDate, endDate time.Time) (map[time
 		if dayMap, exists := calendar[checkOut]; exists {
 			// В день выезда помечаем специальным статусом для диагональной кнопки
 			dayMap[booking.CottageID] = models.BookingStatus{
-				Status:     booking.Status,
-				BookingID:  booking.ID,
-				GuestName:  booking.GuestName,
-				IsPartDay:  true,
-				IsCheckIn:  false,
-				IsCheckOut: true,
+				Status:      booking.Status,
+				BookingID:   booking.ID,
+				GuestName:   booking.GuestName,
+				IsPartDay:   true,
+				IsCheckIn:   false,
+				IsCheckOut:  true,
+				BlockReason: booking.BlockReason,
 			}
 		}
 	}
@@ -195,54 +575,46 @@ func (s *BookingService) GetCalendarData(startDate, endDate time.Time) (map[time
 
 // CheckOutBooking выселяет гостя (завершает бронирование)
 func (s *BookingService) CheckOutBooking(bookingID int) error {
-	// Получаем бронь
+	return s.applyEvent(bookingID, models.BookingEventCheckOut)
+}
+
+// QuoteEarlyCheckout считает, сколько будет стоить проживание при выезде
+// в день newCheckOutDate, штраф по правилам тарифа за освобожденные ночи
+// и сколько вернуть гостю из уже полученных денег
+func (s *BookingService) QuoteEarlyCheckout(bookingID int, newCheckOutDate time.Time) (*models.CancellationQuote, error) {
 	booking, err := s.GetBookingByID(bookingID)
 	if err != nil {
-		return err
-	}
-
-	if booking.Status != models.BookingStatusCheckedIn {
-		return fmt.Errorf("можно выселить только заселенного гостя")
+		return nil, err
 	}
-
-	// Начинаем транзакцию
-	tx, err := s.db.Begin()
+	newCheckOutDate = s.stay.CheckOutTime(newCheckOutDate)
+	shortened, released, err := s.quoteCheckOutChange(booking, newCheckOutDate)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	quote, _, err := quoteSettlement(s.store, shortened, released, daysUntil(time.Now(), newCheckOutDate))
+	return quote, err
+}
 
-	// Обновляем статус брони на "completed"
-	_, err = tx.Exec(
-		"UPDATE lesbaza.bookings SET status = $1 WHERE booking_id = $2",
-		models.BookingStatusCompleted, bookingID,
-	)
-	if err != nil {
-		tx.Rollback()
-		return err
+// quoteCheckOutChange пересчитывает стоимость и скидку заселенной брони
+// с новой датой выезда тем же расчетом, что и при создании брони.
+// released — на сколько уменьшилась стоимость.
+func (s *BookingService) quoteCheckOutChange(booking *models.Booking, newCheckOut time.Time) (shortened models.Booking, released models.Money, err error) {
+	if booking.Status != models.BookingStatusCheckedIn {
+		return *booking, released, fmt.Errorf("можно изменить дату выезда только для заселенного гостя")
 	}
 
-	// Освобождаем домик
-	_, err = tx.Exec(
-		"UPDATE lesbaza.cottages SET status = 'free' WHERE cottage_id = $1",
-		booking.CottageID,
-	)
-	if err != nil {
-		tx.Rollback()
-		return err
+	checkInDate := time.Date(booking.CheckInDate.Year(), booking.CheckInDate.Month(), booking.CheckInDate.Day(), 0, 0, 0, 0, time.Local)
+	if newCheckOut.Before(checkInDate) {
+		return *booking, released, fmt.Errorf("дата выезда не может быть раньше даты заезда")
 	}
 
-	// Удаляем из таблицы гостей, если есть
-	_, err = tx.Exec(
-		"DELETE FROM lesbaza.guests WHERE cottage_id = $1",
-		booking.CottageID,
-	)
-	if err != nil {
-		// Это не критичная ошибка, продолжаем
-		// tx.Rollback()
-		// return err
+	shortened = *booking
+	shortened.CheckOutDate = newCheckOut
+	if err := s.priceBooking(s.store, &shortened); err != nil {
+		return *booking, released, err
 	}
-
-	return tx.Commit()
+	released = booking.TotalCost.Sub(shortened.TotalCost).Max(models.NewMoney(0))
+	return shortened, released, nil
 }
 
 // UpdateCheckOutDate обновляет дату выезда (для раннего выселения)
@@ -253,30 +625,11 @@ func (s *BookingService) UpdateCheckOutDate(bookingID int, newCheckOutDate time.
 		return err
 	}
 
-	if booking.Status != models.BookingStatusCheckedIn {
-		return fmt.Errorf("можно изменить дату выезда только для заселенного гостя")
-	}
-
-	// Проверяем, что новая дата не раньше даты заезда
-	checkInDate := time.Date(booking.CheckInDate.Year(), booking.CheckInDate.Month(), booking.CheckInDate.Day(), 0, 0, 0, 0, time.Local)
-	newCheckOutDateOnly := time.Date(newCheckOutDate.Year(), newCheckOutDate.Month(), newCheckOutDate.Day(), 0, 0, 0, 0, time.Local)
-
-	if newCheckOutDateOnly.Before(checkInDate) {
-		return fmt.Errorf("дата выезда не может быть раньше даты заезда")
-	}
-
-	// Получаем тариф для пересчета стоимости
-	tariff, err := s.tariffService.GetTariffByID(booking.TariffID)
+	newCheckOutDate = s.stay.CheckOutTime(newCheckOutDate)
+	shortened, released, err := s.quoteCheckOutChange(booking, newCheckOutDate)
 	if err != nil {
-		return fmt.Errorf("ошибка получения тарифа: %w", err)
-	}
-
-	// Пересчитываем стоимость
-	days := int(newCheckOutDateOnly.Sub(checkInDate).Hours()/24) + 1
-	if days <= 0 {
-		days = 1
+		return err
 	}
-	newTotalCost := float64(days) * tariff.PricePerDay
 
 	// Формируем примечание
 	note := fmt.Sprintf("Изменена дата выезда с %s на %s",
@@ -286,13 +639,25 @@ func (s *BookingService) UpdateCheckOutDate(bookingID int, newCheckOutDate time.
 		note += fmt.Sprintf(". Причина: %s", reason)
 	}
 
-	// Обновляем в базе данных
-	_, err = s.db.Exec(`
-		UPDATE lesbaza.bookings 
-		SET check_out_date = $1, total_cost = $2, notes = COALESCE(notes, '') || $3
-		WHERE booking_id = $4`,
-		newCheckOutDate, newTotalCost, ". "+note, bookingID,
-	)
+	// Обновляем бронь и проводим штраф и возврат за освобожденные ночи
+	err = s.store.WithTx(func(tx repository.Store) error {
+		err := tx.Bookings().UpdateCheckOut(bookingID, newCheckOutDate, shortened.TotalCost, shortened.DiscountAmount, ". "+note)
+		if err != nil {
+			return err
+		}
+		if released.IsZero() {
+			return nil
+		}
+		now := time.Now()
+		quote, payments, err := quoteSettlement(tx, shortened, released, daysUntil(now, newCheckOutDate))
+		if err != nil {
+			return err
+		}
+		return recordSettlement(tx, bookingID, quote, payments, now, "ранний выезд")
+	})
+	if errors.Is(err, repository.ErrOverlap) {
+		return ErrCottageUnavailable
+	}
 	if err != nil {
 		return fmt.Errorf("ошибка обновления даты выезда: %w", err)
 	}
@@ -300,203 +665,360 @@ func (s *BookingService) UpdateCheckOutDate(bookingID int, newCheckOutDate time.
 	return nil
 }
 
-// UpdateBookingStatus обновляет статус брони
+// UpdateBookingStatus переводит бронь в статус, если такой переход
+// предусмотрен жизненным циклом брони
 func (s *BookingService) UpdateBookingStatus(bookingID int, status string) error {
-	_, err := s.db.Exec(
-		"UPDATE lesbaza.bookings SET status = $1 WHERE booking_id = $2",
-		status, bookingID,
-	)
-	return err
-}
-
-// GetBookingByID получает бронь по ID
-func (s *BookingService) GetBookingByID(bookingID int) (*models.Booking, error) {
-	var booking models.Booking
-	err := s.db.QueryRow(`
-		SELECT b.booking_id, b.cottage_id, b.guest_name, b.phone, b.email, 
-		       b.check_in_date, b.check_out_date, b.status, b.created_at, 
-		       b.notes, b.tariff_id, b.total_cost
-		FROM lesbaza.bookings b
-		WHERE b.booking_id = $1`,
-		bookingID,
-	).Scan(
-		&booking.ID, &booking.CottageID, &booking.GuestName, &booking.Phone, &booking.Email,
-		&booking.CheckInDate, &booking.CheckOutDate, &booking.Status, &booking.CreatedAt,
-		&booking.Notes, &booking.TariffID, &booking.TotalCost,
-	)
+	booking, err := s.GetBookingByID(bookingID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &booking, nil
+	event, ok := models.BookingStateMachine.EventTo(booking.Status, status)
+	if !ok {
+		return &models.TransitionError{
+			BookingID: bookingID,
+			Status:    booking.Status,
+			Reason: fmt.Sprintf("переход «%s» → «%s» не предусмотрен",
+				models.BookingStatusTitle(booking.Status), models.BookingStatusTitle(status)),
+		}
+	}
+	return s.applyEvent(bookingID, event)
 }
 
-// CancelBooking отменяет бронь
-func (s *BookingService) CancelBooking(bookingID int) error {
-	return s.UpdateBookingStatus(bookingID, models.BookingStatusCancelled)
+// applyEvent применяет событие жизненного цикла к брони и его побочные
+// эффекты (статус домика, запись гостя) в одной транзакции
+func (s *BookingService) applyEvent(bookingID int, event models.BookingEvent) error {
+	return s.store.WithTx(func(tx repository.Store) error {
+		booking, err := tx.Bookings().GetByID(bookingID)
+		if err != nil {
+			return err
+		}
+		return s.applyEventTx(tx, *booking, event, time.Now())
+	})
 }
 
-// CheckInBooking заселяет гостя
-func (s *BookingService) CheckInBooking(bookingID int) error {
-	// Получаем бронь
-	booking, err := s.GetBookingByID(bookingID)
+// applyEventTx применяет событие к уже загруженной брони внутри транзакции tx
+func (s *BookingService) applyEventTx(tx repository.Store, booking models.Booking, event models.BookingEvent, now time.Time) error {
+	transition, err := models.BookingStateMachine.Transition(booking, event, now)
 	if err != nil {
 		return err
 	}
 
-	if booking.Status != models.BookingStatusBooked {
-		return fmt.Errorf("можно заселить только забронированного гостя")
+	if err := tx.Bookings().UpdateStatus(booking.ID, transition.From, transition.To); err != nil {
+		if errors.Is(err, repository.ErrOverlap) {
+			return ErrCottageUnavailable
+		}
+		return err
 	}
 
-	// Начинаем транзакцию
-	tx, err := s.db.Begin()
-	if err != nil {
-		return err
+	// Отмена брони удерживает штраф по правилам тарифа и возвращает переплату.
+	// Удержания и блокировки не оплачиваются.
+	if event == models.BookingEventCancel && transition.From == models.BookingStatusBooked {
+		quote, payments, err := s.quoteCancellation(tx, booking, now)
+		if err != nil {
+			return err
+		}
+		if err := recordSettlement(tx, booking.ID, quote, payments, now, "отмена брони"); err != nil {
+			return err
+		}
 	}
 
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			panic(r)
+	effects := transition.Effects
+	if effects.CottageStatus != "" {
+		if err := tx.Cottages().UpdateStatus(booking.CottageID, effects.CottageStatus); err != nil {
+			return err
+		}
+	}
+	if effects.RemoveGuest {
+		if err := tx.Guests().DeleteByCottageID(booking.CottageID); err != nil {
+			return err
 		}
-	}()
+	}
+	if effects.CreateGuest {
+		return tx.Guests().Create(&models.Guest{
+			CottageID:   booking.CottageID,
+			FullName:    booking.GuestName,
+			Phone:       booking.Phone,
+			Email:       booking.Email,
+			CheckInDate: booking.CheckInDate,
+		})
+	}
+	return nil
+}
 
-	// Обновляем статус брони на "заселен"
-	_, err = tx.Exec(
-		"UPDATE lesbaza.bookings SET status = $1 WHERE booking_id = $2",
-		models.BookingStatusCheckedIn, bookingID,
-	)
-	if err != nil {
-		tx.Rollback()
-		return err
+// GetBookingByID получает бронь по ID
+func (s *BookingService) GetBookingByID(bookingID int) (*models.Booking, error) {
+	return s.store.Bookings().GetByID(bookingID)
+}
+
+// CancelBooking отменяет бронь
+func (s *BookingService) CancelBooking(bookingID int) error {
+	return s.applyEvent(bookingID, models.BookingEventCancel)
+}
+
+// CheckInBooking заселяет гостя
+func (s *BookingService) CheckInBooking(bookingID int) error {
+	return s.applyEvent(bookingID, models.BookingEventCheckIn)
+}
+
+// GetAvailableCottagesForDates получает доступные домики на даты
+func (s *BookingService) GetAvailableCottagesForDates(checkIn, checkOut time.Time) ([]models.Cottage, error) {
+	return s.store.Cottages().ListAvailable(checkIn, checkOut)
+}
+
+// GetUpcomingBookings получает предстоящие брони
+func (s *BookingService) GetUpcomingBookings() ([]models.Booking, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	return s.store.Bookings().ListByStatus(models.BookingStatusBooked, today)
+}
+
+// GetBookingsByStatus получает брони по статусу
+func (s *BookingService) GetBookingsByStatus(status string, afterDate time.Time) ([]models.Booking, error) {
+	return s.store.Bookings().ListByStatus(status, afterDate)
+}
+
+// GetBookingsForPeriod возвращает брони для выгрузки, у которых хотя бы
+// одна ночь приходится на период с from по to: без статуса — брони в любом
+// статусе, со статусом — только в нем. Блокировки домиков — не брони
+// и в выгрузку не попадают.
+func (s *BookingService) GetBookingsForPeriod(from, to time.Time, status string) ([]models.Booking, error) {
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.Local)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+	if !end.After(first) {
+		return nil, fmt.Errorf("конец периода раньше начала")
 	}
 
-	// Занятым делаем домик
-	_, err = tx.Exec(
-		"UPDATE lesbaza.cottages SET status = 'occupied' WHERE cottage_id = $1",
-		booking.CottageID,
-	)
+	bookings, err := s.store.Bookings().ListByDateRange(first, end, []string{models.BookingStatusBlocked})
 	if err != nil {
-		tx.Rollback()
-		return err
+		return nil, fmt.Errorf("ошибка получения бронирований: %w", err)
 	}
 
-	// Добавляем гостя в таблицу гостей
-	_, err = tx.Exec(
-		"INSERT INTO lesbaza.guests (cottage_id, full_name, phone, email, check_in_date) VALUES ($1, $2, $3, $4, $5)",
-		booking.CottageID, booking.GuestName, booking.Phone, booking.Email, booking.CheckInDate,
-	)
-	if err != nil {
-		tx.Rollback()
-		return err
+	result := make([]models.Booking, 0, len(bookings))
+	for _, b := range bookings {
+		if status != "" && b.Status != status {
+			continue
+		}
+		// Бронь, выезжающая в первый день периода, ночей в нем не имеет
+		nights := s.stay.NightDates(b.CheckInDate, b.CheckOutDate)
+		if !nights[0].Before(end) || nights[len(nights)-1].Before(first) {
+			continue
+		}
+		result = append(result, b)
 	}
+	return result, nil
+}
 
-	return tx.Commit()
+// PurgeReleasedHolds удаляет удержания, снятые без подтверждения, выезд по
+// которым был больше retention назад. Завершенные и отмененные брони не
+// удаляются — на них строятся отчеты о загрузке и выручке, в том числе доля
+// отмен. Брони с оплатами, возвратами и штрафами, услугами и счетами
+// остаются: журнал оплат и фолио не должны теряться.
+func (s *BookingService) PurgeReleasedHolds(retention time.Duration) (int64, error) {
+	return s.store.Bookings().DeleteReleasedHoldsBefore(time.Now().Add(-retention))
 }
 
-// GetAvailableCottagesForDates получает доступные домики на даты
-func (s *BookingService) GetAvailableCottagesForDates(checkIn, checkOut time.Time) ([]models.Cottage, error) {
-	var cottages []models.Cottage
-	rows, err := s.db.Query(`
-		SELECT c.cottage_id, c.name, c.status
-		FROM lesbaza.cottages c
-		WHERE c.cottage_id NOT IN (
-			SELECT b.cottage_id
-			FROM lesbaza.bookings b
-			WHERE b.status NOT IN ('cancelled', 'completed')
-			AND (
-				($1 BETWEEN b.check_in_date AND b.check_out_date)
-				OR ($2 BETWEEN b.check_in_date AND b.check_out_date)
-				OR (b.check_in_date BETWEEN $1 AND $2)
-			)
-		)
-	`, checkIn, checkOut)
+// GetBookingsDueForCheckIn возвращает брони с заездом сегодня,
+// если расчетное время заезда уже наступило
+func (s *BookingService) GetBookingsDueForCheckIn(now time.Time) ([]models.Booking, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if now.Before(s.stay.CheckInTime(today)) {
+		return nil, nil
+	}
+
+	bookings, err := s.store.Bookings().ListByStatus(models.BookingStatusBooked, today)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var cottage models.Cottage
-		err := rows.Scan(&cottage.ID, &cottage.Name, &cottage.Status)
-		if err != nil {
-			return nil, err
+	var due []models.Booking
+	for _, b := range bookings {
+		checkIn := time.Date(b.CheckInDate.Year(), b.CheckInDate.Month(), b.CheckInDate.Day(), 0, 0, 0, 0, time.Local)
+		if checkIn.Equal(today) {
+			due = append(due, b)
 		}
-		cottages = append(cottages, cottage)
 	}
-	if err := rows.Err(); err != nil {
+	return due, nil
+}
+
+// GetOverdueCheckedInBookings возвращает заселенные брони с датой выезда раньше сегодняшней
+func (s *BookingService) GetOverdueCheckedInBookings(now time.Time) ([]models.Booking, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	bookings, err := s.store.Bookings().ListByStatus(models.BookingStatusCheckedIn, time.Time{})
+	if err != nil {
 		return nil, err
 	}
 
-	return cottages, nil
+	var overdue []models.Booking
+	for _, b := range bookings {
+		checkOut := time.Date(b.CheckOutDate.Year(), b.CheckOutDate.Month(), b.CheckOutDate.Day(), 0, 0, 0, 0, time.Local)
+		if checkOut.Before(today) {
+			overdue = append(overdue, b)
+		}
+	}
+	return overdue, nil
 }
 
-// GetUpcomingBookings получает предстоящие брони
-func (s *BookingService) GetUpcomingBookings() ([]models.Booking, error) {
-	var bookings []models.Booking
-	rows, err := s.db.Query(`
-		SELECT b.booking_id, b.cottage_id, b.guest_name, b.phone, b.email, 
-		       b.check_in_date, b.check_out_date, b.status, b.created_at, 
-		       b.notes, b.tariff_id, b.total_cost
-		FROM lesbaza.bookings b
-		WHERE b.status = 'booked' AND b.check_in_date >= CURRENT_DATE
-		ORDER BY b.check_in_date ASC
-	`)
-	if err != nil {
-		return nil, err
+// CreateGroupBooking создает групповую бронь: группу с плательщиком и брони
+// всех домиков из bookings одной транзакцией. Если хотя бы один домик занят,
+// не создается ничего, а ошибка (ErrCottageUnavailable) называет этот домик.
+// Пустые данные гостя в бронях заполняются данными плательщика.
+func (s *BookingService) CreateGroupBooking(group models.BookingGroup, bookings []models.Booking) (*models.BookingGroupView, error) {
+	if group.PayerName == "" {
+		return nil, fmt.Errorf("укажите плательщика группы")
+	}
+	if len(bookings) == 0 {
+		return nil, fmt.Errorf("в групповой брони должен быть хотя бы один домик")
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var booking models.Booking
-		err := rows.Scan(
-			&booking.ID, &booking.CottageID, &booking.GuestName, &booking.Phone, &booking.Email,
-			&booking.CheckInDate, &booking.CheckOutDate, &booking.Status, &booking.CreatedAt,
-			&booking.Notes, &booking.TariffID, &booking.TotalCost,
-		)
-		if err != nil {
-			return nil, err
+	seen := make(map[int]bool, len(bookings))
+	for _, b := range bookings {
+		if seen[b.CottageID] {
+			return nil, fmt.Errorf("домик указан в группе дважды")
+		}
+		seen[b.CottageID] = true
+		if !s.stay.CheckOutTime(b.CheckOutDate).After(s.stay.CheckInTime(b.CheckInDate)) {
+			return nil, fmt.Errorf("дата выезда должна быть позже даты заезда")
 		}
-		bookings = append(bookings, booking)
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+
+	now := time.Now()
+	group.CreatedAt = now
+	view := &models.BookingGroupView{}
+
+	err := s.store.WithTx(func(tx repository.Store) error {
+		if err := tx.Groups().Create(&group); err != nil {
+			return err
+		}
+
+		for _, booking := range bookings {
+			booking.CheckInDate, booking.CheckOutDate = s.stay.Normalize(booking.CheckInDate, booking.CheckOutDate)
+			if err := checkGuests(tx, &booking); err != nil {
+				return err
+			}
+			if err := s.checkRestrictions(tx, booking.CottageID, booking.CheckInDate, booking.CheckOutDate); err != nil {
+				return err
+			}
+			booking.DiscountID, booking.DiscountCode = 0, ""
+			if err := s.priceBooking(tx, &booking); err != nil {
+				return err
+			}
+			defaultDeposit(&booking)
+
+			booking.GroupID = group.ID
+			booking.Status = models.BookingStatusBooked
+			booking.CreatedAt = now
+			if booking.GuestName == "" {
+				booking.GuestName = group.PayerName
+			}
+			if booking.Phone == "" {
+				booking.Phone = group.Phone
+			}
+			if booking.Email == "" {
+				booking.Email = group.Email
+			}
+
+			if err := insertBookingTx(tx, &booking); err != nil {
+				if errors.Is(err, ErrCottageUnavailable) || errors.Is(err, repository.ErrOverlap) {
+					return fmt.Errorf("%w: %s", ErrCottageUnavailable, cottageName(tx, booking.CottageID))
+				}
+				return err
+			}
+			view.Bookings = append(view.Bookings, booking)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrCottageUnavailable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка создания групповой брони: %w", err)
 	}
 
-	return bookings, nil
+	view.Group = group
+	return view, nil
 }
 
-// GetBookingsByStatus получает брони по статусу
-func (s *BookingService) GetBookingsByStatus(status string, afterDate time.Time) ([]models.Booking, error) {
-	var bookings []models.Booking
-	rows, err := s.db.Query(`
-		SELECT b.booking_id, b.cottage_id, b.guest_name, b.phone, b.email, 
-		       b.check_in_date, b.check_out_date, b.status, b.created_at, 
-		       b.notes, b.tariff_id, b.total_cost
-		FROM lesbaza.bookings b
-		WHERE b.status = $1 AND b.check_in_date >= $2
-		ORDER BY b.check_in_date ASC
-	`, status, afterDate)
+// cottageName возвращает название домика для сообщений об ошибках
+func cottageName(store repository.Store, cottageID int) string {
+	cottages, err := store.Cottages().List()
+	if err == nil {
+		for _, c := range cottages {
+			if c.ID == cottageID {
+				return "домик " + c.Name
+			}
+		}
+	}
+	return fmt.Sprintf("домик №%d", cottageID)
+}
+
+// GetBookingGroup возвращает группу вместе с бронями всех её домиков
+func (s *BookingService) GetBookingGroup(groupID int) (*models.BookingGroupView, error) {
+	group, err := s.store.Groups().GetByID(groupID)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := s.store.Bookings().ListByGroup(groupID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return &models.BookingGroupView{Group: *group, Bookings: bookings}, nil
+}
+
+// UpdateBookingGroup сохраняет название, плательщика и примечания группы
+func (s *BookingService) UpdateBookingGroup(group models.BookingGroup) error {
+	if group.PayerName == "" {
+		return fmt.Errorf("укажите плательщика группы")
+	}
+	return s.store.Groups().Update(group)
+}
+
+// CancelGroup отменяет все брони группы, которые еще можно отменить
+func (s *BookingService) CancelGroup(groupID int) (int, error) {
+	return s.applyGroupEvent(groupID, models.BookingEventCancel)
+}
+
+// CheckInGroup заселяет гостей во все забронированные домики группы
+func (s *BookingService) CheckInGroup(groupID int) (int, error) {
+	return s.applyGroupEvent(groupID, models.BookingEventCheckIn)
+}
+
+// CheckOutGroup выселяет гостей из всех заселенных домиков группы
+func (s *BookingService) CheckOutGroup(groupID int) (int, error) {
+	return s.applyGroupEvent(groupID, models.BookingEventCheckOut)
+}
 
-	for rows.Next() {
-		var booking models.Booking
-		err := rows.Scan(
-			&booking.ID, &booking.CottageID, &booking.GuestName, &booking.Phone, &booking.Email,
-			&booking.CheckInDate, &booking.CheckOutDate, &booking.Status, &booking.CreatedAt,
-			&booking.Notes, &booking.TariffID, &booking.TotalCost,
-		)
+// applyGroupEvent применяет событие ко всем броням группы, для статуса
+// которых оно разрешено (уже отмененные или выселенные домики пропускаются),
+// в одной транзакции: если хоть одна бронь не прошла проверку, не меняется
+// ни одна. Возвращает число измененных броней.
+func (s *BookingService) applyGroupEvent(groupID int, event models.BookingEvent) (int, error) {
+	changed := 0
+	err := s.store.WithTx(func(tx repository.Store) error {
+		bookings, err := tx.Bookings().ListByGroup(groupID)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		bookings = append(bookings, booking)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
 
-	return bookings, nil
+		now := time.Now()
+		for _, booking := range bookings {
+			if !models.BookingStateMachine.Can(booking.Status, event) {
+				continue
+			}
+			if err := s.applyEventTx(tx, booking, event, now); err != nil {
+				return fmt.Errorf("%s: %w", cottageName(tx, booking.CottageID), err)
+			}
+			changed++
+		}
+
+		if changed == 0 {
+			return fmt.Errorf("нельзя %s: в группе нет подходящих броней", event.Title())
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return changed, nil
 }
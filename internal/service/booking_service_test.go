@@ -0,0 +1,793 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/config"
+	"github.com/VallfIK/bazaotdx/internal/db"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+	"github.com/VallfIK/bazaotdx/internal/repository/memory"
+	"github.com/VallfIK/bazaotdx/internal/repository/sqlite"
+)
+
+// fixture — хранилище с двумя домиками и тарифом за 3000 ₽ в сутки
+type fixture struct {
+	store    repository.Store
+	bookings *BookingService
+	cottageA int
+	cottageB int
+	tariff   int
+}
+
+// forEachStore выполняет тест на хранилище в памяти и на SQLite с
+// примененными миграциями: хранилище в памяти должно вести себя как база
+func forEachStore(t *testing.T, fn func(t *testing.T, f *fixture)) {
+	t.Helper()
+	t.Run("memory", func(t *testing.T) {
+		fn(t, newFixture(t, memory.NewStore()))
+	})
+	t.Run("sqlite", func(t *testing.T) {
+		fn(t, newFixture(t, newSQLiteStore(t)))
+	})
+}
+
+// newSQLiteStore создает базу SQLite во временной папке теста
+func newSQLiteStore(t *testing.T) repository.Store {
+	t.Helper()
+	return sqlite.NewStore(newSQLiteDB(t))
+}
+
+// newSQLiteDB открывает базу SQLite во временной папке теста и применяет миграции
+func newSQLiteDB(t *testing.T) *sql.DB {
+	t.Helper()
+	cfg := config.Default().Database
+	cfg.Driver = config.DriverSQLite
+	cfg.SQLitePath = filepath.Join(t.TempDir(), "test.db")
+
+	database, err := db.NewSQLiteDB(cfg)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	migrator, err := db.NewSQLiteMigrator(database.DB)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("apply migrations: %v", err)
+	}
+	return database.DB
+}
+
+func newFixture(t *testing.T, store repository.Store) *fixture {
+	t.Helper()
+	f := &fixture{store: store, bookings: NewBookingService(store, models.DefaultStayPolicy)}
+	for _, c := range []*int{&f.cottageA, &f.cottageB} {
+		cottage := &models.Cottage{Name: "Домик", Status: "free"}
+		if err := store.Cottages().Create(cottage); err != nil {
+			t.Fatalf("create cottage: %v", err)
+		}
+		*c = cottage.ID
+	}
+	tariff := &models.Tariff{Name: "Стандарт", PricePerDay: models.Rubles(3000)}
+	if err := store.Tariffs().Create(tariff); err != nil {
+		t.Fatalf("create tariff: %v", err)
+	}
+	f.tariff = tariff.ID
+	return f
+}
+
+// book создает бронь домика с заезда через in дней по выезд через out дней
+func (f *fixture) book(cottageID, in, out int) (*models.Booking, error) {
+	return f.bookings.CreateBooking(models.Booking{
+		CottageID:    cottageID,
+		TariffID:     f.tariff,
+		GuestName:    "Иванов",
+		CheckInDate:  day(in),
+		CheckOutDate: day(out),
+	})
+}
+
+// mustBook создает бронь или останавливает тест
+func (f *fixture) mustBook(t *testing.T, cottageID, in, out int) *models.Booking {
+	t.Helper()
+	b, err := f.book(cottageID, in, out)
+	if err != nil {
+		t.Fatalf("create booking %d..%d: %v", in, out, err)
+	}
+	return b
+}
+
+// day возвращает полночь дня через n дней от сегодняшнего
+func day(n int) time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day()+n, 0, 0, 0, 0, time.Local)
+}
+
+func TestCreateBooking(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		first := f.mustBook(t, f.cottageA, 3, 5)
+		second := f.mustBook(t, f.cottageB, 3, 5)
+
+		if first.ID <= 0 || second.ID <= first.ID {
+			t.Errorf("ids = %d, %d, want increasing positive ids", first.ID, second.ID)
+		}
+		if first.Status != models.BookingStatusBooked {
+			t.Errorf("status = %q, want %q", first.Status, models.BookingStatusBooked)
+		}
+		if first.CheckInDate.Hour() != 14 || first.CheckOutDate.Hour() != 12 {
+			t.Errorf("dates = %s – %s, want check-in at 14:00 and check-out at 12:00", first.CheckInDate, first.CheckOutDate)
+		}
+		if want := models.Rubles(6000); first.TotalCost.Cmp(want) != 0 {
+			t.Errorf("total = %s, want %s", first.TotalCost, want)
+		}
+
+		stored, err := f.bookings.GetBookingByID(first.ID)
+		if err != nil {
+			t.Fatalf("get booking: %v", err)
+		}
+		if stored.CottageID != f.cottageA || !stored.CheckInDate.Equal(first.CheckInDate) ||
+			!stored.CheckOutDate.Equal(first.CheckOutDate) || stored.TotalCost.Cmp(first.TotalCost) != 0 {
+			t.Errorf("stored booking = %+v, want %+v", stored, first)
+		}
+	})
+}
+
+func TestCreateBookingOverlap(t *testing.T) {
+	// Уже есть бронь домика A с 3-го по 6-й день
+	tests := []struct {
+		name    string
+		cottage func(f *fixture) int
+		in, out int
+		wantErr error
+	}{
+		{"same dates", cottageA, 3, 6, ErrCottageUnavailable},
+		{"inside", cottageA, 4, 5, ErrCottageUnavailable},
+		{"covers", cottageA, 2, 7, ErrCottageUnavailable},
+		{"overlaps check-in", cottageA, 1, 4, ErrCottageUnavailable},
+		{"overlaps check-out", cottageA, 5, 8, ErrCottageUnavailable},
+		{"check-out on check-in day", cottageA, 1, 3, nil},
+		{"check-in on check-out day", cottageA, 6, 8, nil},
+		{"other cottage", cottageB, 3, 6, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forEachStore(t, func(t *testing.T, f *fixture) {
+				f.mustBook(t, f.cottageA, 3, 6)
+
+				_, err := f.book(tt.cottage(f), tt.in, tt.out)
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+			})
+		})
+	}
+}
+
+func cottageA(f *fixture) int { return f.cottageA }
+func cottageB(f *fixture) int { return f.cottageB }
+
+func TestCreateBookingConcurrent(t *testing.T) {
+	const n = 8
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		var wg sync.WaitGroup
+		errs := make([]error, n)
+		start := make(chan struct{})
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				_, errs[i] = f.book(f.cottageA, 3, 6)
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		succeeded, unavailable := 0, 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.Is(err, ErrCottageUnavailable):
+				unavailable++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+		if succeeded != 1 || unavailable != n-1 {
+			t.Errorf("succeeded = %d, unavailable = %d, want 1 and %d", succeeded, unavailable, n-1)
+		}
+
+		bookings, err := f.bookings.GetBookingsByDateRange(day(0), day(10))
+		if err != nil {
+			t.Fatalf("list bookings: %v", err)
+		}
+		if len(bookings) != 1 {
+			t.Errorf("stored bookings = %d, want 1", len(bookings))
+		}
+	})
+}
+
+// Проверка занятости в сервисе последовательна, поэтому ограничение базы
+// (триггер в SQLite) проверяется прямой вставкой в обход сервиса
+func TestBookingsNoOverlapConstraint(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		existing := f.mustBook(t, f.cottageA, 3, 6)
+
+		overlapping := *existing
+		overlapping.ID = 0
+		overlapping.CheckInDate = models.DefaultStayPolicy.CheckInTime(day(5))
+		overlapping.CheckOutDate = models.DefaultStayPolicy.CheckOutTime(day(7))
+		if err := f.store.Bookings().Create(&overlapping); !errors.Is(err, repository.ErrOverlap) {
+			t.Errorf("insert overlapping: err = %v, want ErrOverlap", err)
+		}
+
+		// Отмененная бронь не мешает, но вернуть ее в действующие нельзя
+		cancelled := overlapping
+		cancelled.Status = models.BookingStatusCancelled
+		if err := f.store.Bookings().Create(&cancelled); err != nil {
+			t.Fatalf("insert cancelled: %v", err)
+		}
+		err := f.store.Bookings().UpdateStatus(cancelled.ID, models.BookingStatusCancelled, models.BookingStatusBooked)
+		if !errors.Is(err, repository.ErrOverlap) {
+			t.Errorf("reactivate overlapping: err = %v, want ErrOverlap", err)
+		}
+	})
+}
+
+func TestCancelledBookingFreesCottage(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		b := f.mustBook(t, f.cottageA, 3, 6)
+		if err := f.bookings.CancelBooking(b.ID); err != nil {
+			t.Fatalf("cancel: %v", err)
+		}
+		f.mustBook(t, f.cottageA, 3, 6)
+	})
+}
+
+func TestBookingCapacity(t *testing.T) {
+	// Домик A вмещает 3 гостей, из них не больше 2 взрослых; в цену тарифа
+	// входят двое, доплата за ребенка 500 ₽ за ночь
+	tests := []struct {
+		name             string
+		adults, children int
+		wantAdults       int
+		// wantTotal — стоимость двух ночей в рублях
+		wantTotal int64
+		wantErr   error
+	}{
+		{"no headcount is one adult", 0, 0, 1, 6000, nil},
+		{"within base guests", 2, 0, 2, 6000, nil},
+		{"extra child", 2, 1, 2, 7000, nil},
+		{"too many adults", 3, 0, 0, 0, ErrOverCapacity},
+		{"too many guests", 2, 2, 0, 0, ErrOverCapacity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forEachStore(t, func(t *testing.T, f *fixture) {
+				if err := NewCottageService(f.store).UpdateCottageCapacity(f.cottageA, 2, 3); err != nil {
+					t.Fatalf("update capacity: %v", err)
+				}
+				if err := NewTariffService(f.store).SetGuestFees(f.tariff, 2, models.Rubles(1000), models.Rubles(500)); err != nil {
+					t.Fatalf("set guest fees: %v", err)
+				}
+
+				// Удержание проверяет вместимость так же, как бронь
+				for _, create := range []struct {
+					name string
+					in   int
+					fn   func(models.Booking) (*models.Booking, error)
+				}{
+					{"booking", 3, f.bookings.CreateBooking},
+					{"hold", 7, func(b models.Booking) (*models.Booking, error) { return f.bookings.CreateHold(b, time.Hour) }},
+				} {
+					got, err := create.fn(models.Booking{
+						CottageID:    f.cottageA,
+						TariffID:     f.tariff,
+						GuestName:    "Иванов",
+						Adults:       tt.adults,
+						Children:     tt.children,
+						CheckInDate:  day(create.in),
+						CheckOutDate: day(create.in + 2),
+					})
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("%s: err = %v, want %v", create.name, err, tt.wantErr)
+					}
+					if err != nil {
+						continue
+					}
+					if got.Adults != tt.wantAdults || got.Children != tt.children {
+						t.Errorf("%s: guests = %d+%d, want %d+%d", create.name, got.Adults, got.Children, tt.wantAdults, tt.children)
+					}
+					if want := models.Rubles(tt.wantTotal); got.TotalCost.Cmp(want) != 0 {
+						t.Errorf("%s: total = %s, want %s", create.name, got.TotalCost, want)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestModifyBookingNormalizesDates(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		f.mustBook(t, f.cottageA, 1, 3)
+		b := f.mustBook(t, f.cottageA, 4, 5)
+
+		// Полночь 3-го дня — это заезд в 14:00, после выезда предыдущей брони
+		mod := models.BookingModification{CottageID: f.cottageA, CheckInDate: day(3), CheckOutDate: day(6), TariffID: f.tariff}
+		cost, err := f.bookings.PreviewModification(b.ID, mod)
+		if err != nil {
+			t.Fatalf("preview: %v", err)
+		}
+		if want := models.Rubles(9000); cost.Cmp(want) != 0 {
+			t.Errorf("preview cost = %s, want %s", cost, want)
+		}
+
+		modified, err := f.bookings.ModifyBooking(b.ID, mod)
+		if err != nil {
+			t.Fatalf("modify: %v", err)
+		}
+		if modified.CheckInDate.Hour() != 14 || modified.CheckOutDate.Hour() != 12 {
+			t.Errorf("dates = %s – %s, want check-in at 14:00 and check-out at 12:00", modified.CheckInDate, modified.CheckOutDate)
+		}
+		f.mustBook(t, f.cottageA, 6, 7)
+	})
+}
+
+func TestModifyBookingRechecksDiscountTariff(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		premium := &models.Tariff{Name: "Премиум", PricePerDay: models.Rubles(5000)}
+		if err := f.store.Tariffs().Create(premium); err != nil {
+			t.Fatalf("create tariff: %v", err)
+		}
+		if _, err := NewTariffService(f.store).SaveDiscount(models.Discount{
+			Code: "лето", Kind: models.DiscountPercent, Value: 10, Active: true, TariffIDs: []int{f.tariff},
+		}); err != nil {
+			t.Fatalf("save discount: %v", err)
+		}
+
+		b, err := f.bookings.CreateBooking(models.Booking{
+			CottageID: f.cottageA, TariffID: f.tariff, GuestName: "Иванов",
+			CheckInDate: day(3), CheckOutDate: day(5), DiscountCode: "лето",
+		})
+		if err != nil {
+			t.Fatalf("create booking: %v", err)
+		}
+		if want := models.Rubles(5400); b.TotalCost.Cmp(want) != 0 {
+			t.Fatalf("total = %s, want %s", b.TotalCost, want)
+		}
+
+		toPremium := models.BookingModification{CottageID: f.cottageA, CheckInDate: day(3), CheckOutDate: day(5), TariffID: premium.ID}
+		if _, err := f.bookings.PreviewModification(b.ID, toPremium); !errors.Is(err, ErrDiscountInvalid) {
+			t.Errorf("preview to other tariff: err = %v, want ErrDiscountInvalid", err)
+		}
+		if _, err := f.bookings.ModifyBooking(b.ID, toPremium); !errors.Is(err, ErrDiscountInvalid) {
+			t.Errorf("modify to other tariff: err = %v, want ErrDiscountInvalid", err)
+		}
+
+		// Перенос дат по тому же тарифу сохраняет скидку
+		longer := models.BookingModification{CottageID: f.cottageA, CheckInDate: day(3), CheckOutDate: day(6), TariffID: f.tariff}
+		modified, err := f.bookings.ModifyBooking(b.ID, longer)
+		if err != nil {
+			t.Fatalf("modify: %v", err)
+		}
+		if want := models.Rubles(8100); modified.TotalCost.Cmp(want) != 0 {
+			t.Errorf("total = %s, want %s", modified.TotalCost, want)
+		}
+	})
+}
+
+func TestBookingLifecycle(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		b := f.mustBook(t, f.cottageA, 0, 2)
+		future := f.mustBook(t, f.cottageB, 3, 5)
+
+		if err := f.bookings.CheckInBooking(future.ID); !errors.Is(err, models.ErrInvalidTransition) {
+			t.Errorf("check in before arrival day: err = %v, want ErrInvalidTransition", err)
+		}
+		if err := f.bookings.CheckOutBooking(future.ID); !errors.Is(err, models.ErrInvalidTransition) {
+			t.Errorf("check out of booked: err = %v, want ErrInvalidTransition", err)
+		}
+
+		if err := f.bookings.CheckInBooking(b.ID); err != nil {
+			t.Fatalf("check in: %v", err)
+		}
+		assertStatus(t, f, b.ID, models.BookingStatusCheckedIn)
+		assertCottageStatus(t, f, f.cottageA, "occupied")
+		guest, err := f.store.Guests().GetByCottageID(f.cottageA)
+		if err != nil {
+			t.Fatalf("guest after check in: %v", err)
+		}
+		if guest.FullName != b.GuestName {
+			t.Errorf("guest = %q, want %q", guest.FullName, b.GuestName)
+		}
+
+		if err := f.bookings.CheckOutBooking(b.ID); err != nil {
+			t.Fatalf("check out: %v", err)
+		}
+		assertStatus(t, f, b.ID, models.BookingStatusCompleted)
+		assertCottageStatus(t, f, f.cottageA, "free")
+		if _, err := f.store.Guests().GetByCottageID(f.cottageA); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("guest after check out: err = %v, want ErrNotFound", err)
+		}
+
+		if err := f.bookings.CheckInBooking(b.ID); !errors.Is(err, models.ErrInvalidTransition) {
+			t.Errorf("check in of completed: err = %v, want ErrInvalidTransition", err)
+		}
+	})
+}
+
+func assertStatus(t *testing.T, f *fixture, bookingID int, want string) {
+	t.Helper()
+	b, err := f.bookings.GetBookingByID(bookingID)
+	if err != nil {
+		t.Fatalf("get booking %d: %v", bookingID, err)
+	}
+	if b.Status != want {
+		t.Errorf("booking %d status = %q, want %q", bookingID, b.Status, want)
+	}
+}
+
+func assertCottageStatus(t *testing.T, f *fixture, cottageID int, want string) {
+	t.Helper()
+	c, err := f.store.Cottages().GetByID(cottageID)
+	if err != nil {
+		t.Fatalf("get cottage %d: %v", cottageID, err)
+	}
+	if c.Status != want {
+		t.Errorf("cottage %d status = %q, want %q", cottageID, c.Status, want)
+	}
+}
+
+func TestBookingStatusFilters(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		checkedIn := f.mustBook(t, f.cottageA, 0, 2)
+		booked := f.mustBook(t, f.cottageB, 3, 5)
+		cancelled := f.mustBook(t, f.cottageA, 3, 5)
+		completed := f.mustBook(t, f.cottageB, 0, 1)
+
+		for _, step := range []struct {
+			id int
+			fn func(int) error
+		}{
+			{checkedIn.ID, f.bookings.CheckInBooking},
+			{cancelled.ID, f.bookings.CancelBooking},
+			{completed.ID, f.bookings.CheckInBooking},
+			{completed.ID, f.bookings.CheckOutBooking},
+		} {
+			if err := step.fn(step.id); err != nil {
+				t.Fatalf("booking %d: %v", step.id, err)
+			}
+		}
+
+		inRange, err := f.bookings.GetBookingsByDateRange(day(0), day(10))
+		if err != nil {
+			t.Fatalf("by date range: %v", err)
+		}
+		assertIDs(t, "by date range", inRange, checkedIn.ID, booked.ID)
+
+		byStatus, err := f.bookings.GetBookingsByStatus(models.BookingStatusCancelled, day(0))
+		if err != nil {
+			t.Fatalf("by status: %v", err)
+		}
+		assertIDs(t, "cancelled", byStatus, cancelled.ID)
+
+		later, err := f.bookings.GetBookingsByStatus(models.BookingStatusBooked, day(4))
+		if err != nil {
+			t.Fatalf("by status after date: %v", err)
+		}
+		assertIDs(t, "booked after day 4", later)
+
+		count, err := f.store.Bookings().CountOverlapping(f.cottageA, day(0), day(10), models.ActiveBookingStatuses, 0)
+		if err != nil {
+			t.Fatalf("count overlapping: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("active overlapping in cottage A = %d, want 1", count)
+		}
+		count, err = f.store.Bookings().CountOverlapping(f.cottageA, day(0), day(10), models.ActiveBookingStatuses, checkedIn.ID)
+		if err != nil {
+			t.Fatalf("count overlapping: %v", err)
+		}
+		if count != 0 {
+			t.Errorf("overlapping without the booking itself = %d, want 0", count)
+		}
+	})
+}
+
+// assertIDs проверяет, что в списке ровно брони ids в этом порядке
+func assertIDs(t *testing.T, name string, bookings []models.Booking, ids ...int) {
+	t.Helper()
+	got := make([]int, len(bookings))
+	for i, b := range bookings {
+		got[i] = b.ID
+	}
+	if len(got) != len(ids) {
+		t.Errorf("%s: ids = %v, want %v", name, got, ids)
+		return
+	}
+	for i := range ids {
+		if got[i] != ids[i] {
+			t.Errorf("%s: ids = %v, want %v", name, got, ids)
+			return
+		}
+	}
+}
+
+func TestWithTxRollback(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		errFail := errors.New("fail")
+		err := f.store.WithTx(func(tx repository.Store) error {
+			if err := tx.Cottages().Create(&models.Cottage{Name: "Новый", Status: "free"}); err != nil {
+				return err
+			}
+			booking := models.Booking{
+				CottageID:    f.cottageA,
+				TariffID:     f.tariff,
+				GuestName:    "Петров",
+				Status:       models.BookingStatusBooked,
+				CheckInDate:  day(1),
+				CheckOutDate: day(2),
+				CreatedAt:    time.Now(),
+			}
+			if err := tx.Bookings().Create(&booking); err != nil {
+				return err
+			}
+			return errFail
+		})
+		if !errors.Is(err, errFail) {
+			t.Fatalf("WithTx err = %v, want %v", err, errFail)
+		}
+
+		cottages, err := f.store.Cottages().List()
+		if err != nil {
+			t.Fatalf("list cottages: %v", err)
+		}
+		if len(cottages) != 2 {
+			t.Errorf("cottages after rollback = %d, want 2", len(cottages))
+		}
+		// Откаченная бронь не занимает домик
+		f.mustBook(t, f.cottageA, 1, 2)
+	})
+}
+
+func TestCreateGroupBookingIsAtomic(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		f.mustBook(t, f.cottageB, 3, 5)
+
+		group := models.BookingGroup{Name: "Свадьба", PayerName: "Сидоров"}
+		_, err := f.bookings.CreateGroupBooking(group, []models.Booking{
+			{CottageID: f.cottageA, TariffID: f.tariff, CheckInDate: day(3), CheckOutDate: day(5)},
+			{CottageID: f.cottageB, TariffID: f.tariff, CheckInDate: day(3), CheckOutDate: day(5)},
+		})
+		if !errors.Is(err, ErrCottageUnavailable) {
+			t.Fatalf("err = %v, want ErrCottageUnavailable", err)
+		}
+		// Бронь свободного домика A откатилась вместе с группой
+		f.mustBook(t, f.cottageA, 3, 5)
+	})
+}
+
+// insertBooking сохраняет бронь в статусе status в обход проверок сервиса —
+// так создаются брони в прошлом
+func (f *fixture) insertBooking(t *testing.T, status string, in, out int) *models.Booking {
+	t.Helper()
+	checkIn, checkOut := models.DefaultStayPolicy.Normalize(day(in), day(out))
+	booking := models.Booking{
+		CottageID:    f.cottageA,
+		TariffID:     f.tariff,
+		GuestName:    "Иванов",
+		Status:       status,
+		CheckInDate:  checkIn,
+		CheckOutDate: checkOut,
+		CreatedAt:    day(in - 60),
+		TotalCost:    models.Rubles(3000),
+	}
+	if err := f.store.Bookings().Create(&booking); err != nil {
+		t.Fatalf("insert booking: %v", err)
+	}
+	return &booking
+}
+
+// insertReleasedHold сохраняет удержание, снятое без подтверждения
+func (f *fixture) insertReleasedHold(t *testing.T, in, out int) *models.Booking {
+	t.Helper()
+	hold := f.insertBooking(t, models.BookingStatusCancelled, in, out)
+	hold.HoldExpiresAt = hold.CreatedAt.Add(time.Hour)
+	if err := f.store.Bookings().Update(*hold); err != nil {
+		t.Fatalf("update hold: %v", err)
+	}
+	return hold
+}
+
+// addPayment добавляет в журнал брони запись вида kind на rubles рублей
+func (f *fixture) addPayment(t *testing.T, bookingID int, kind string, rubles int64, paidAt time.Time) {
+	t.Helper()
+	if err := f.store.Payments().Create(&models.Payment{
+		BookingID: bookingID,
+		Kind:      kind,
+		Amount:    models.Rubles(rubles),
+		Method:    models.PaymentMethodCash,
+		PaidAt:    paidAt,
+	}); err != nil {
+		t.Fatalf("create payment: %v", err)
+	}
+}
+
+func TestPurgeReleasedHolds(t *testing.T) {
+	// Удаляются только удержания, снятые без подтверждения, с выездом больше
+	// 30 дней назад и без записей в журнале оплат, фолио и счетах
+	tests := []struct {
+		name   string
+		create func(t *testing.T, f *fixture) *models.Booking
+		purged bool
+	}{
+		{"old released hold", func(t *testing.T, f *fixture) *models.Booking {
+			return f.insertReleasedHold(t, -70, -68)
+		}, true},
+		{"released hold checked out recently", func(t *testing.T, f *fixture) *models.Booking {
+			// Создано давно, но выезд был недавно
+			return f.insertReleasedHold(t, -10, -5)
+		}, false},
+		{"released hold with payment", func(t *testing.T, f *fixture) *models.Booking {
+			hold := f.insertReleasedHold(t, -66, -64)
+			f.addPayment(t, hold.ID, models.PaymentKindPayment, 3000, day(-66))
+			return hold
+		}, false},
+		{"released hold with folio item", func(t *testing.T, f *fixture) *models.Booking {
+			hold := f.insertReleasedHold(t, -62, -60)
+			if err := f.store.Folio().Create(&models.FolioItem{
+				BookingID:   hold.ID,
+				Title:       "Баня",
+				Quantity:    1,
+				UnitPrice:   models.Rubles(1500),
+				ServiceDate: day(-61),
+				CreatedAt:   day(-61),
+			}); err != nil {
+				t.Fatalf("create folio item: %v", err)
+			}
+			return hold
+		}, false},
+		{"released hold with invoice", func(t *testing.T, f *fixture) *models.Booking {
+			hold := f.insertReleasedHold(t, -58, -56)
+			if err := f.store.Invoices().Create(&models.Invoice{
+				BookingID: hold.ID,
+				Year:      day(-57).Year(),
+				Seq:       1,
+				Number:    "1",
+				IssuedAt:  day(-57),
+				Total:     models.Rubles(3000),
+			}); err != nil {
+				t.Fatalf("create invoice: %v", err)
+			}
+			return hold
+		}, false},
+		// Отмены и завершенные проживания нужны отчетам о загрузке, выручке
+		// и доле отмен
+		{"cancelled booking", func(t *testing.T, f *fixture) *models.Booking {
+			return f.insertBooking(t, models.BookingStatusCancelled, -54, -52)
+		}, false},
+		{"cancelled booking with penalty and refund", func(t *testing.T, f *fixture) *models.Booking {
+			b := f.insertBooking(t, models.BookingStatusCancelled, -50, -48)
+			f.addPayment(t, b.ID, models.PaymentKindPayment, 3000, day(-60))
+			f.addPayment(t, b.ID, models.PaymentKindPenalty, 1500, day(-55))
+			f.addPayment(t, b.ID, models.PaymentKindRefund, 1500, day(-55))
+			return b
+		}, false},
+		{"completed stay", func(t *testing.T, f *fixture) *models.Booking {
+			return f.insertBooking(t, models.BookingStatusCompleted, -46, -40)
+		}, false},
+		{"upcoming booking", func(t *testing.T, f *fixture) *models.Booking {
+			return f.mustBook(t, f.cottageA, 3, 5)
+		}, false},
+	}
+
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		bookings := make([]*models.Booking, len(tests))
+		ledgers := make([]int, len(tests))
+		for i, tt := range tests {
+			bookings[i] = tt.create(t, f)
+			payments, err := f.bookings.GetPayments(bookings[i].ID)
+			if err != nil {
+				t.Fatalf("payments: %v", err)
+			}
+			ledgers[i] = len(payments)
+		}
+
+		deleted, err := f.bookings.PurgeReleasedHolds(30 * 24 * time.Hour)
+		if err != nil {
+			t.Fatalf("purge: %v", err)
+		}
+		if deleted != 1 {
+			t.Errorf("deleted = %d, want 1", deleted)
+		}
+
+		for i, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := f.store.Bookings().GetByID(bookings[i].ID)
+				if tt.purged {
+					if !errors.Is(err, repository.ErrNotFound) {
+						t.Errorf("err = %v, want purged", err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("get booking: %v, want kept", err)
+				}
+				payments, err := f.bookings.GetPayments(bookings[i].ID)
+				if err != nil {
+					t.Fatalf("payments: %v", err)
+				}
+				if len(payments) != ledgers[i] {
+					t.Errorf("ledger = %d records, want %d", len(payments), ledgers[i])
+				}
+			})
+		}
+	})
+}
+
+func TestBookingWithLedgerCannotBeDeleted(t *testing.T) {
+	database := newSQLiteDB(t)
+	f := newFixture(t, sqlite.NewStore(database))
+	paid := f.insertBooking(t, models.BookingStatusCompleted, -40, -35)
+	f.addPayment(t, paid.ID, models.PaymentKindPayment, 3000, day(-40))
+	if _, err := database.Exec(`DELETE FROM bookings WHERE booking_id = ?`, paid.ID); err == nil {
+		t.Error("deleted a booking with payments, want the foreign key to restrict it")
+	}
+
+	withFolio := f.insertBooking(t, models.BookingStatusCompleted, -60, -58)
+	if err := f.store.Folio().Create(&models.FolioItem{
+		BookingID:   withFolio.ID,
+		Title:       "Баня",
+		Quantity:    1,
+		UnitPrice:   models.Rubles(1500),
+		ServiceDate: day(-59),
+		CreatedAt:   day(-59),
+	}); err != nil {
+		t.Fatalf("create folio item: %v", err)
+	}
+	if _, err := database.Exec(`DELETE FROM bookings WHERE booking_id = ?`, withFolio.ID); err == nil {
+		t.Error("deleted a booking with folio items, want the foreign key to restrict it")
+	}
+}
+
+func TestGetBookingsForPeriod(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		// Выгрузка за февраль 2026
+		stay := func(cottage func(*fixture) int, status string, in, out time.Time) int {
+			return f.insertStay(t, models.Booking{CottageID: cottage(f), Status: status, CheckInDate: in, CheckOutDate: out}).ID
+		}
+		fromJanuary := stay(cottageA, models.BookingStatusCompleted, date(2026, time.January, 30), date(2026, time.February, 2))
+		stay(cottageB, models.BookingStatusCompleted, date(2026, time.January, 28), date(2026, time.February, 1))
+		cancelled := stay(cottageA, models.BookingStatusCancelled, date(2026, time.February, 10), date(2026, time.February, 12))
+		stay(cottageB, models.BookingStatusBlocked, date(2026, time.February, 15), date(2026, time.February, 17))
+		intoMarch := stay(cottageA, models.BookingStatusBooked, date(2026, time.February, 27), date(2026, time.March, 2))
+		stay(cottageB, models.BookingStatusBooked, date(2026, time.March, 1), date(2026, time.March, 3))
+
+		tests := []struct {
+			status string
+			want   []int
+		}{
+			{"", []int{fromJanuary, cancelled, intoMarch}},
+			{models.BookingStatusCompleted, []int{fromJanuary}},
+			{models.BookingStatusCancelled, []int{cancelled}},
+			{models.BookingStatusBooked, []int{intoMarch}},
+			{models.BookingStatusCheckedIn, nil},
+		}
+		for _, tt := range tests {
+			bookings, err := f.bookings.GetBookingsForPeriod(date(2026, time.February, 1), date(2026, time.February, 28), tt.status)
+			if err != nil {
+				t.Fatalf("bookings %q: %v", tt.status, err)
+			}
+			assertIDs(t, "status "+tt.status, bookings, tt.want...)
+		}
+	})
+}
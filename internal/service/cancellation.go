@@ -0,0 +1,185 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// GetCancellationPolicies возвращает все правила отмены
+func (s *TariffService) GetCancellationPolicies() ([]models.CancellationPolicy, error) {
+	policies, err := s.store.CancellationPolicies().List()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения правил отмены: %w", err)
+	}
+	return policies, nil
+}
+
+// SaveCancellationPolicy сохраняет правила отмены: новые (policy.ID == 0)
+// или измененные. Изменение действует и на уже созданные брони тарифов.
+func (s *TariffService) SaveCancellationPolicy(policy models.CancellationPolicy) (*models.CancellationPolicy, error) {
+	policy.Name = strings.TrimSpace(policy.Name)
+	if policy.Name == "" {
+		return nil, fmt.Errorf("укажите название правил отмены")
+	}
+	if policy.FreeDays < 0 {
+		return nil, fmt.Errorf("число дней бесплатной отмены не может быть отрицательным")
+	}
+	if policy.PenaltyPercent < 0 || policy.PenaltyPercent > 100 {
+		return nil, fmt.Errorf("штраф должен быть от 0 до 100%%")
+	}
+
+	err := s.store.WithTx(func(tx repository.Store) error {
+		policies, err := tx.CancellationPolicies().List()
+		if err != nil {
+			return err
+		}
+		for _, p := range policies {
+			if p.ID != policy.ID && strings.EqualFold(p.Name, policy.Name) {
+				return fmt.Errorf("правила «%s» уже существуют", p.Name)
+			}
+		}
+		if policy.ID == 0 {
+			return tx.CancellationPolicies().Create(&policy)
+		}
+		return tx.CancellationPolicies().Update(policy)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения правил отмены: %w", err)
+	}
+	return &policy, nil
+}
+
+// DeleteCancellationPolicy удаляет правила отмены; тарифы с ними остаются
+// без штрафов
+func (s *TariffService) DeleteCancellationPolicy(policyID int) error {
+	if err := s.store.CancellationPolicies().Delete(policyID); err != nil {
+		return fmt.Errorf("ошибка удаления правил отмены: %w", err)
+	}
+	return nil
+}
+
+// SetCancellationPolicy назначает тарифу правила отмены (0 — без штрафов)
+func (s *TariffService) SetCancellationPolicy(tariffID, policyID int) error {
+	if policyID != 0 {
+		if _, err := s.store.CancellationPolicies().GetByID(policyID); err != nil {
+			return fmt.Errorf("правила отмены с ID %d не найдены: %w", policyID, err)
+		}
+	}
+
+	tariff, err := s.GetTariffByID(tariffID)
+	if err != nil {
+		return err
+	}
+	tariff.CancellationPolicyID = policyID
+
+	if err := s.store.Tariffs().Update(*tariff); err != nil {
+		return fmt.Errorf("ошибка обновления правил отмены тарифа: %w", err)
+	}
+	return nil
+}
+
+// QuoteCancellation считает штраф по правилам тарифа и возврат гостю,
+// если отменить бронь сейчас
+func (s *BookingService) QuoteCancellation(bookingID int) (*models.CancellationQuote, error) {
+	booking, err := s.GetBookingByID(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	quote, _, err := s.quoteCancellation(s.store, *booking, time.Now())
+	return quote, err
+}
+
+// quoteCancellation считает расчет с гостем при отмене брони в момент now
+func (s *BookingService) quoteCancellation(store repository.Store, booking models.Booking, now time.Time) (*models.CancellationQuote, []models.Payment, error) {
+	cancelled := booking
+	cancelled.Status = models.BookingStatusCancelled
+	return quoteSettlement(store, cancelled, booking.TotalCost, daysUntil(now, booking.CheckInDate))
+}
+
+// quoteSettlement считает расчет с гостем для брони в состоянии после
+// отмены или раннего выезда: штраф по правилам тарифа со стоимости released
+// ночей, от которых гость отказался за daysBefore дней. Возвращает и журнал
+// оплат брони.
+func quoteSettlement(store repository.Store, booking models.Booking, released models.Money, daysBefore int) (*models.CancellationQuote, []models.Payment, error) {
+	quote := &models.CancellationQuote{Released: released, Stay: models.NewMoney(0)}
+	if booking.Status != models.BookingStatusCancelled {
+		quote.Stay = booking.TotalCost
+	}
+
+	tariff, err := store.Tariffs().GetByID(booking.TariffID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, fmt.Errorf("ошибка получения тарифа: %w", err)
+	}
+	if tariff != nil && tariff.CancellationPolicyID != 0 {
+		policy, err := store.CancellationPolicies().GetByID(tariff.CancellationPolicyID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ошибка получения правил отмены: %w", err)
+		}
+		quote.Policy = policy.Name
+		quote.Penalty = policy.Penalty(quote.Released, daysBefore)
+	}
+
+	payments, err := store.Payments().ListByBooking(booking.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка получения оплат: %w", err)
+	}
+	summary := paymentSummary(booking, payments)
+	quote.Total = summary.Total.Add(quote.Penalty)
+	quote.Paid = summary.Paid
+	return quote, payments, nil
+}
+
+// recordSettlement записывает в журнал оплат штраф и возврат переплаты
+// гостю по расчету quote. Деньги возвращаются тем же способом, каким гость
+// платил последний раз. Бронь с такими записями не удаляется очисткой
+// старых броней, поэтому расчет с гостем сохраняется.
+func recordSettlement(tx repository.Store, bookingID int, quote *models.CancellationQuote, payments []models.Payment, now time.Time, reason string) error {
+	method := models.PaymentMethodCash
+	for _, p := range payments {
+		if p.Kind == models.PaymentKindPayment {
+			method = p.Method
+		}
+	}
+
+	if quote.Penalty.IsPositive() {
+		err := tx.Payments().Create(&models.Payment{
+			BookingID: bookingID,
+			Kind:      models.PaymentKindPenalty,
+			Amount:    quote.Penalty,
+			Method:    method,
+			PaidAt:    now,
+			Note:      fmt.Sprintf("Штраф: %s, правила «%s»", reason, quote.Policy),
+		})
+		if err != nil {
+			return fmt.Errorf("ошибка записи штрафа: %w", err)
+		}
+	}
+	if refund := quote.Refund(); refund.IsPositive() {
+		err := tx.Payments().Create(&models.Payment{
+			BookingID: bookingID,
+			Kind:      models.PaymentKindRefund,
+			Amount:    refund.Neg(),
+			Method:    method,
+			PaidAt:    now,
+			Note:      "Возврат: " + reason,
+		})
+		if err != nil {
+			return fmt.Errorf("ошибка записи возврата: %w", err)
+		}
+	}
+	return nil
+}
+
+// daysUntil возвращает, за сколько календарных дней до day наступает now
+// (отрицательное число — day уже прошел)
+func daysUntil(now, day time.Time) int {
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	to := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// setPolicy назначает тарифу фикстуры правила отмены
+func (f *fixture) setPolicy(t *testing.T, policy models.CancellationPolicy) {
+	t.Helper()
+	tariffs := NewTariffService(f.store)
+	saved, err := tariffs.SaveCancellationPolicy(policy)
+	if err != nil {
+		t.Fatalf("save policy: %v", err)
+	}
+	if err := tariffs.SetCancellationPolicy(f.tariff, saved.ID); err != nil {
+		t.Fatalf("set policy: %v", err)
+	}
+}
+
+// ledgerRecord — ожидаемая запись журнала оплат: вид и сумма в рублях
+type ledgerRecord struct {
+	kind   string
+	rubles int64
+}
+
+// assertLedger проверяет журнал оплат брони по порядку записей
+func assertLedger(t *testing.T, f *fixture, bookingID int, want ...ledgerRecord) {
+	t.Helper()
+	payments, err := f.bookings.GetPayments(bookingID)
+	if err != nil {
+		t.Fatalf("payments: %v", err)
+	}
+	got := make([]ledgerRecord, len(payments))
+	for i, p := range payments {
+		got[i] = ledgerRecord{p.Kind, p.Amount.Kopecks / 100}
+		if p.Amount.Kopecks%100 != 0 {
+			t.Errorf("payment %d amount = %s, want whole rubles", i, p.Amount)
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ledger = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("ledger = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCancelBookingSettlement(t *testing.T) {
+	strict := models.CancellationPolicy{Name: "Строгие", FreeDays: 7, PenaltyPercent: 50}
+	sameDay := models.CancellationPolicy{Name: "До заезда", FreeDays: 0, PenaltyPercent: 100}
+	nonRefundable := models.CancellationPolicy{Name: "Невозвратный", NonRefundable: true}
+
+	// Бронь на две ночи стоит 6000 ₽
+	tests := []struct {
+		name   string
+		policy models.CancellationPolicy
+		// in — через сколько дней заезд; paid — сколько гость заплатил
+		in   int
+		paid int64
+		want []ledgerRecord
+	}{
+		{"free cancellation refunds everything", strict, 10, 6000, []ledgerRecord{
+			{models.PaymentKindPayment, 6000},
+			{models.PaymentKindRefund, -6000},
+		}},
+		{"last free day", strict, 7, 2000, []ledgerRecord{
+			{models.PaymentKindPayment, 2000},
+			{models.PaymentKindRefund, -2000},
+		}},
+		{"late cancellation keeps the penalty", strict, 6, 6000, []ledgerRecord{
+			{models.PaymentKindPayment, 6000},
+			{models.PaymentKindPenalty, 3000},
+			{models.PaymentKindRefund, -3000},
+		}},
+		{"deposit smaller than penalty", strict, 3, 1000, []ledgerRecord{
+			{models.PaymentKindPayment, 1000},
+			{models.PaymentKindPenalty, 3000},
+		}},
+		{"unpaid late cancellation", strict, 3, 0, []ledgerRecord{
+			{models.PaymentKindPenalty, 3000},
+		}},
+		{"no free days, day before arrival", sameDay, 1, 6000, []ledgerRecord{
+			{models.PaymentKindPayment, 6000},
+			{models.PaymentKindRefund, -6000},
+		}},
+		{"no free days, arrival day", sameDay, 0, 6000, []ledgerRecord{
+			{models.PaymentKindPayment, 6000},
+			{models.PaymentKindRefund, -6000},
+		}},
+		{"non-refundable", nonRefundable, 30, 6000, []ledgerRecord{
+			{models.PaymentKindPayment, 6000},
+			{models.PaymentKindPenalty, 6000},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forEachStore(t, func(t *testing.T, f *fixture) {
+				f.setPolicy(t, tt.policy)
+				b := f.mustBook(t, f.cottageA, tt.in, tt.in+2)
+				if tt.paid > 0 {
+					f.addPayment(t, b.ID, models.PaymentKindPayment, tt.paid, time.Now().Add(-time.Minute))
+				}
+
+				quote, err := f.bookings.QuoteCancellation(b.ID)
+				if err != nil {
+					t.Fatalf("quote: %v", err)
+				}
+				if err := f.bookings.CancelBooking(b.ID); err != nil {
+					t.Fatalf("cancel: %v", err)
+				}
+				assertLedger(t, f, b.ID, tt.want...)
+
+				// Предварительный расчет совпадает с проведенным
+				var penalty, refund int64
+				for _, r := range tt.want {
+					switch r.kind {
+					case models.PaymentKindPenalty:
+						penalty = r.rubles
+					case models.PaymentKindRefund:
+						refund = -r.rubles
+					}
+				}
+				if quote.Penalty.Cmp(models.Rubles(penalty)) != 0 || quote.Refund().Cmp(models.Rubles(refund)) != 0 {
+					t.Errorf("quote penalty = %s, refund = %s, want %d and %d ₽", quote.Penalty, quote.Refund(), penalty, refund)
+				}
+			})
+		})
+	}
+}
+
+func TestEarlyCheckoutSettlement(t *testing.T) {
+	// Гость заселился позавчера на пять ночей по 3000 ₽ и заплатил 15000 ₽
+	tests := []struct {
+		name   string
+		policy models.CancellationPolicy
+		// out — через сколько дней новый выезд
+		out         int
+		wantStay    int64
+		wantPenalty int64
+		wantRefund  int64
+	}{
+		// Сегодня — меньше дня до выезда: штраф 50% с трех освобожденных ночей
+		{"leaves today", models.CancellationPolicy{Name: "Строгие", FreeDays: 1, PenaltyPercent: 50}, 0, 6000, 4500, 4500},
+		{"leaves tomorrow, notice in time", models.CancellationPolicy{Name: "Строгие", FreeDays: 1, PenaltyPercent: 50}, 1, 9000, 0, 6000},
+		{"no free days, leaves today", models.CancellationPolicy{Name: "До заезда", PenaltyPercent: 100}, 0, 6000, 0, 9000},
+		{"non-refundable", models.CancellationPolicy{Name: "Невозвратный", NonRefundable: true}, 1, 9000, 6000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forEachStore(t, func(t *testing.T, f *fixture) {
+				f.setPolicy(t, tt.policy)
+				b := f.insertBooking(t, models.BookingStatusCheckedIn, -2, 3)
+				b.TotalCost = models.Rubles(15000)
+				if err := f.store.Bookings().Update(*b); err != nil {
+					t.Fatalf("update booking: %v", err)
+				}
+				f.addPayment(t, b.ID, models.PaymentKindPayment, 15000, day(-2))
+
+				quote, err := f.bookings.QuoteEarlyCheckout(b.ID, day(tt.out))
+				if err != nil {
+					t.Fatalf("quote: %v", err)
+				}
+				if want := models.Rubles(15000 - tt.wantStay); quote.Released.Cmp(want) != 0 {
+					t.Errorf("released = %s, want %s", quote.Released, want)
+				}
+				if quote.Stay.Cmp(models.Rubles(tt.wantStay)) != 0 || quote.Penalty.Cmp(models.Rubles(tt.wantPenalty)) != 0 ||
+					quote.Refund().Cmp(models.Rubles(tt.wantRefund)) != 0 {
+					t.Errorf("quote stay = %s, penalty = %s, refund = %s, want %d, %d and %d ₽",
+						quote.Stay, quote.Penalty, quote.Refund(), tt.wantStay, tt.wantPenalty, tt.wantRefund)
+				}
+
+				if err := f.bookings.UpdateCheckOutDate(b.ID, day(tt.out), "семейные обстоятельства"); err != nil {
+					t.Fatalf("update check-out: %v", err)
+				}
+				updated, err := f.bookings.GetBookingByID(b.ID)
+				if err != nil {
+					t.Fatalf("get booking: %v", err)
+				}
+				if updated.TotalCost.Cmp(models.Rubles(tt.wantStay)) != 0 {
+					t.Errorf("total = %s, want %s", updated.TotalCost, models.Rubles(tt.wantStay))
+				}
+				if want := models.DefaultStayPolicy.CheckOutTime(day(tt.out)); !updated.CheckOutDate.Equal(want) {
+					t.Errorf("check-out = %s, want %s", updated.CheckOutDate, want)
+				}
+
+				want := []ledgerRecord{{models.PaymentKindPayment, 15000}}
+				if tt.wantPenalty > 0 {
+					want = append(want, ledgerRecord{models.PaymentKindPenalty, tt.wantPenalty})
+				}
+				if tt.wantRefund > 0 {
+					want = append(want, ledgerRecord{models.PaymentKindRefund, -tt.wantRefund})
+				}
+				assertLedger(t, f, b.ID, want...)
+			})
+		})
+	}
+}
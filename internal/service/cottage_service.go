@@ -1,53 +1,34 @@
 package service
 
 import (
-	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
 )
 
 type CottageService struct {
-	db *sql.DB
+	store repository.Store
 }
 
-func NewCottageService(db *sql.DB) *CottageService {
-	return &CottageService{db: db}
+func NewCottageService(store repository.Store) *CottageService {
+	return &CottageService{store: store}
 }
 
 func (s *CottageService) GetFreeCottages() ([]models.Cottage, error) {
-	rows, err := s.db.Query("SELECT cottage_id, name FROM lesbaza.cottages WHERE status = 'free'")
+	cottages, err := s.store.Cottages().ListByStatus("free")
 	if err != nil {
 		return nil, fmt.Errorf("failed to query free cottages: %w", err)
 	}
-	defer rows.Close()
-
-	var cottages []models.Cottage
-	for rows.Next() {
-		var c models.Cottage
-		if err := rows.Scan(&c.ID, &c.Name); err != nil {
-			return nil, fmt.Errorf("failed to scan cottage: %w", err)
-		}
-		cottages = append(cottages, c)
-	}
 	return cottages, nil
 }
 
 func (s *CottageService) GetAllCottages() ([]models.Cottage, error) {
-	rows, err := s.db.Query("SELECT cottage_id, name, status FROM lesbaza.cottages ORDER BY cottage_id")
+	cottages, err := s.store.Cottages().List()
 	if err != nil {
 		return nil, fmt.Errorf("failed to query all cottages: %w", err)
 	}
-	defer rows.Close()
-
-	var cottages []models.Cottage
-	for rows.Next() {
-		var c models.Cottage
-		if err := rows.Scan(&c.ID, &c.Name, &c.Status); err != nil {
-			return nil, fmt.Errorf("failed to scan cottage: %w", err)
-		}
-		cottages = append(cottages, c)
-	}
 	return cottages, nil
 }
 
@@ -58,10 +39,7 @@ func (s *CottageService) CreateCottage(cottage models.Cottage) error {
 
 // AddCottage добавляет новый домик
 func (s *CottageService) AddCottage(name string) error {
-	_, err := s.db.Exec(
-		"INSERT INTO lesbaza.cottages (name, status) VALUES ($1, 'free')",
-		name,
-	)
+	err := s.store.Cottages().Create(&models.Cottage{Name: name, Status: "free"})
 	if err != nil {
 		return fmt.Errorf("ошибка добавления домика: %w", err)
 	}
@@ -70,11 +48,7 @@ func (s *CottageService) AddCottage(name string) error {
 
 // UpdateCottageStatus обновляет статус домика
 func (s *CottageService) UpdateCottageStatus(cottageID int, status string) error {
-	_, err := s.db.Exec(
-		"UPDATE lesbaza.cottages SET status = $1 WHERE cottage_id = $2",
-		status,
-		cottageID,
-	)
+	err := s.store.Cottages().UpdateStatus(cottageID, status)
 	if err != nil {
 		return fmt.Errorf("ошибка обновления статуса домика: %w", err)
 	}
@@ -84,14 +58,10 @@ func (s *CottageService) UpdateCottageStatus(cottageID int, status string) error
 // DeleteCottage удаляет домик по ID
 func (s *CottageService) DeleteCottage(cottageID int) error {
 	// Проверяем, нет ли активных бронирований для этого домика
-	var activeBookings int
-	err := s.db.QueryRow(`
-		SELECT COUNT(*) 
-		FROM lesbaza.bookings 
-		WHERE cottage_id = $1 
-		AND status IN ('booked', 'checked_in')
-	`, cottageID).Scan(&activeBookings)
-
+	activeBookings, err := s.store.Bookings().CountByCottage(cottageID, []string{
+		models.BookingStatusBooked,
+		models.BookingStatusCheckedIn,
+	})
 	if err != nil {
 		return fmt.Errorf("ошибка проверки активных бронирований: %w", err)
 	}
@@ -101,13 +71,7 @@ func (s *CottageService) DeleteCottage(cottageID int) error {
 	}
 
 	// Проверяем, не заселен ли кто-то в домике
-	var guestCount int
-	err = s.db.QueryRow(`
-		SELECT COUNT(*) 
-		FROM lesbaza.guests 
-		WHERE cottage_id = $1
-	`, cottageID).Scan(&guestCount)
-
+	guestCount, err := s.store.Guests().CountByCottage(cottageID)
 	if err != nil {
 		return fmt.Errorf("ошибка проверки гостей: %w", err)
 	}
@@ -117,18 +81,12 @@ func (s *CottageService) DeleteCottage(cottageID int) error {
 	}
 
 	// Удаляем домик
-	result, err := s.db.Exec("DELETE FROM lesbaza.cottages WHERE cottage_id = $1", cottageID)
-	if err != nil {
-		return fmt.Errorf("ошибка удаления домика: %w", err)
+	err = s.store.Cottages().Delete(cottageID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("домик с ID %d не найден", cottageID)
 	}
-
-	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("ошибка получения количества удаленных строк: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("домик с ID %d не найден", cottageID)
+		return fmt.Errorf("ошибка удаления домика: %w", err)
 	}
 
 	return nil
@@ -136,22 +94,33 @@ func (s *CottageService) DeleteCottage(cottageID int) error {
 
 // UpdateCottageName обновляет название домика
 func (s *CottageService) UpdateCottageName(cottageID int, newName string) error {
-	result, err := s.db.Exec(
-		"UPDATE lesbaza.cottages SET name = $1 WHERE cottage_id = $2",
-		newName, cottageID,
-	)
+	err := s.store.Cottages().UpdateName(cottageID, newName)
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("домик с ID %d не найден", cottageID)
+	}
 	if err != nil {
 		return fmt.Errorf("ошибка обновления названия домика: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("ошибка получения количества обновленных строк: %w", err)
+	return nil
+}
+
+// UpdateCottageCapacity меняет вместимость домика: сколько взрослых
+// и всего гостей он вмещает (0 — без ограничения)
+func (s *CottageService) UpdateCottageCapacity(cottageID, maxAdults, maxGuests int) error {
+	if maxAdults < 0 || maxGuests < 0 {
+		return fmt.Errorf("вместимость не может быть отрицательной")
+	}
+	if maxAdults > 0 && maxGuests > 0 && maxAdults > maxGuests {
+		return fmt.Errorf("взрослых не может быть больше, чем всего гостей")
 	}
 
-	if rowsAffected == 0 {
+	err := s.store.Cottages().UpdateCapacity(cottageID, maxAdults, maxGuests)
+	if errors.Is(err, repository.ErrNotFound) {
 		return fmt.Errorf("домик с ID %d не найден", cottageID)
 	}
-
+	if err != nil {
+		return fmt.Errorf("ошибка обновления вместимости домика: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// ErrDiscountInvalid возвращается, когда промокод нельзя применить к брони
+var ErrDiscountInvalid = errors.New("промокод недействителен")
+
+// findDiscount находит промокод code и проверяет, что его можно применить
+// в день today к брони по тарифу tariffID
+func findDiscount(store repository.Store, code string, tariffID int, today time.Time) (*models.Discount, error) {
+	code = models.NormalizeDiscountCode(code)
+	discount, err := store.Discounts().GetByCode(code)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: промокод «%s» не найден", ErrDiscountInvalid, code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения промокода: %w", err)
+	}
+
+	switch {
+	case !discount.Active:
+		return nil, fmt.Errorf("%w: промокод «%s» отключен", ErrDiscountInvalid, code)
+	case !discount.ValidOn(today):
+		return nil, fmt.Errorf("%w: промокод «%s» не действует %s", ErrDiscountInvalid, code, today.Format("02.01.2006"))
+	case discount.Exhausted():
+		return nil, fmt.Errorf("%w: промокод «%s» уже использован %d раз", ErrDiscountInvalid, code, discount.UsedCount)
+	case !discount.AppliesTo(tariffID):
+		return nil, fmt.Errorf("%w: промокод «%s» не действует для этого тарифа", ErrDiscountInvalid, code)
+	}
+	return discount, nil
+}
+
+// ApplyDiscountCode проверяет промокод так же, как CreateBooking, и добавляет
+// скидку к расчету quote. Пустой код снимает скидку.
+func (s *BookingService) ApplyDiscountCode(quote *models.PriceQuote, code string) error {
+	quote.DiscountCode, quote.Discount = "", models.NewMoney(0)
+	if models.NormalizeDiscountCode(code) == "" {
+		return nil
+	}
+	discount, err := findDiscount(s.store, code, quote.TariffID, time.Now())
+	if err != nil {
+		return err
+	}
+	quote.DiscountCode = discount.Code
+	quote.Discount = discount.Amount(quote.Total)
+	return nil
+}
+
+// GetDiscounts возвращает все промокоды
+func (s *TariffService) GetDiscounts() ([]models.Discount, error) {
+	discounts, err := s.store.Discounts().List()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения промокодов: %w", err)
+	}
+	return discounts, nil
+}
+
+// SaveDiscount создает промокод (ID = 0) или сохраняет изменения.
+// Код хранится заглавными буквами и должен быть уникальным.
+func (s *TariffService) SaveDiscount(discount models.Discount) (*models.Discount, error) {
+	discount.Code = models.NormalizeDiscountCode(discount.Code)
+	if discount.Code == "" {
+		return nil, fmt.Errorf("укажите промокод")
+	}
+	switch discount.Kind {
+	case models.DiscountPercent:
+		if discount.Value <= 0 || discount.Value > 100 {
+			return nil, fmt.Errorf("процент скидки должен быть от 0 до 100")
+		}
+		discount.FixedAmount = models.NewMoney(0)
+	case models.DiscountFixed:
+		if !discount.FixedAmount.IsPositive() {
+			return nil, fmt.Errorf("сумма скидки должна быть больше нуля")
+		}
+		discount.Value = 0
+	default:
+		return nil, fmt.Errorf("неизвестный вид скидки: %s", discount.Kind)
+	}
+	if discount.MaxUses < 0 {
+		return nil, fmt.Errorf("лимит использований не может быть отрицательным")
+	}
+	if !discount.ValidFrom.IsZero() {
+		discount.ValidFrom = time.Date(discount.ValidFrom.Year(), discount.ValidFrom.Month(), discount.ValidFrom.Day(), 0, 0, 0, 0, time.Local)
+	}
+	if !discount.ValidTo.IsZero() {
+		discount.ValidTo = time.Date(discount.ValidTo.Year(), discount.ValidTo.Month(), discount.ValidTo.Day(), 0, 0, 0, 0, time.Local)
+	}
+	if !discount.ValidFrom.IsZero() && !discount.ValidTo.IsZero() && discount.ValidTo.Before(discount.ValidFrom) {
+		return nil, fmt.Errorf("срок действия не может закончиться раньше, чем начался")
+	}
+
+	existing, err := s.store.Discounts().GetByCode(discount.Code)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("ошибка получения промокода: %w", err)
+	}
+	if existing != nil && existing.ID != discount.ID {
+		return nil, fmt.Errorf("промокод «%s» уже существует", discount.Code)
+	}
+
+	err = s.store.WithTx(func(tx repository.Store) error {
+		if discount.ID == 0 {
+			return tx.Discounts().Create(&discount)
+		}
+		return tx.Discounts().Update(discount)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения промокода: %w", err)
+	}
+	return &discount, nil
+}
+
+// DeleteDiscount удаляет промокод. Примененный промокод остается в бронях
+// и пересчитывается вместе с ними, поэтому его можно только отключить.
+func (s *TariffService) DeleteDiscount(discountID int) error {
+	discount, err := s.store.Discounts().GetByID(discountID)
+	if err != nil {
+		return fmt.Errorf("промокод с ID %d не найден: %w", discountID, err)
+	}
+	if discount.UsedCount > 0 {
+		return fmt.Errorf("промокод «%s» уже применялся — его можно только отключить", discount.Code)
+	}
+	if err := s.store.Discounts().Delete(discountID); err != nil {
+		return fmt.Errorf("ошибка удаления промокода: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+func TestFixedDiscountKeepsKopecks(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		tariffs := NewTariffService(f.store)
+		saved, err := tariffs.SaveDiscount(models.Discount{
+			Code: "минус", Kind: models.DiscountFixed, Value: 5, FixedAmount: models.NewMoney(50050), Active: true,
+		})
+		if err != nil {
+			t.Fatalf("save discount: %v", err)
+		}
+
+		stored, err := f.store.Discounts().GetByID(saved.ID)
+		if err != nil {
+			t.Fatalf("get discount: %v", err)
+		}
+		// Для фиксированной скидки процент не хранится
+		if stored.FixedAmount.Cmp(models.NewMoney(50050)) != 0 || stored.Value != 0 {
+			t.Errorf("stored discount amount = %s, value = %v, want 500.50 ₽ and no percent", stored.FixedAmount, stored.Value)
+		}
+
+		b, err := f.bookings.CreateBooking(models.Booking{
+			CottageID: f.cottageA, TariffID: f.tariff, GuestName: "Иванов",
+			CheckInDate: day(3), CheckOutDate: day(5), DiscountCode: "минус",
+		})
+		if err != nil {
+			t.Fatalf("create booking: %v", err)
+		}
+		if want := models.NewMoney(50050); b.DiscountAmount.Cmp(want) != 0 {
+			t.Errorf("discount = %s, want %s", b.DiscountAmount, want)
+		}
+		if want := models.NewMoney(549950); b.TotalCost.Cmp(want) != 0 {
+			t.Errorf("total = %s, want %s", b.TotalCost, want)
+		}
+
+		if _, err := tariffs.SaveDiscount(models.Discount{Code: "ноль", Kind: models.DiscountFixed, Value: 5, Active: true}); err == nil {
+			t.Error("saved a fixed discount without an amount")
+		}
+	})
+}
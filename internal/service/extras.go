@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// folioStatuses — статусы броней, к которым можно добавлять услуги
+var folioStatuses = []string{models.BookingStatusBooked, models.BookingStatusCheckedIn, models.BookingStatusCompleted}
+
+// GetExtras возвращает справочник платных услуг
+func (s *TariffService) GetExtras() ([]models.Extra, error) {
+	extras, err := s.store.Extras().List()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения услуг: %w", err)
+	}
+	return extras, nil
+}
+
+// SaveExtra сохраняет услугу справочника: новую (extra.ID == 0) или
+// измененную. Новая цена действует на услуги, заказанные после изменения.
+func (s *TariffService) SaveExtra(extra models.Extra) (*models.Extra, error) {
+	extra.Name = strings.TrimSpace(extra.Name)
+	extra.Unit = strings.TrimSpace(extra.Unit)
+	if extra.Name == "" {
+		return nil, fmt.Errorf("укажите название услуги")
+	}
+	if extra.Price.IsNegative() {
+		return nil, fmt.Errorf("цена услуги не может быть отрицательной")
+	}
+
+	err := s.store.WithTx(func(tx repository.Store) error {
+		extras, err := tx.Extras().List()
+		if err != nil {
+			return err
+		}
+		for _, e := range extras {
+			if e.ID != extra.ID && strings.EqualFold(e.Name, extra.Name) {
+				return fmt.Errorf("услуга «%s» уже существует", e.Name)
+			}
+		}
+		if extra.ID == 0 {
+			return tx.Extras().Create(&extra)
+		}
+		return tx.Extras().Update(extra)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения услуги: %w", err)
+	}
+	return &extra, nil
+}
+
+// DeleteExtra удаляет услугу из справочника; уже заказанные услуги
+// остаются в фолио броней
+func (s *TariffService) DeleteExtra(extraID int) error {
+	if err := s.store.Extras().Delete(extraID); err != nil {
+		return fmt.Errorf("ошибка удаления услуги: %w", err)
+	}
+	return nil
+}
+
+// GetFolio возвращает услуги, заказанные по брони, по дате оказания
+func (s *BookingService) GetFolio(bookingID int) ([]models.FolioItem, error) {
+	items, err := s.store.Folio().ListByBooking(bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения услуг брони: %w", err)
+	}
+	return items, nil
+}
+
+// AddFolioItem добавляет услугу в фолио брони. Услуга справочника
+// (item.ExtraID != 0) должна быть в продаже; без названия строка получает
+// название услуги. Дата оказания по умолчанию — текущая.
+func (s *BookingService) AddFolioItem(item models.FolioItem) (*models.FolioItem, error) {
+	item.Title = strings.TrimSpace(item.Title)
+	if item.Quantity <= 0 {
+		return nil, fmt.Errorf("количество должно быть больше нуля")
+	}
+	if item.UnitPrice.IsNegative() {
+		return nil, fmt.Errorf("цена услуги не может быть отрицательной")
+	}
+	item.CreatedAt = time.Now()
+	if item.ServiceDate.IsZero() {
+		item.ServiceDate = item.CreatedAt
+	}
+
+	err := s.store.WithTx(func(tx repository.Store) error {
+		if err := s.checkFolioBooking(tx, item.BookingID); err != nil {
+			return err
+		}
+		if item.ExtraID != 0 {
+			extra, err := tx.Extras().GetByID(item.ExtraID)
+			if err != nil {
+				return fmt.Errorf("услуга с ID %d не найдена: %w", item.ExtraID, err)
+			}
+			if !extra.Active {
+				return fmt.Errorf("услуга «%s» снята с продажи", extra.Name)
+			}
+			if item.Title == "" {
+				item.Title = extra.Name
+			}
+		}
+		if item.Title == "" {
+			return fmt.Errorf("укажите название услуги")
+		}
+		return tx.Folio().Create(&item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// RemoveFolioItem удаляет услугу из фолио брони
+func (s *BookingService) RemoveFolioItem(itemID int) error {
+	return s.store.WithTx(func(tx repository.Store) error {
+		item, err := tx.Folio().GetByID(itemID)
+		if err != nil {
+			return fmt.Errorf("услуга брони не найдена: %w", err)
+		}
+		if err := s.checkFolioBooking(tx, item.BookingID); err != nil {
+			return err
+		}
+		if err := tx.Folio().Delete(itemID); err != nil {
+			return fmt.Errorf("ошибка удаления услуги брони: %w", err)
+		}
+		return nil
+	})
+}
+
+// checkFolioBooking проверяет, что фолио брони можно менять
+func (s *BookingService) checkFolioBooking(store repository.Store, bookingID int) error {
+	booking, err := store.Bookings().GetByID(bookingID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения бронирования: %w", err)
+	}
+	if !slices.Contains(folioStatuses, booking.Status) {
+		return fmt.Errorf("услуги брони в статусе «%s» не меняются", models.BookingStatusTitle(booking.Status))
+	}
+	return nil
+}
@@ -2,23 +2,25 @@
 package service
 
 import (
-	"database/sql"
 	"fmt"
 	"time"
 
 	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
 	"github.com/VallfIK/bazaotdx/internal/utils"
 )
 
 type GuestService struct {
-	db            *sql.DB
+	store         repository.Store
 	documentsPath string // Путь для хранения документов из конфига
+	stay          models.StayPolicy
 }
 
-func NewGuestService(db *sql.DB, documentsPath string) *GuestService {
+func NewGuestService(store repository.Store, documentsPath string, stay models.StayPolicy) *GuestService {
 	return &GuestService{
-		db:            db,
+		store:         store,
 		documentsPath: documentsPath,
+		stay:          stay,
 	}
 }
 
@@ -38,130 +40,64 @@ func (s *GuestService) RegisterGuest(guest models.Guest, cottageID int) error {
 		guest.DocumentScanPath = newDocPath
 	}
 
-	// Начинаем транзакцию
-	tx, err := s.db.Begin()
-	if err != nil {
-		return fmt.Errorf("ошибка начала транзакции: %w", err)
-	}
+	guest.CottageID = cottageID
 
-	// Вставляем запись о госте
-	_, err = tx.Exec(`
-		INSERT INTO lesbaza.guests 
-			(full_name, email, phone, cottage_id, document_scan_path, check_in_date, check_out_date, tariff_id) 
-		VALUES 
-			($1, $2, $3, $4, $5, $6, $7, $8)`,
-		guest.FullName,
-		guest.Email,
-		guest.Phone,
-		cottageID,
-		guest.DocumentScanPath,
-		guest.CheckInDate,
-		guest.CheckOutDate,
-		guest.TariffID,
-	)
-	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("ошибка добавления гостя: %w", err)
-	}
-
-	// Обновляем статус домика
-	_, err = tx.Exec(`
-        UPDATE lesbaza.cottages 
-        SET status = 'occupied' 
-        WHERE cottage_id = $1`,
-		cottageID,
-	)
-	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("ошибка обновления статуса домика: %w", err)
-	}
+	return s.store.WithTx(func(tx repository.Store) error {
+		// Вставляем запись о госте
+		if err := tx.Guests().Create(&guest); err != nil {
+			return fmt.Errorf("ошибка добавления гостя: %w", err)
+		}
 
-	// Фиксируем транзакцию
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
-	}
+		// Обновляем статус домика
+		if err := tx.Cottages().UpdateStatus(cottageID, "occupied"); err != nil {
+			return fmt.Errorf("ошибка обновления статуса домика: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // internal/service/guest_service.go
 func (s *GuestService) GetGuestByCottageID(cottageID int) (*models.Guest, error) {
-	row := s.db.QueryRow(`
-        SELECT 
-            guest_id, 
-            full_name, 
-            email, 
-            phone, 
-            cottage_id, 
-            document_scan_path 
-        FROM lesbaza.guests 
-        WHERE cottage_id = $1`, cottageID)
-
-	guest := &models.Guest{}
-	err := row.Scan(
-		&guest.ID,
-		&guest.FullName,
-		&guest.Email,
-		&guest.Phone,
-		&guest.CottageID,
-		&guest.DocumentScanPath,
-	)
-
+	guest, err := s.store.Guests().GetByCottageID(cottageID)
 	if err != nil {
 		return nil, fmt.Errorf("guest not found: %w", err)
 	}
 	return guest, nil
 }
 
-func (s *GuestService) CheckOutGuest(cottageID int) error {
-	tx, err := s.db.Begin()
+// GetGuests возвращает всех зарегистрированных гостей
+func (s *GuestService) GetGuests() ([]models.Guest, error) {
+	guests, err := s.store.Guests().List()
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("ошибка получения гостей: %w", err)
 	}
+	return guests, nil
+}
 
-	// Удаляем гостя
-	_, err = tx.Exec("DELETE FROM lesbaza.guests WHERE cottage_id = $1", cottageID)
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
+func (s *GuestService) CheckOutGuest(cottageID int) error {
+	return s.store.WithTx(func(tx repository.Store) error {
+		// Удаляем гостя
+		if err := tx.Guests().DeleteByCottageID(cottageID); err != nil {
+			return err
+		}
 
-	// Обновляем статус домика
-	_, err = tx.Exec("UPDATE lesbaza.cottages SET status='free' WHERE cottage_id=$1", cottageID)
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
+		// Обновляем статус домика
+		return tx.Cottages().UpdateStatus(cottageID, "free")
+	})
+}
 
-	return tx.Commit()
+// EvictCheckedOutGuests удаляет гостей, чей выезд был более grace назад
+func (s *GuestService) EvictCheckedOutGuests(grace time.Duration) (int64, error) {
+	return s.store.Guests().DeleteCheckedOutBefore(time.Now().Add(-grace))
 }
 
-func (s *GuestService) CalculateCost(checkIn, checkOut time.Time, tariffID int) (float64, error) {
-	var price float64
-	err := s.db.QueryRow(
-		"SELECT price_per_day FROM lesbaza.tariffs WHERE tariff_id = $1",
-		tariffID,
-	).Scan(&price)
+// CalculateCost считает стоимость проживания гостя в домике по тарифу.
+// Состав гостей при заселении не известен, поэтому доплата за гостей не берется.
+func (s *GuestService) CalculateCost(checkIn, checkOut time.Time, tariffID, cottageID int) (models.Money, error) {
+	quote, err := quoteStay(s.store, s.stay, tariffID, cottageID, 0, 0, checkIn, checkOut)
 	if err != nil {
-		return 0, err
+		return models.Money{}, err
 	}
-
-	// Нормализируем даты: заезд в 14:00, выезд в 12:00
-	checkInNormalized := time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 14, 0, 0, 0, time.Local)
-	checkOutNormalized := time.Date(checkOut.Year(), checkOut.Month(), checkOut.Day(), 12, 0, 0, 0, time.Local)
-
-	// Если выезд в тот же день что и заезд - это минимум 1 день
-	if checkInNormalized.Format("2006-01-02") == checkOutNormalized.Format("2006-01-02") {
-		return price, nil
-	}
-
-	// Рассчитываем количество полных дней
-	days := int(checkOutNormalized.Sub(checkInNormalized).Hours() / 24)
-
-	// Если получается 0 или меньше дней, возвращаем стоимость за 1 день
-	if days <= 0 {
-		return price, nil
-	}
-
-	return float64(days) * price, nil
+	return quote.Total, nil
 }
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/invoice"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// InvoiceService выставляет гостям счета-квитанции в PDF
+type InvoiceService struct {
+	store         repository.Store
+	stay          models.StayPolicy
+	documentsPath string // PDF сохраняются в папке invoices внутри нее
+	seller        models.LegalDetails
+}
+
+// NewInvoiceService создает сервис счетов с реквизитами продавца seller
+func NewInvoiceService(store repository.Store, stay models.StayPolicy, documentsPath string, seller models.LegalDetails) *InvoiceService {
+	return &InvoiceService{store: store, stay: stay, documentsPath: documentsPath, seller: seller}
+}
+
+// GetInvoices возвращает счета брони по порядку выставления
+func (s *InvoiceService) GetInvoices(bookingID int) ([]models.Invoice, error) {
+	invoices, err := s.store.Invoices().ListByBooking(bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения счетов: %w", err)
+	}
+	return invoices, nil
+}
+
+// issueAttempts — сколько раз IssueInvoice пробует выставить счет, если
+// следующий номер года успевает занять параллельный запрос
+const issueAttempts = 5
+
+// IssueInvoice выставляет счет-квитанцию по заселенной или завершенной
+// брони: присваивает следующий номер года и сохраняет PDF в папке
+// документов. Повторный вызов выставляет новый счет с текущими суммами.
+func (s *InvoiceService) IssueInvoice(bookingID int) (*models.Invoice, error) {
+	for attempt := 1; ; attempt++ {
+		issued, err := s.issueInvoice(bookingID)
+		if !errors.Is(err, repository.ErrDuplicate) {
+			return issued, err
+		}
+		if attempt == issueAttempts {
+			return nil, fmt.Errorf("не удалось присвоить номер счета: его занимают другие рабочие места, повторите попытку")
+		}
+	}
+}
+
+// issueInvoice выставляет счет со следующим номером года; если номер
+// успели занять, возвращает ошибку с repository.ErrDuplicate
+func (s *InvoiceService) issueInvoice(bookingID int) (*models.Invoice, error) {
+	now := time.Now()
+	var issued models.Invoice
+	written := false
+
+	err := s.store.WithTx(func(tx repository.Store) error {
+		booking, err := tx.Bookings().GetByID(bookingID)
+		if err != nil {
+			return fmt.Errorf("ошибка получения бронирования: %w", err)
+		}
+		if booking.Status != models.BookingStatusCheckedIn && booking.Status != models.BookingStatusCompleted {
+			return fmt.Errorf("счет выставляется только заселенному или выехавшему гостю")
+		}
+
+		doc, err := s.buildDocument(tx, *booking)
+		if err != nil {
+			return err
+		}
+
+		last, err := tx.Invoices().LastSeq(now.Year())
+		if err != nil {
+			return fmt.Errorf("ошибка получения номера счета: %w", err)
+		}
+		inv := &doc.Invoice
+		inv.Year, inv.Seq = now.Year(), last+1
+		inv.Number = models.InvoiceNumber(inv.Year, inv.Seq)
+		inv.IssuedAt = now
+		inv.FilePath = filepath.Join(s.documentsPath, "invoices", strconv.Itoa(inv.Year), "Счет_"+inv.Number+".pdf")
+		if err := tx.Invoices().Create(inv); err != nil {
+			return fmt.Errorf("ошибка сохранения счета: %w", err)
+		}
+
+		if err := invoice.WritePDF(inv.FilePath, doc); err != nil {
+			return fmt.Errorf("ошибка создания PDF счета: %w", err)
+		}
+		written = true
+		issued = *inv
+		return nil
+	})
+	if err != nil {
+		// Номер не сохранился — файл с ним не должен остаться
+		if written {
+			os.Remove(issued.FilePath)
+		}
+		return nil, err
+	}
+	return &issued, nil
+}
+
+// buildDocument собирает начисления брони для счета: проживание, скидку,
+// услуги по фолио и штрафы, — и оплаты
+func (s *InvoiceService) buildDocument(store repository.Store, booking models.Booking) (invoice.Document, error) {
+	doc := invoice.Document{Seller: s.seller, Booking: booking}
+
+	cottage, err := store.Cottages().GetByID(booking.CottageID)
+	if err != nil {
+		return doc, fmt.Errorf("ошибка получения домика: %w", err)
+	}
+	doc.Cottage = cottage.Name
+
+	if booking.TariffID != 0 {
+		tariff, err := store.Tariffs().GetByID(booking.TariffID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return doc, fmt.Errorf("ошибка получения тарифа: %w", err)
+		}
+		if tariff != nil {
+			doc.Tariff = tariff.Name
+		}
+	}
+
+	payments, err := store.Payments().ListByBooking(booking.ID)
+	if err != nil {
+		return doc, fmt.Errorf("ошибка получения оплат: %w", err)
+	}
+	doc.Payments = payments
+
+	doc.Lines = s.stayLines(store, booking)
+	if booking.DiscountAmount.IsPositive() {
+		doc.Lines = append(doc.Lines, models.InvoiceLine{
+			Title:    "Скидка по промокоду " + booking.DiscountCode,
+			Quantity: 1,
+			Price:    booking.DiscountAmount.Neg(),
+			Amount:   booking.DiscountAmount.Neg(),
+		})
+	}
+
+	folio, err := store.Folio().ListByBooking(booking.ID)
+	if err != nil {
+		return doc, fmt.Errorf("ошибка получения услуг брони: %w", err)
+	}
+	for _, item := range folio {
+		doc.Lines = append(doc.Lines, models.InvoiceLine{
+			Title:    fmt.Sprintf("%s (%s)", item.Title, item.ServiceDate.Format("02.01")),
+			Quantity: item.Quantity,
+			Price:    item.UnitPrice,
+			Amount:   item.Amount(),
+		})
+	}
+	for _, p := range payments {
+		if p.IsPenalty() {
+			doc.Lines = append(doc.Lines, models.InvoiceLine{Title: p.Note, Quantity: 1, Price: p.Amount, Amount: p.Amount})
+		}
+	}
+
+	summary := paymentSummary(booking, payments)
+	doc.Invoice = models.Invoice{
+		BookingID: booking.ID,
+		GuestName: booking.GuestName,
+		Total:     summary.Total,
+		Paid:      summary.Paid,
+	}
+	return doc, nil
+}
+
+// stayLines разбивает проживание на строки счета: подряд идущие ночи
+// с одинаковой ценой — одна строка. Если цены тарифа с тех пор
+// изменились и расчет не сходится со стоимостью брони, проживание
+// выводится одной строкой.
+func (s *InvoiceService) stayLines(store repository.Store, booking models.Booking) []models.InvoiceLine {
+	stayCost := booking.TotalCost.Add(booking.DiscountAmount)
+	nights := s.stay.Nights(booking.CheckInDate, booking.CheckOutDate)
+	single := []models.InvoiceLine{{
+		Title:    fmt.Sprintf("Проживание %s–%s", booking.CheckInDate.Format("02.01"), booking.CheckOutDate.Format("02.01.2006")),
+		Quantity: 1,
+		Price:    stayCost,
+		Amount:   stayCost,
+	}}
+	if booking.TariffID == 0 || nights == 0 {
+		return single
+	}
+
+	quote, err := quoteStay(store, s.stay, booking.TariffID, booking.CottageID, booking.Adults, booking.Children,
+		booking.CheckInDate, booking.CheckOutDate)
+	if err != nil || quote.Total.Cmp(stayCost) != 0 {
+		return single
+	}
+
+	var lines []models.InvoiceLine
+	for i := 0; i < len(quote.Nights); {
+		night := quote.Nights[i]
+		j := i + 1
+		for j < len(quote.Nights) && quote.Nights[j].Price.Cmp(night.Price) == 0 {
+			j++
+		}
+		dates := night.Date.Format("02.01")
+		if j-i > 1 {
+			dates += "–" + quote.Nights[j-1].Date.Format("02.01")
+		}
+		lines = append(lines, models.InvoiceLine{
+			Title:    fmt.Sprintf("Проживание, ночи %s", dates),
+			Quantity: j - i,
+			Price:    night.Price,
+			Amount:   night.Price.Mul(j - i),
+		})
+		i = j
+	}
+	return lines
+}
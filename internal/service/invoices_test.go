@@ -0,0 +1,104 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// racingStore перед сохранением счета занимает его номер, как это сделал бы
+// параллельный запрос, пока races больше нуля
+type racingStore struct {
+	repository.Store
+	races *int
+}
+
+func (s racingStore) WithTx(fn func(tx repository.Store) error) error {
+	return s.Store.WithTx(func(tx repository.Store) error {
+		return fn(racingStore{Store: tx, races: s.races})
+	})
+}
+
+func (s racingStore) Invoices() repository.InvoiceRepository {
+	return racingInvoices{InvoiceRepository: s.Store.Invoices(), races: s.races}
+}
+
+type racingInvoices struct {
+	repository.InvoiceRepository
+	races *int
+}
+
+func (r racingInvoices) Create(invoice *models.Invoice) error {
+	if *r.races > 0 {
+		*r.races--
+		rival := *invoice
+		rival.BookingID = 0
+		if err := r.InvoiceRepository.Create(&rival); err != nil {
+			return err
+		}
+	}
+	return r.InvoiceRepository.Create(invoice)
+}
+
+func TestIssueInvoiceNumbers(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		docs := t.TempDir()
+		invoices := NewInvoiceService(f.store, models.DefaultStayPolicy, docs, models.LegalDetails{CompanyName: "ИП Иванов"})
+		b := f.insertBooking(t, models.BookingStatusCompleted, -5, -3)
+
+		year := time.Now().Year()
+		for seq := 1; seq <= 2; seq++ {
+			inv, err := invoices.IssueInvoice(b.ID)
+			if err != nil {
+				t.Fatalf("issue invoice: %v", err)
+			}
+			if inv.Year != year || inv.Seq != seq || inv.Number != models.InvoiceNumber(year, seq) {
+				t.Errorf("invoice = %d/%d %q, want %d/%d", inv.Year, inv.Seq, inv.Number, year, seq)
+			}
+			if _, err := os.Stat(inv.FilePath); err != nil {
+				t.Errorf("invoice pdf: %v", err)
+			}
+		}
+	})
+}
+
+func TestIssueInvoiceRetriesTakenNumber(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		b := f.insertBooking(t, models.BookingStatusCompleted, -5, -3)
+		races := 0
+		store := racingStore{Store: f.store, races: &races}
+		docs := t.TempDir()
+		invoices := NewInvoiceService(store, models.DefaultStayPolicy, docs, models.LegalDetails{CompanyName: "ИП Иванов"})
+
+		// Номер дважды занимают — третья попытка его получает
+		races = 2
+		inv, err := invoices.IssueInvoice(b.ID)
+		if err != nil {
+			t.Fatalf("issue invoice: %v", err)
+		}
+		if races != 0 || inv.Seq != 1 {
+			t.Errorf("races left = %d, seq = %d, want 0 and 1", races, inv.Seq)
+		}
+
+		// Номер занимают при каждой попытке — понятная ошибка без счета и PDF
+		races = issueAttempts
+		if _, err := invoices.IssueInvoice(b.ID); err == nil {
+			t.Fatal("issued an invoice while every number was taken")
+		}
+		issued, err := invoices.GetInvoices(b.ID)
+		if err != nil {
+			t.Fatalf("get invoices: %v", err)
+		}
+		if len(issued) != 1 {
+			t.Errorf("invoices = %d, want 1", len(issued))
+		}
+		files, _ := filepath.Glob(filepath.Join(docs, "invoices", "*", "*.pdf"))
+		if len(files) != 1 {
+			t.Errorf("pdf files = %v, want only the first invoice", files)
+		}
+	})
+}
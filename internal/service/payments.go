@@ -0,0 +1,156 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// defaultDeposit назначает новой брони предоплату DefaultDepositPercent
+// от стоимости, если она не указана явно
+func defaultDeposit(booking *models.Booking) {
+	if !booking.DepositAmount.IsPositive() {
+		booking.DepositAmount = booking.TotalCost.Percent(models.DefaultDepositPercent)
+	}
+	booking.DepositAmount = booking.DepositAmount.Min(booking.TotalCost)
+}
+
+// GetPayments возвращает журнал оплат брони по времени
+func (s *BookingService) GetPayments(bookingID int) ([]models.Payment, error) {
+	payments, err := s.store.Payments().ListByBooking(bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения оплат: %w", err)
+	}
+	return payments, nil
+}
+
+// GetPaymentsBetween возвращает оплаты всех броней за период с from по to
+func (s *BookingService) GetPaymentsBetween(from, to time.Time) ([]models.Payment, error) {
+	payments, err := s.store.Payments().ListBetween(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения оплат: %w", err)
+	}
+	return payments, nil
+}
+
+// GetPaymentSummary возвращает стоимость брони, требуемую предоплату
+// и сумму, полученную по журналу оплат
+func (s *BookingService) GetPaymentSummary(bookingID int) (*models.PaymentSummary, error) {
+	booking, err := s.store.Bookings().GetByID(bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения бронирования: %w", err)
+	}
+	payments, err := s.GetPayments(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	return paymentSummary(*booking, payments), nil
+}
+
+// paymentSummary сводит журнал оплат брони. Стоимость включает услуги
+// по фолио. Предоплата не может быть больше стоимости, которая могла
+// уменьшиться после изменения брони; отмененная бронь стоит столько,
+// сколько начислено штрафов.
+func paymentSummary(booking models.Booking, payments []models.Payment) *models.PaymentSummary {
+	summary := &models.PaymentSummary{
+		Total:   booking.Total(),
+		Deposit: booking.DepositAmount,
+	}
+	if booking.Status == models.BookingStatusCancelled {
+		summary.Total, summary.Deposit = models.NewMoney(0), models.NewMoney(0)
+	}
+	summary.Deposit = summary.Deposit.Min(summary.Total)
+	for _, p := range payments {
+		if p.IsPenalty() {
+			summary.Penalty = summary.Penalty.Add(p.Amount)
+		} else {
+			summary.Paid = summary.Paid.Add(p.Amount)
+		}
+	}
+	summary.Total = summary.Total.Add(summary.Penalty)
+	return summary
+}
+
+// RegisterPayment записывает в журнал оплату гостя по брони, в том числе
+// штрафа по отмененной брони. Время оплаты по умолчанию — текущее.
+func (s *BookingService) RegisterPayment(payment models.Payment) (*models.Payment, error) {
+	payment.Kind = models.PaymentKindPayment
+	if !payment.Amount.IsPositive() {
+		return nil, fmt.Errorf("сумма оплаты должна быть больше нуля")
+	}
+	return s.addPayment(payment, models.BookingStatusBooked, models.BookingStatusCheckedIn,
+		models.BookingStatusCompleted, models.BookingStatusCancelled)
+}
+
+// RegisterRefund записывает возврат гостю суммы payment.Amount.
+// Вернуть можно не больше, чем получено по брони.
+func (s *BookingService) RegisterRefund(refund models.Payment) (*models.Payment, error) {
+	if !refund.Amount.IsPositive() {
+		return nil, fmt.Errorf("сумма возврата должна быть больше нуля")
+	}
+	refund.Kind = models.PaymentKindRefund
+	refund.Amount = refund.Amount.Neg()
+	return s.addPayment(refund, models.BookingStatusBooked, models.BookingStatusCheckedIn,
+		models.BookingStatusCompleted, models.BookingStatusCancelled)
+}
+
+// addPayment проверяет способ оплаты и статус брони и сохраняет запись
+// в одной транзакции с проверкой суммы по журналу оплат
+func (s *BookingService) addPayment(payment models.Payment, statuses ...string) (*models.Payment, error) {
+	if !slices.Contains(models.PaymentMethods, payment.Method) {
+		return nil, fmt.Errorf("неизвестный способ оплаты: %s", payment.Method)
+	}
+	payment.Operator = strings.TrimSpace(payment.Operator)
+	payment.Note = strings.TrimSpace(payment.Note)
+	if payment.PaidAt.IsZero() {
+		payment.PaidAt = time.Now()
+	}
+
+	err := s.store.WithTx(func(tx repository.Store) error {
+		booking, err := tx.Bookings().GetByID(payment.BookingID)
+		if err != nil {
+			return fmt.Errorf("ошибка получения бронирования: %w", err)
+		}
+		if !slices.Contains(statuses, booking.Status) {
+			return fmt.Errorf("по брони в статусе «%s» оплаты не принимаются", models.BookingStatusTitle(booking.Status))
+		}
+		payments, err := tx.Payments().ListByBooking(payment.BookingID)
+		if err != nil {
+			return fmt.Errorf("ошибка получения оплат: %w", err)
+		}
+		summary := paymentSummary(*booking, payments)
+		switch {
+		case payment.IsRefund() && payment.Amount.Neg().Cmp(summary.Paid) > 0:
+			return fmt.Errorf("нельзя вернуть больше полученного: %s", summary.Paid)
+		case !payment.IsRefund() && booking.Status == models.BookingStatusCancelled && payment.Amount.Cmp(summary.Balance()) > 0:
+			return fmt.Errorf("по отмененной брони можно оплатить только штраф: %s", summary.Balance().Max(models.NewMoney(0)))
+		}
+		return tx.Payments().Create(&payment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// SetDeposit меняет требуемую предоплату брони: от нуля до её стоимости
+func (s *BookingService) SetDeposit(bookingID int, amount models.Money) error {
+	return s.store.WithTx(func(tx repository.Store) error {
+		booking, err := tx.Bookings().GetByID(bookingID)
+		if err != nil {
+			return fmt.Errorf("ошибка получения бронирования: %w", err)
+		}
+		if booking.Status != models.BookingStatusBooked && booking.Status != models.BookingStatusCheckedIn {
+			return fmt.Errorf("предоплату можно изменить только у действующей брони")
+		}
+		if amount.IsNegative() || amount.Cmp(booking.TotalCost) > 0 {
+			return fmt.Errorf("предоплата должна быть от 0 до %s", booking.TotalCost)
+		}
+		booking.DepositAmount = amount
+		return tx.Bookings().Update(*booking)
+	})
+}
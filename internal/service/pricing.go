@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// quoteStay — движок цен и единственный расчет стоимости проживания:
+// считает стоимость в домике по тарифу с разбивкой по ночам. Ночи
+// определяет расчетный час stay (models.StayPolicy.NightDates).
+//
+// Базовая цена ночи берется из самой точной цены тарифа: сезон для этого
+// домика, сезон для всех домиков, цена домика, иначе цена тарифа. Среди
+// пересекающихся сезонов выигрывает более короткий. На праздники и выходные
+// добавляется наценка тарифа; праздничная заменяет выходную. За гостей
+// сверх включенных в цену тарифа к каждой ночи добавляется доплата без наценки.
+func quoteStay(store repository.Store, stay models.StayPolicy, tariffID, cottageID, adults, children int, checkIn, checkOut time.Time) (*models.PriceQuote, error) {
+	tariff, err := store.Tariffs().GetByID(tariffID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения тарифа: %w", err)
+	}
+	rates, err := store.TariffRates().ListByTariff(tariffID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения цен тарифа: %w", err)
+	}
+
+	nights := stay.NightDates(stay.Normalize(checkIn, checkOut))
+
+	holidays, err := store.Holidays().ListBetween(nights[0], nights[len(nights)-1])
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения праздников: %w", err)
+	}
+	holidayNames := make(map[string]string, len(holidays))
+	for _, h := range holidays {
+		holidayNames[h.Date.Format("2006-01-02")] = h.Name
+	}
+
+	extraGuests := tariff.ExtraGuestsPrice(adults, children)
+	quote := &models.PriceQuote{TariffID: tariffID, CottageID: cottageID, Adults: adults, Children: children, Total: models.NewMoney(0)}
+	for _, night := range nights {
+		price := models.NightPrice{Date: night, RateName: tariff.Name, BasePrice: tariff.PricePerDay}
+		if rate := pickRate(rates, cottageID, night); rate != nil {
+			price.RateName = rate.Name
+			price.BasePrice = rate.PricePerDay
+		}
+
+		if name, ok := holidayNames[night.Format("2006-01-02")]; ok && tariff.HolidaySurcharge != 0 {
+			price.Surcharge, price.SurchargeName = tariff.HolidaySurcharge, "праздник"
+			if name != "" {
+				price.SurchargeName = name
+			}
+		} else if models.IsWeekendNight(night) && tariff.WeekendSurcharge != 0 {
+			price.Surcharge, price.SurchargeName = tariff.WeekendSurcharge, "выходной"
+		}
+
+		price.ExtraGuests = extraGuests
+		price.Price = price.BasePrice.Add(price.BasePrice.Percent(price.Surcharge)).Add(extraGuests)
+		quote.Nights = append(quote.Nights, price)
+		quote.Total = quote.Total.Add(price.Price)
+	}
+	return quote, nil
+}
+
+// pickRate выбирает самую точную цену тарифа на ночь night или nil,
+// если действует цена самого тарифа
+func pickRate(rates []models.TariffRate, cottageID int, night time.Time) *models.TariffRate {
+	var best *models.TariffRate
+	bestScore := -1
+	for i := range rates {
+		rate := &rates[i]
+		if (rate.CottageID != 0 && rate.CottageID != cottageID) || !rate.Covers(night) {
+			continue
+		}
+
+		score := 0
+		if rate.Seasonal() {
+			score += 2
+		}
+		if rate.CottageID != 0 {
+			score++
+		}
+
+		switch {
+		case score > bestScore:
+		case score == bestScore && rate.Seasonal() &&
+			rate.EndDate.Sub(rate.StartDate) < best.EndDate.Sub(best.StartDate):
+		default:
+			continue
+		}
+		best, bestScore = rate, score
+	}
+	return best
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// useLocation подменяет местный часовой пояс на время теста. Берлин
+// переходит на летнее время 29.03.2026.
+func useLocation(t *testing.T, name string) {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+	local := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = local })
+}
+
+// date возвращает местную полночь дня
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func TestQuoteBooking(t *testing.T) {
+	useLocation(t, "Europe/Berlin")
+
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		// Тариф 3000 ₽: выходные +50%, праздники +100%, в августе 4000 ₽
+		tariffs := NewTariffService(f.store)
+		if err := tariffs.SetSurcharges(f.tariff, 50, 100); err != nil {
+			t.Fatalf("set surcharges: %v", err)
+		}
+		if _, err := tariffs.SaveRate(models.TariffRate{
+			TariffID:    f.tariff,
+			Name:        "Лето",
+			StartDate:   date(2026, time.August, 1),
+			EndDate:     date(2026, time.August, 31),
+			PricePerDay: models.Rubles(4000),
+		}); err != nil {
+			t.Fatalf("save rate: %v", err)
+		}
+		for _, day := range []time.Time{date(2026, time.December, 31), date(2027, time.January, 1)} {
+			if _, err := tariffs.AddHoliday(day, ""); err != nil {
+				t.Fatalf("add holiday: %v", err)
+			}
+		}
+
+		tests := []struct {
+			name     string
+			checkIn  time.Time
+			checkOut time.Time
+			// nights — цена каждой ночи в рублях
+			nights []int64
+		}{
+			{
+				// Заезд и выезд в один день — одна ночь
+				name:     "same day",
+				checkIn:  date(2026, time.September, 30),
+				checkOut: date(2026, time.September, 30),
+				nights:   []int64{3000},
+			},
+			{
+				name:     "one weekday night",
+				checkIn:  date(2026, time.November, 30),
+				checkOut: date(2026, time.December, 1),
+				nights:   []int64{3000},
+			},
+			{
+				name:     "one weekend night",
+				checkIn:  date(2026, time.November, 27),
+				checkOut: date(2026, time.November, 28),
+				nights:   []int64{4500},
+			},
+			{
+				// Пт 31.07 по тарифу с наценкой, Сб 01.08 — уже по летней
+				// цене с наценкой, Вс 02.08 — по летней цене
+				name:     "weekend across month and season boundary",
+				checkIn:  date(2026, time.July, 31),
+				checkOut: date(2026, time.August, 3),
+				nights:   []int64{4500, 6000, 4000},
+			},
+			{
+				// Ср 30.12; Чт 31.12 и Пт 01.01 — праздники, праздничная
+				// наценка вместо выходной; Сб 02.01 — выходной
+				name:     "holidays across month and year boundary",
+				checkIn:  date(2026, time.December, 30),
+				checkOut: date(2027, time.January, 3),
+				nights:   []int64{3000, 6000, 6000, 4500},
+			},
+			{
+				// Ночь на 29.03 на час короче, но это одна ночь
+				name:     "spring DST transition",
+				checkIn:  date(2026, time.March, 27),
+				checkOut: date(2026, time.March, 30),
+				nights:   []int64{4500, 4500, 3000},
+			},
+			{
+				name:     "autumn DST transition",
+				checkIn:  date(2026, time.October, 23),
+				checkOut: date(2026, time.October, 26),
+				nights:   []int64{4500, 4500, 3000},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				quote, err := f.bookings.QuoteBooking(f.tariff, f.cottageA, 0, 0, tt.checkIn, tt.checkOut)
+				if err != nil {
+					t.Fatalf("quote: %v", err)
+				}
+				if len(quote.Nights) != len(tt.nights) {
+					t.Fatalf("nights = %d, want %d", len(quote.Nights), len(tt.nights))
+				}
+
+				total := models.NewMoney(0)
+				for i, night := range quote.Nights {
+					if want := tt.checkIn.AddDate(0, 0, i); !night.Date.Equal(want) {
+						t.Errorf("night %d date = %s, want %s", i, night.Date.Format(time.RFC3339), want.Format(time.RFC3339))
+					}
+					if want := models.Rubles(tt.nights[i]); night.Price.Cmp(want) != 0 {
+						t.Errorf("night %s price = %s, want %s", night.Date.Format("2006-01-02"), night.Price, want)
+					}
+					total = total.Add(models.Rubles(tt.nights[i]))
+				}
+				if quote.Total.Cmp(total) != 0 {
+					t.Errorf("total = %s, want %s", quote.Total, total)
+				}
+			})
+		}
+	})
+}
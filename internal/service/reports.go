@@ -0,0 +1,316 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// maxReportDays ограничивает период отчета, чтобы случайно введенный
+// год не строил отчет за века
+const maxReportDays = 3 * 366
+
+// Состояние ночи домика в отчете о заполняемости
+const (
+	nightFree = iota
+	nightSold
+	nightBlocked
+)
+
+// ReportService строит отчеты по броням базы отдыха
+type ReportService struct {
+	store repository.Store
+	stay  models.StayPolicy
+}
+
+// NewReportService создает сервис отчетов
+func NewReportService(store repository.Store, stay models.StayPolicy) *ReportService {
+	return &ReportService{store: store, stay: stay}
+}
+
+// Occupancy считает заполняемость домиков за ночи с from по to включительно.
+// Проданной считается ночь действующей или завершенной брони; ночи под
+// блокировкой (ремонт, уборка) не продаются и из доступных исключаются.
+func (s *ReportService) Occupancy(from, to time.Time) (*models.OccupancyReport, error) {
+	days, err := reportDays(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	cottages, err := s.store.Cottages().List()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения домиков: %w", err)
+	}
+	nights, err := s.nightStates(cottages, days)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.OccupancyReport{From: days[0], To: days[len(days)-1]}
+	report.Days = make([]models.PeriodOccupancy, len(days))
+	for i, day := range days {
+		report.Days[i].Start = day
+	}
+	for _, c := range cottages {
+		row := models.CottageOccupancy{CottageID: c.ID, Name: c.Name}
+		for i, state := range nights[c.ID] {
+			night := occupancyNight(state)
+			row.OccupancyStats = row.OccupancyStats.Add(night)
+			report.Days[i].OccupancyStats = report.Days[i].OccupancyStats.Add(night)
+		}
+		report.Cottages = append(report.Cottages, row)
+		report.Total = report.Total.Add(row.OccupancyStats)
+	}
+
+	for _, day := range report.Days {
+		month := monthStart(day.Start)
+		if n := len(report.Months); n == 0 || !report.Months[n-1].Start.Equal(month) {
+			report.Months = append(report.Months, models.PeriodOccupancy{Start: month})
+		}
+		last := &report.Months[len(report.Months)-1]
+		last.OccupancyStats = last.OccupancyStats.Add(day.OccupancyStats)
+	}
+	return report, nil
+}
+
+// nightStates размечает ночи периода каждого домика: свободна, продана
+// или заблокирована
+func (s *ReportService) nightStates(cottages []models.Cottage, days []time.Time) (map[int][]int, error) {
+	index := make(map[string]int, len(days))
+	for i, day := range days {
+		index[day.Format("2006-01-02")] = i
+	}
+	nights := make(map[int][]int, len(cottages))
+	for _, c := range cottages {
+		nights[c.ID] = make([]int, len(days))
+	}
+
+	bookings, err := s.store.Bookings().ListByDateRange(days[0], days[len(days)-1].AddDate(0, 0, 1), []string{
+		models.BookingStatusCancelled,
+		models.BookingStatusTemporary,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения бронирований: %w", err)
+	}
+	for _, b := range bookings {
+		states, ok := nights[b.CottageID]
+		if !ok {
+			continue
+		}
+		state := nightSold
+		if b.Status == models.BookingStatusBlocked {
+			state = nightBlocked
+		}
+		for _, night := range s.stay.NightDates(b.CheckInDate, b.CheckOutDate) {
+			// Проданная ночь остается проданной, даже если на нее попала блокировка
+			if i, ok := index[night.Format("2006-01-02")]; ok && states[i] != nightSold {
+				states[i] = state
+			}
+		}
+	}
+	return nights, nil
+}
+
+// Revenue считает выручку и показатели продаж за дни с from по to
+// включительно. Стоимость проживания брони делится поровну между ее
+// ночами, поэтому бронь на стыке месяцев попадает в каждый месяц только
+// своими ночами. Услуги относятся к дню оказания; заезды, ALOS, срок
+// бронирования и отмены — к дню заезда. Снятые удержания отменами не
+// считаются.
+func (s *ReportService) Revenue(from, to time.Time) (*models.RevenueReport, error) {
+	occupancy, err := s.Occupancy(from, to)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(occupancy.Days))
+	for i, day := range occupancy.Days {
+		index[day.Start.Format("2006-01-02")] = i
+	}
+
+	tariffs, err := s.store.Tariffs().List()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения тарифов: %w", err)
+	}
+	bookings, err := s.store.Bookings().ListByDateRange(occupancy.From, occupancy.To.AddDate(0, 0, 1), []string{
+		models.BookingStatusTemporary,
+		models.BookingStatusBlocked,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения бронирований: %w", err)
+	}
+
+	// Показатели каждой брони раскладываются по дням периода
+	days := make([]models.RevenueStats, len(occupancy.Days))
+	cottages := make(map[int]*models.RevenueStats)
+	byTariff := make(map[int]*models.RevenueStats)
+	for _, b := range bookings {
+		add := func(day int, o models.RevenueStats) {
+			days[day] = days[day].Add(o)
+			addGroup(cottages, b.CottageID, o)
+			addGroup(byTariff, b.TariffID, o)
+		}
+
+		if err := s.addBookingRevenue(b, index, add); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &models.RevenueReport{From: occupancy.From, To: occupancy.To}
+	report.Days = make([]models.PeriodRevenue, len(days))
+	for i, day := range occupancy.Days {
+		stats := days[i]
+		stats.Available = day.Available
+		report.Days[i] = models.PeriodRevenue{Start: day.Start, RevenueStats: stats}
+		report.Total = report.Total.Add(stats)
+
+		month := monthStart(day.Start)
+		if n := len(report.Months); n == 0 || !report.Months[n-1].Start.Equal(month) {
+			report.Months = append(report.Months, models.PeriodRevenue{Start: month})
+		}
+		last := &report.Months[len(report.Months)-1]
+		last.RevenueStats = last.RevenueStats.Add(stats)
+	}
+
+	for _, c := range occupancy.Cottages {
+		row := models.RevenueGroup{ID: c.CottageID, Name: c.Name}
+		if stats, ok := cottages[c.CottageID]; ok {
+			row.RevenueStats = *stats
+		}
+		row.Available = c.Available
+		report.Cottages = append(report.Cottages, row)
+	}
+
+	// Тарифы — в порядке справочника, брони без тарифа и с удаленным
+	// тарифом — в конце
+	for _, t := range tariffs {
+		if stats, ok := byTariff[t.ID]; ok {
+			report.Tariffs = append(report.Tariffs, models.RevenueGroup{ID: t.ID, Name: t.Name, RevenueStats: *stats})
+			delete(byTariff, t.ID)
+		}
+	}
+	rest := make([]int, 0, len(byTariff))
+	for id := range byTariff {
+		rest = append(rest, id)
+	}
+	sort.Ints(rest)
+	for _, id := range rest {
+		name := fmt.Sprintf("Тариф #%d", id)
+		if id == 0 {
+			name = "Без тарифа"
+		}
+		report.Tariffs = append(report.Tariffs, models.RevenueGroup{ID: id, Name: name, RevenueStats: *byTariff[id]})
+	}
+	return report, nil
+}
+
+// addBookingRevenue передает в add показатели брони по дням периода,
+// заданным index: выручку за ночи, услуги и заезд
+func (s *ReportService) addBookingRevenue(b models.Booking, index map[string]int, add func(day int, o models.RevenueStats)) error {
+	cancelled := b.Status == models.BookingStatusCancelled
+	nights := s.stay.NightDates(b.CheckInDate, b.CheckOutDate)
+
+	if arrival, ok := index[b.CheckInDate.Format("2006-01-02")]; ok {
+		switch {
+		case cancelled && !b.HoldExpiresAt.IsZero():
+			// Удержание сняли, не подтвердив, — это не отмена брони
+		case cancelled:
+			add(arrival, models.RevenueStats{Bookings: 1, Cancelled: 1})
+		default:
+			add(arrival, models.RevenueStats{
+				Bookings:   1,
+				Arrivals:   1,
+				StayNights: len(nights),
+				LeadDays:   leadDays(b.CreatedAt, b.CheckInDate),
+			})
+		}
+	}
+	if cancelled {
+		return nil
+	}
+
+	shares := b.TotalCost.Split(len(nights))
+	for i, night := range nights {
+		if day, ok := index[night.Format("2006-01-02")]; ok {
+			add(day, models.RevenueStats{Revenue: shares[i], Sold: 1})
+		}
+	}
+
+	if b.ExtrasTotal.IsZero() {
+		return nil
+	}
+	items, err := s.store.Folio().ListByBooking(b.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения услуг брони: %w", err)
+	}
+	for _, item := range items {
+		if day, ok := index[item.ServiceDate.Format("2006-01-02")]; ok {
+			add(day, models.RevenueStats{Extras: item.Amount()})
+		}
+	}
+	return nil
+}
+
+// addGroup прибавляет o к показателям домика или тарифа id
+func addGroup(groups map[int]*models.RevenueStats, id int, o models.RevenueStats) {
+	stats, ok := groups[id]
+	if !ok {
+		stats = &models.RevenueStats{}
+		groups[id] = stats
+	}
+	*stats = stats.Add(o)
+}
+
+// leadDays возвращает число дней от бронирования до заезда; бронь,
+// оформленная в день заезда или задним числом, — ноль
+func leadDays(createdAt, checkIn time.Time) int {
+	if createdAt.IsZero() {
+		return 0
+	}
+	created := time.Date(createdAt.Year(), createdAt.Month(), createdAt.Day(), 0, 0, 0, 0, time.Local)
+	arrival := time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 0, 0, 0, 0, time.Local)
+	days := int(math.Round(arrival.Sub(created).Hours() / 24))
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// monthStart возвращает первый день месяца t
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
+}
+
+// occupancyNight переводит состояние одной ночи домика в счетчики отчета
+func occupancyNight(state int) models.OccupancyStats {
+	switch state {
+	case nightSold:
+		return models.OccupancyStats{Sold: 1, Available: 1}
+	case nightBlocked:
+		return models.OccupancyStats{Blocked: 1}
+	default:
+		return models.OccupancyStats{Available: 1}
+	}
+}
+
+// reportDays возвращает календарные дни периода отчета с from по to
+// включительно
+func reportDays(from, to time.Time) ([]time.Time, error) {
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.Local)
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.Local)
+	if last.Before(first) {
+		return nil, fmt.Errorf("конец периода отчета раньше начала")
+	}
+
+	var days []time.Time
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if len(days) == maxReportDays {
+			return nil, fmt.Errorf("период отчета не может быть длиннее %d дней", maxReportDays)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
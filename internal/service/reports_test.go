@@ -0,0 +1,196 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// insertStay сохраняет бронь b тарифа фикстуры в обход проверок сервиса,
+// переводя даты на расчетные часы
+func (f *fixture) insertStay(t *testing.T, b models.Booking) *models.Booking {
+	t.Helper()
+	b.CheckInDate, b.CheckOutDate = models.DefaultStayPolicy.Normalize(b.CheckInDate, b.CheckOutDate)
+	b.TariffID = f.tariff
+	if b.GuestName == "" && b.Status != models.BookingStatusBlocked {
+		b.GuestName = "Иванов"
+	}
+	if err := f.store.Bookings().Create(&b); err != nil {
+		t.Fatalf("insert %s booking %s–%s: %v", b.Status,
+			b.CheckInDate.Format("02.01"), b.CheckOutDate.Format("02.01"), err)
+	}
+	return &b
+}
+
+func TestOccupancy(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		// Домик A: проданы ночи 30 и 31 января; отмена, снятое и действующее
+		// удержание ночей не занимают
+		f.insertStay(t, models.Booking{CottageID: f.cottageA, Status: models.BookingStatusCompleted,
+			CheckInDate: date(2026, time.January, 30), CheckOutDate: date(2026, time.February, 1)})
+		f.insertStay(t, models.Booking{CottageID: f.cottageA, Status: models.BookingStatusCancelled,
+			CheckInDate: date(2026, time.January, 29), CheckOutDate: date(2026, time.January, 30)})
+		f.insertStay(t, models.Booking{CottageID: f.cottageA, Status: models.BookingStatusCancelled,
+			CheckInDate: date(2026, time.February, 1), CheckOutDate: date(2026, time.February, 2),
+			HoldExpiresAt: date(2026, time.January, 20)})
+		f.insertStay(t, models.Booking{CottageID: f.cottageA, Status: models.BookingStatusTemporary,
+			CheckInDate: date(2026, time.February, 2), CheckOutDate: date(2026, time.February, 3),
+			HoldExpiresAt: date(2026, time.February, 2)})
+
+		// Домик B: проданы 29 и 30 января; блокировка 30–31 января оставляет
+		// ночь 30-го проданной и закрывает 31-е; 2 февраля продано
+		f.insertStay(t, models.Booking{CottageID: f.cottageB, Status: models.BookingStatusCompleted,
+			CheckInDate: date(2026, time.January, 29), CheckOutDate: date(2026, time.January, 31)})
+		f.insertStay(t, models.Booking{CottageID: f.cottageB, Status: models.BookingStatusBlocked,
+			CheckInDate: date(2026, time.January, 30), CheckOutDate: date(2026, time.February, 1)})
+		f.insertStay(t, models.Booking{CottageID: f.cottageB, Status: models.BookingStatusBooked,
+			CheckInDate: date(2026, time.February, 2), CheckOutDate: date(2026, time.February, 4)})
+
+		report, err := NewReportService(f.store, models.DefaultStayPolicy).
+			Occupancy(date(2026, time.January, 29), date(2026, time.February, 2))
+		if err != nil {
+			t.Fatalf("occupancy: %v", err)
+		}
+
+		assertOccupancy := func(name string, got, want models.OccupancyStats) {
+			t.Helper()
+			if got != want {
+				t.Errorf("%s = %+v, want %+v", name, got, want)
+			}
+		}
+		assertOccupancy("total", report.Total, models.OccupancyStats{Sold: 5, Blocked: 1, Available: 9})
+
+		if len(report.Cottages) != 2 {
+			t.Fatalf("cottages = %d, want 2", len(report.Cottages))
+		}
+		assertOccupancy("cottage A", report.Cottages[0].OccupancyStats, models.OccupancyStats{Sold: 2, Available: 5})
+		assertOccupancy("cottage B", report.Cottages[1].OccupancyStats, models.OccupancyStats{Sold: 3, Blocked: 1, Available: 4})
+
+		wantDays := []models.OccupancyStats{
+			{Sold: 1, Available: 2},             // 29.01: A отменена
+			{Sold: 2, Available: 2},             // 30.01: у B продано под блокировкой
+			{Sold: 1, Blocked: 1, Available: 1}, // 31.01
+			{Available: 2},                      // 01.02: A — снятое удержание
+			{Sold: 1, Available: 2},             // 02.02: A — удержание
+		}
+		if len(report.Days) != len(wantDays) {
+			t.Fatalf("days = %d, want %d", len(report.Days), len(wantDays))
+		}
+		for i, want := range wantDays {
+			assertOccupancy(report.Days[i].Start.Format("02.01"), report.Days[i].OccupancyStats, want)
+		}
+
+		if len(report.Months) != 2 {
+			t.Fatalf("months = %d, want 2", len(report.Months))
+		}
+		for i, want := range []struct {
+			start time.Time
+			stats models.OccupancyStats
+		}{
+			{date(2026, time.January, 1), models.OccupancyStats{Sold: 4, Blocked: 1, Available: 5}},
+			{date(2026, time.February, 1), models.OccupancyStats{Sold: 1, Available: 4}},
+		} {
+			month := report.Months[i]
+			if !month.Start.Equal(want.start) {
+				t.Errorf("month %d starts %s, want %s", i, month.Start.Format("02.01.2006"), want.start.Format("02.01.2006"))
+			}
+			assertOccupancy(month.Start.Format("01.2006"), month.OccupancyStats, want.stats)
+		}
+		if got := report.Months[0].Percent(); got != 80 {
+			t.Errorf("January occupancy = %v%%, want 80%%", got)
+		}
+	})
+}
+
+func TestRevenue(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		// Три ночи на стыке месяцев за 10 000 ₽: 3333,34 ₽ за 30.01,
+		// по 3333,33 ₽ за 31.01 и 01.02; бронировали за 10 дней
+		f.insertStay(t, models.Booking{CottageID: f.cottageA, Status: models.BookingStatusCompleted,
+			CheckInDate: date(2026, time.January, 30), CheckOutDate: date(2026, time.February, 2),
+			TotalCost: models.Rubles(10000), CreatedAt: date(2026, time.January, 20)})
+		// Две ночи в феврале по 3500 ₽, бронировали накануне
+		f.insertStay(t, models.Booking{CottageID: f.cottageB, Status: models.BookingStatusBooked,
+			CheckInDate: date(2026, time.February, 1), CheckOutDate: date(2026, time.February, 3),
+			TotalCost: models.Rubles(7000), CreatedAt: date(2026, time.January, 31)})
+		// Отмена с заездом в январе; снятое удержание отменой не считается
+		f.insertStay(t, models.Booking{CottageID: f.cottageB, Status: models.BookingStatusCancelled,
+			CheckInDate: date(2026, time.January, 29), CheckOutDate: date(2026, time.January, 30),
+			TotalCost: models.Rubles(3000), CreatedAt: date(2026, time.January, 1)})
+		f.insertStay(t, models.Booking{CottageID: f.cottageA, Status: models.BookingStatusCancelled,
+			CheckInDate: date(2026, time.January, 28), CheckOutDate: date(2026, time.January, 29),
+			TotalCost: models.Rubles(3000), CreatedAt: date(2026, time.January, 27), HoldExpiresAt: date(2026, time.January, 27)})
+
+		report, err := NewReportService(f.store, models.DefaultStayPolicy).
+			Revenue(date(2026, time.January, 28), date(2026, time.February, 2))
+		if err != nil {
+			t.Fatalf("revenue: %v", err)
+		}
+
+		tests := []struct {
+			name  string
+			stats models.RevenueStats
+			want  models.RevenueStats
+			// Ожидаемые ADR и RevPAR в копейках, ALOS, срок бронирования
+			// и доля отмен
+			adr, revPAR             int64
+			alos, leadTime, cxlRate float64
+		}{
+			{"January", report.Months[0].RevenueStats, models.RevenueStats{
+				Revenue: models.NewMoney(666667), Extras: models.NewMoney(0), Sold: 2, Available: 8,
+				Arrivals: 1, StayNights: 3, LeadDays: 10, Bookings: 2, Cancelled: 1,
+			}, 333334, 83333, 3, 10, 50},
+			{"February", report.Months[1].RevenueStats, models.RevenueStats{
+				Revenue: models.NewMoney(1033333), Extras: models.NewMoney(0), Sold: 3, Available: 4,
+				Arrivals: 1, StayNights: 2, LeadDays: 1, Bookings: 1,
+			}, 344444, 258333, 2, 1, 0},
+			{"total", report.Total, models.RevenueStats{
+				Revenue: models.NewMoney(1700000), Extras: models.NewMoney(0), Sold: 5, Available: 12,
+				Arrivals: 2, StayNights: 5, LeadDays: 11, Bookings: 3, Cancelled: 1,
+			}, 340000, 141667, 2.5, 5.5, 100.0 / 3},
+		}
+		if len(report.Months) != 2 {
+			t.Fatalf("months = %d, want 2", len(report.Months))
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, want := tt.stats, tt.want
+				if got.Revenue.Cmp(want.Revenue) != 0 || got.Extras.Cmp(want.Extras) != 0 {
+					t.Errorf("revenue = %s, extras = %s, want %s and %s", got.Revenue, got.Extras, want.Revenue, want.Extras)
+				}
+				got.Revenue, got.Extras, want.Revenue, want.Extras = models.Money{}, models.Money{}, models.Money{}, models.Money{}
+				if got != want {
+					t.Errorf("stats = %+v, want %+v", got, want)
+				}
+
+				if adr := tt.stats.ADR(); adr.Cmp(models.NewMoney(tt.adr)) != 0 {
+					t.Errorf("ADR = %s, want %s", adr, models.NewMoney(tt.adr))
+				}
+				if revPAR := tt.stats.RevPAR(); revPAR.Cmp(models.NewMoney(tt.revPAR)) != 0 {
+					t.Errorf("RevPAR = %s, want %s", revPAR, models.NewMoney(tt.revPAR))
+				}
+				for _, kpi := range []struct {
+					name      string
+					got, want float64
+				}{
+					{"ALOS", tt.stats.ALOS(), tt.alos},
+					{"lead time", tt.stats.LeadTime(), tt.leadTime},
+					{"cancellation rate", tt.stats.CancellationRate(), tt.cxlRate},
+				} {
+					if math.Abs(kpi.got-kpi.want) > 1e-9 {
+						t.Errorf("%s = %v, want %v", kpi.name, kpi.got, kpi.want)
+					}
+				}
+			})
+		}
+
+		// Выручка домиков — по их ночам в периоде
+		for i, want := range []int64{1000000, 700000} {
+			if got := report.Cottages[i].Revenue; got.Cmp(models.NewMoney(want)) != 0 {
+				t.Errorf("cottage %d revenue = %s, want %s", i, got, models.NewMoney(want))
+			}
+		}
+	})
+}
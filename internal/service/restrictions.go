@@ -0,0 +1,115 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
+)
+
+// ErrStayRestricted возвращается, когда даты брони нарушают ограничения:
+// минимальный срок проживания, запрет заезда или выезда
+var ErrStayRestricted = errors.New("даты брони нарушают ограничения")
+
+// checkRestrictions проверяет бронь домика на даты checkIn–checkOut:
+// заезд не запрещен, срок не меньше минимального для дня заезда,
+// выезд не запрещен
+func (s *BookingService) checkRestrictions(store repository.Store, cottageID int, checkIn, checkOut time.Time) error {
+	nights := s.stay.NightDates(checkIn, checkOut)
+	arrival := nights[0]
+	departure := nights[len(nights)-1].AddDate(0, 0, 1)
+
+	restrictions, err := store.StayRestrictions().ListBetween(arrival, departure)
+	if err != nil {
+		return fmt.Errorf("ошибка получения ограничений: %w", err)
+	}
+
+	onArrival := models.RestrictionsOn(restrictions, cottageID, arrival)
+	if onArrival.ClosedToArrival {
+		return fmt.Errorf("%w: заезд %s закрыт%s", ErrStayRestricted,
+			arrival.Format("02.01.2006"), restrictionSuffix(onArrival))
+	}
+	if len(nights) < onArrival.MinNights {
+		return fmt.Errorf("%w: при заезде %s минимальный срок — %d %s%s", ErrStayRestricted,
+			arrival.Format("02.01.2006"), onArrival.MinNights, nightsWord(onArrival.MinNights), restrictionSuffix(onArrival))
+	}
+
+	onDeparture := models.RestrictionsOn(restrictions, cottageID, departure)
+	if onDeparture.ClosedToDeparture {
+		return fmt.Errorf("%w: выезд %s закрыт%s", ErrStayRestricted,
+			departure.Format("02.01.2006"), restrictionSuffix(onDeparture))
+	}
+	return nil
+}
+
+// restrictionSuffix добавляет к сообщению название ограничения
+func restrictionSuffix(d models.DayRestriction) string {
+	if d.Name == "" {
+		return ""
+	}
+	return " (" + d.Name + ")"
+}
+
+// nightsWord согласует слово «ночь» с числом
+func nightsWord(n int) string {
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return "ночь"
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20):
+		return "ночи"
+	default:
+		return "ночей"
+	}
+}
+
+// GetRestrictions возвращает ограничения, действующие хотя бы в один день
+// периода from–to
+func (s *BookingService) GetRestrictions(from, to time.Time) ([]models.StayRestriction, error) {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.Local)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.Local)
+	restrictions, err := s.store.StayRestrictions().ListBetween(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения ограничений: %w", err)
+	}
+	return restrictions, nil
+}
+
+// SaveRestriction создает ограничение (ID = 0) или сохраняет изменения.
+// Даты округляются до дня.
+func (s *BookingService) SaveRestriction(restriction models.StayRestriction) (*models.StayRestriction, error) {
+	if restriction.StartDate.IsZero() || restriction.EndDate.IsZero() {
+		return nil, fmt.Errorf("укажите период ограничения")
+	}
+	restriction.StartDate = time.Date(restriction.StartDate.Year(), restriction.StartDate.Month(), restriction.StartDate.Day(), 0, 0, 0, 0, time.Local)
+	restriction.EndDate = time.Date(restriction.EndDate.Year(), restriction.EndDate.Month(), restriction.EndDate.Day(), 0, 0, 0, 0, time.Local)
+	if restriction.EndDate.Before(restriction.StartDate) {
+		return nil, fmt.Errorf("конец периода раньше начала")
+	}
+	if restriction.MinNights < 0 {
+		return nil, fmt.Errorf("минимальный срок не может быть отрицательным")
+	}
+	if restriction.MinNights <= 1 && !restriction.ClosedToArrival && !restriction.ClosedToDeparture {
+		return nil, fmt.Errorf("укажите минимальный срок или запрет заезда/выезда")
+	}
+
+	var err error
+	if restriction.ID == 0 {
+		err = s.store.StayRestrictions().Create(&restriction)
+	} else {
+		err = s.store.StayRestrictions().Update(restriction)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения ограничения: %w", err)
+	}
+	return &restriction, nil
+}
+
+// DeleteRestriction удаляет ограничение
+func (s *BookingService) DeleteRestriction(restrictionID int) error {
+	if err := s.store.StayRestrictions().Delete(restrictionID); err != nil {
+		return fmt.Errorf("ошибка удаления ограничения: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// addRestriction сохраняет ограничение на дни через first…last дней от
+// сегодняшнего; cottage == nil — для всех домиков
+func (f *fixture) addRestriction(t *testing.T, cottage func(*fixture) int, first, last int, r models.StayRestriction) {
+	t.Helper()
+	if cottage != nil {
+		r.CottageID = cottage(f)
+	}
+	r.StartDate, r.EndDate = day(first), day(last)
+	if _, err := f.bookings.SaveRestriction(r); err != nil {
+		t.Fatalf("save restriction: %v", err)
+	}
+}
+
+func TestCheckRestrictions(t *testing.T) {
+	tests := []struct {
+		name    string
+		cottage func(f *fixture) int
+		in, out int
+		wantErr error
+	}{
+		{"arrival on closed-to-arrival day", cottageA, 10, 12, ErrStayRestricted},
+		{"stay over closed-to-arrival day", cottageA, 9, 11, nil},
+		{"departure on closed-to-arrival day", cottageA, 8, 10, nil},
+		{"departure on closed-to-departure day", cottageA, 18, 20, ErrStayRestricted},
+		{"arrival on closed-to-departure day", cottageA, 20, 22, nil},
+		{"stay over closed-to-departure day", cottageA, 19, 21, nil},
+		{"shorter than minimum of arrival day", cottageA, 30, 32, ErrStayRestricted},
+		{"minimum of arrival day met", cottageA, 30, 33, nil},
+		// Минимум берется по дню заезда, а не по ночам внутри периода
+		{"arrival before minimum period", cottageA, 29, 31, nil},
+		{"arrival on last day of minimum period", cottageA, 32, 34, ErrStayRestricted},
+		{"restriction of other cottage", cottageA, 40, 41, nil},
+		{"restriction of own cottage", cottageB, 40, 41, ErrStayRestricted},
+		// Из общего и собственного минимума домика действует больший
+		{"global minimum", cottageA, 50, 52, nil},
+		{"cottage minimum stricter than global", cottageB, 50, 53, ErrStayRestricted},
+		{"cottage minimum met", cottageB, 50, 54, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forEachStore(t, func(t *testing.T, f *fixture) {
+				f.addRestriction(t, nil, 10, 10, models.StayRestriction{Name: "Заезд закрыт", ClosedToArrival: true})
+				f.addRestriction(t, nil, 20, 20, models.StayRestriction{Name: "Выезд закрыт", ClosedToDeparture: true})
+				f.addRestriction(t, nil, 30, 32, models.StayRestriction{Name: "Праздники", MinNights: 3})
+				f.addRestriction(t, cottageB, 40, 40, models.StayRestriction{ClosedToArrival: true})
+				f.addRestriction(t, nil, 50, 50, models.StayRestriction{MinNights: 2})
+				f.addRestriction(t, cottageB, 50, 50, models.StayRestriction{MinNights: 4})
+
+				_, err := f.book(tt.cottage(f), tt.in, tt.out)
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+			})
+		})
+	}
+}
+
+func TestModifyBookingRestrictions(t *testing.T) {
+	forEachStore(t, func(t *testing.T, f *fixture) {
+		b := f.mustBook(t, f.cottageA, 3, 5)
+		other := &models.Tariff{Name: "Выходные", PricePerDay: models.Rubles(4000)}
+		if err := f.store.Tariffs().Create(other); err != nil {
+			t.Fatalf("create tariff: %v", err)
+		}
+
+		// Правила введены после бронирования: бронь их уже нарушает
+		f.addRestriction(t, nil, 3, 3, models.StayRestriction{MinNights: 5})
+		f.addRestriction(t, nil, 5, 5, models.StayRestriction{ClosedToDeparture: true})
+
+		tests := []struct {
+			name    string
+			mod     models.BookingModification
+			wantErr error
+		}{
+			{"tariff only", models.BookingModification{
+				CottageID: f.cottageA, CheckInDate: day(3), CheckOutDate: day(5), TariffID: other.ID,
+			}, nil},
+			{"headcount only", models.BookingModification{
+				CottageID: f.cottageA, CheckInDate: day(3), CheckOutDate: day(5), TariffID: other.ID, Adults: 2,
+			}, nil},
+			{"new check-out date", models.BookingModification{
+				CottageID: f.cottageA, CheckInDate: day(3), CheckOutDate: day(6), TariffID: other.ID,
+			}, ErrStayRestricted},
+			{"other cottage", models.BookingModification{
+				CottageID: f.cottageB, CheckInDate: day(3), CheckOutDate: day(5), TariffID: other.ID,
+			}, ErrStayRestricted},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := f.bookings.ModifyBooking(b.ID, tt.mod)
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+			})
+		}
+	})
+}
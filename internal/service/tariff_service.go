@@ -3,25 +3,23 @@
 package service
 
 import (
-	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/repository"
 )
 
 type TariffService struct {
-	db *sql.DB
+	store repository.Store
 }
 
-func NewTariffService(db *sql.DB) *TariffService {
-	return &TariffService{db: db}
+func NewTariffService(store repository.Store) *TariffService {
+	return &TariffService{store: store}
 }
 
-func (s *TariffService) CreateTariff(name string, price float64) error {
-	_, err := s.db.Exec(
-		"INSERT INTO lesbaza.tariffs (name, price_per_day) VALUES ($1, $2)",
-		name, price,
-	)
+func (s *TariffService) CreateTariff(name string, price models.Money) error {
+	err := s.store.Tariffs().Create(&models.Tariff{Name: name, PricePerDay: price})
 	if err != nil {
 		return fmt.Errorf("ошибка создания тарифа: %w", err)
 	}
@@ -29,46 +27,31 @@ func (s *TariffService) CreateTariff(name string, price float64) error {
 }
 
 func (s *TariffService) GetTariffs() ([]models.Tariff, error) {
-	rows, err := s.db.Query("SELECT tariff_id, name, price_per_day FROM lesbaza.tariffs ORDER BY name")
+	tariffs, err := s.store.Tariffs().List()
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения тарифов: %w", err)
 	}
-	defer rows.Close()
-
-	var tariffs []models.Tariff
-	for rows.Next() {
-		var t models.Tariff
-		err := rows.Scan(&t.ID, &t.Name, &t.PricePerDay)
-		if err != nil {
-			return nil, fmt.Errorf("ошибка сканирования тарифа: %w", err)
-		}
-		tariffs = append(tariffs, t)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("ошибка при обработке строк: %w", err)
-	}
-
 	return tariffs, nil
 }
 
 func (s *TariffService) GetTariffByID(tariffID int) (*models.Tariff, error) {
-	row := s.db.QueryRow("SELECT tariff_id, name, price_per_day FROM lesbaza.tariffs WHERE tariff_id = $1", tariffID)
-
-	var t models.Tariff
-	err := row.Scan(&t.ID, &t.Name, &t.PricePerDay)
+	t, err := s.store.Tariffs().GetByID(tariffID)
 	if err != nil {
 		return nil, fmt.Errorf("тариф с ID %d не найден: %w", tariffID, err)
 	}
 
-	return &t, nil
+	return t, nil
 }
 
-func (s *TariffService) UpdateTariff(tariffID int, name string, price float64) error {
-	_, err := s.db.Exec(
-		"UPDATE lesbaza.tariffs SET name = $1, price_per_day = $2 WHERE tariff_id = $3",
-		name, price, tariffID,
-	)
+func (s *TariffService) UpdateTariff(tariffID int, name string, price models.Money) error {
+	tariff, err := s.GetTariffByID(tariffID)
+	if err != nil {
+		return err
+	}
+	tariff.Name = name
+	tariff.PricePerDay = price
+
+	err = s.store.Tariffs().Update(*tariff)
 	if err != nil {
 		return fmt.Errorf("ошибка обновления тарифа: %w", err)
 	}
@@ -77,8 +60,7 @@ func (s *TariffService) UpdateTariff(tariffID int, name string, price float64) e
 
 func (s *TariffService) DeleteTariff(tariffID int) error {
 	// Проверяем, не используется ли тариф гостями
-	var count int
-	err := s.db.QueryRow("SELECT COUNT(*) FROM lesbaza.guests WHERE tariff_id = $1", tariffID).Scan(&count)
+	count, err := s.store.Guests().CountByTariff(tariffID)
 	if err != nil {
 		return fmt.Errorf("ошибка проверки использования тарифа: %w", err)
 	}
@@ -87,10 +69,144 @@ func (s *TariffService) DeleteTariff(tariffID int) error {
 		return fmt.Errorf("нельзя удалить тариф: он используется %d гостями", count)
 	}
 
-	_, err = s.db.Exec("DELETE FROM lesbaza.tariffs WHERE tariff_id = $1", tariffID)
+	err = s.store.Tariffs().Delete(tariffID)
 	if err != nil {
 		return fmt.Errorf("ошибка удаления тарифа: %w", err)
 	}
 	return nil
 
 }
+
+// SetSurcharges задает наценки тарифа в процентах на ночи выходных и праздников
+func (s *TariffService) SetSurcharges(tariffID int, weekend, holiday float64) error {
+	if weekend < 0 || holiday < 0 {
+		return fmt.Errorf("наценка не может быть отрицательной")
+	}
+
+	tariff, err := s.GetTariffByID(tariffID)
+	if err != nil {
+		return err
+	}
+	tariff.WeekendSurcharge = weekend
+	tariff.HolidaySurcharge = holiday
+
+	if err := s.store.Tariffs().Update(*tariff); err != nil {
+		return fmt.Errorf("ошибка обновления наценок: %w", err)
+	}
+	return nil
+}
+
+// SetGuestFees задает, сколько гостей входит в цену ночи тарифа (0 — все),
+// и доплату за ночь за каждого взрослого и ребенка сверх них
+func (s *TariffService) SetGuestFees(tariffID, baseGuests int, extraAdult, extraChild models.Money) error {
+	if baseGuests < 0 {
+		return fmt.Errorf("число гостей в цене не может быть отрицательным")
+	}
+	if extraAdult.IsNegative() || extraChild.IsNegative() {
+		return fmt.Errorf("доплата не может быть отрицательной")
+	}
+
+	tariff, err := s.GetTariffByID(tariffID)
+	if err != nil {
+		return err
+	}
+	tariff.BaseGuests = baseGuests
+	tariff.ExtraAdultPrice = extraAdult
+	tariff.ExtraChildPrice = extraChild
+
+	if err := s.store.Tariffs().Update(*tariff); err != nil {
+		return fmt.Errorf("ошибка обновления доплаты за гостей: %w", err)
+	}
+	return nil
+}
+
+// GetRates возвращает сезонные цены и цены домиков тарифа
+func (s *TariffService) GetRates(tariffID int) ([]models.TariffRate, error) {
+	rates, err := s.store.TariffRates().ListByTariff(tariffID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения цен тарифа: %w", err)
+	}
+	return rates, nil
+}
+
+// SaveRate сохраняет цену тарифа: новую (rate.ID == 0) или измененную.
+// Даты сезона задаются обе или ни одной.
+func (s *TariffService) SaveRate(rate models.TariffRate) (*models.TariffRate, error) {
+	if !rate.PricePerDay.IsPositive() {
+		return nil, fmt.Errorf("цена за сутки должна быть больше нуля")
+	}
+	if rate.StartDate.IsZero() != rate.EndDate.IsZero() {
+		return nil, fmt.Errorf("укажите обе даты сезона или ни одной")
+	}
+	if rate.Seasonal() {
+		rate.StartDate = time.Date(rate.StartDate.Year(), rate.StartDate.Month(), rate.StartDate.Day(), 0, 0, 0, 0, time.Local)
+		rate.EndDate = time.Date(rate.EndDate.Year(), rate.EndDate.Month(), rate.EndDate.Day(), 0, 0, 0, 0, time.Local)
+		if rate.EndDate.Before(rate.StartDate) {
+			return nil, fmt.Errorf("сезон не может закончиться раньше, чем начался")
+		}
+	}
+	if !rate.Seasonal() && rate.CottageID == 0 {
+		return nil, fmt.Errorf("цена без сезона задается для домика; общая цена — это цена самого тарифа")
+	}
+
+	if rate.ID == 0 {
+		if _, err := s.GetTariffByID(rate.TariffID); err != nil {
+			return nil, err
+		}
+		if err := s.store.TariffRates().Create(&rate); err != nil {
+			return nil, fmt.Errorf("ошибка сохранения цены: %w", err)
+		}
+		return &rate, nil
+	}
+
+	if err := s.store.TariffRates().Update(rate); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения цены: %w", err)
+	}
+	return &rate, nil
+}
+
+// DeleteRate удаляет цену тарифа
+func (s *TariffService) DeleteRate(rateID int) error {
+	if err := s.store.TariffRates().Delete(rateID); err != nil {
+		return fmt.Errorf("ошибка удаления цены: %w", err)
+	}
+	return nil
+}
+
+// GetHolidays возвращает праздники года year
+func (s *TariffService) GetHolidays(year int) ([]models.Holiday, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local)
+	holidays, err := s.store.Holidays().ListBetween(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения праздников: %w", err)
+	}
+	return holidays, nil
+}
+
+// AddHoliday отмечает день праздником
+func (s *TariffService) AddHoliday(date time.Time, name string) (*models.Holiday, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+
+	existing, err := s.store.Holidays().ListBetween(day, day)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения праздников: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("%s уже отмечен праздником", day.Format("02.01.2006"))
+	}
+
+	holiday := models.Holiday{Date: day, Name: name}
+	if err := s.store.Holidays().Create(&holiday); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения праздника: %w", err)
+	}
+	return &holiday, nil
+}
+
+// DeleteHoliday снимает отметку праздника
+func (s *TariffService) DeleteHoliday(holidayID int) error {
+	if err := s.store.Holidays().Delete(holidayID); err != nil {
+		return fmt.Errorf("ошибка удаления праздника: %w", err)
+	}
+	return nil
+}
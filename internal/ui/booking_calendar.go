@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"image/color"
 	"os"
@@ -19,8 +20,17 @@ import (
 
 var (
 	GreenColor = color.NRGBA{R: 40, G: 167, B: 69, A: 255}
+	// HoldColor — оттенок ячеек с временным удержанием домика
+	HoldColor = color.NRGBA{R: 111, G: 66, B: 193, A: 150}
+	// BlockedColor — оттенок ячеек домика, закрытого на обслуживание
+	BlockedColor = color.NRGBA{R: 108, G: 117, B: 125, A: 200}
+	// RestrictedColor — оттенок свободных дней с ограничениями сроков
+	RestrictedColor = color.NRGBA{R: 255, G: 193, B: 7, A: 110}
 )
 
+// holdDuration — на сколько удерживается домик кнопкой "Удержать"
+const holdDuration = 15 * time.Minute
+
 // BookingCalendar представляет календарный виджет для бронирования
 type BookingCalendar struct {
 	widget.BaseWidget
@@ -28,10 +38,12 @@ type BookingCalendar struct {
 	bookingService *service.BookingService
 	cottageService *service.CottageService
 	tariffService  *service.TariffService
+	invoiceService *service.InvoiceService
 
 	currentMonth time.Time
 	calendarData map[time.Time]map[int]models.BookingStatus
 	cottages     []models.Cottage
+	restrictions []models.StayRestriction
 
 	window    fyne.Window
 	onRefresh func()
@@ -39,39 +51,19 @@ type BookingCalendar struct {
 	// UI элементы
 	monthLabel   *widget.Label
 	calendarGrid *fyne.Container
+	dragLabel    *widget.Label
+
+	// Перетаскивание броней
+	cells []*calendarCell
+	drag  *calendarDrag
+	// selection — выбираемый период новой брони
+	selection *calendarSelection
 
 	// Кэш изображений
 	imageCache map[string]*canvas.Image
 	imagesPath string
 }
 
-func getProjectPath() string {
-	// Получаем путь к исполняемому файлу
-	execPath, err := os.Executable()
-	if err != nil {
-		// Если не удалось получить путь, используем фиксированный
-		return "C:\\Users\\VallfIK\\Documents\\GitHub\\bazaotdx"
-	}
-
-	// Поднимаемся вверх по директориям, пока не найдем go.mod
-	dir := filepath.Dir(execPath)
-	for {
-		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
-			return dir
-		}
-
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			// Достигли корня файловой системы
-			break
-		}
-		dir = parent
-	}
-
-	// Если не нашли go.mod, используем фиксированный путь
-	return "C:\\Users\\VallfIK\\Documents\\GitHub\\bazaotdx"
-}
-
 // SetOnRefresh устанавливает callback для обновления
 func (bc *BookingCalendar) SetOnRefresh(f func()) {
 	bc.onRefresh = f
@@ -86,11 +78,14 @@ func (bc *BookingCalendar) Update() {
 	}
 }
 
-// ClickableImage - изображение которое можно кликать
+// ClickableImage - изображение которое можно кликать и перетаскивать
 type ClickableImage struct {
 	widget.BaseWidget
 	image    *canvas.Image
 	onTapped func()
+
+	onDragged func(ev *fyne.DragEvent)
+	onDragEnd func()
 }
 
 func NewClickableImage(imagePath string, onTapped func()) *ClickableImage {
@@ -117,24 +112,36 @@ func (ci *ClickableImage) Tapped(_ *fyne.PointEvent) {
 
 func (ci *ClickableImage) TappedSecondary(_ *fyne.PointEvent) {}
 
+func (ci *ClickableImage) Dragged(ev *fyne.DragEvent) {
+	if ci.onDragged != nil {
+		ci.onDragged(ev)
+	}
+}
+
+func (ci *ClickableImage) DragEnd() {
+	if ci.onDragEnd != nil {
+		ci.onDragEnd()
+	}
+}
+
 // NewBookingCalendar создает новый календарь бронирования
 func NewBookingCalendar(
 	bookingService *service.BookingService,
 	cottageService *service.CottageService,
 	tariffService *service.TariffService,
+	invoiceService *service.InvoiceService,
 	window fyne.Window,
+	imagesPath string,
 ) *BookingCalendar {
-	projectPath := getProjectPath()
-	imagesPath := filepath.Join(projectPath, "images")
-
 	bc := &BookingCalendar{
 		bookingService: bookingService,
 		cottageService: cottageService,
 		tariffService:  tariffService,
+		invoiceService: invoiceService,
 		currentMonth:   time.Now().Local(),
 		window:         window,
 		imageCache:     make(map[string]*canvas.Image),
-		imagesPath:     imagesPath, // Используем абсолютный путь
+		imagesPath:     imagesPath,
 	}
 
 	bc.ExtendBaseWidget(bc)
@@ -174,7 +181,7 @@ func (bc *BookingCalendar) getStatusImage(status models.BookingStatus) string {
 	}
 
 	switch status.Status {
-	case models.BookingStatusBooked:
+	case models.BookingStatusBooked, models.BookingStatusTemporary:
 		return filepath.Join(bc.imagesPath, "booked.png")
 	case models.BookingStatusCheckedIn:
 		return filepath.Join(bc.imagesPath, "bought.png")
@@ -188,7 +195,7 @@ func (bc *BookingCalendar) getDiagonalImages(topStatus, bottomStatus string) (st
 	// Верхняя часть (заезд после 14:00)
 	var topImage string
 	switch topStatus {
-	case models.BookingStatusBooked:
+	case models.BookingStatusBooked, models.BookingStatusTemporary, models.BookingStatusBlocked:
 		topImage = filepath.Join(bc.imagesPath, "bookedfirst.png")
 	case models.BookingStatusCheckedIn:
 		topImage = filepath.Join(bc.imagesPath, "boughtfirst.png")
@@ -199,7 +206,7 @@ func (bc *BookingCalendar) getDiagonalImages(topStatus, bottomStatus string) (st
 	// Нижняя часть (выезд до 12:00)
 	var bottomImage string
 	switch bottomStatus {
-	case models.BookingStatusBooked:
+	case models.BookingStatusBooked, models.BookingStatusTemporary, models.BookingStatusBlocked:
 		bottomImage = filepath.Join(bc.imagesPath, "bookedlast.png")
 	case models.BookingStatusCheckedIn:
 		bottomImage = filepath.Join(bc.imagesPath, "boughtlast.png")
@@ -210,6 +217,27 @@ func (bc *BookingCalendar) getDiagonalImages(topStatus, bottomStatus string) (st
 	return topImage, bottomImage
 }
 
+// statusTint возвращает цвет, которым поверх изображения выделяется
+// ячейка со статусом, или nil, если выделять не нужно
+func (bc *BookingCalendar) statusTint(status string) color.Color {
+	switch status {
+	case models.BookingStatusTemporary:
+		return HoldColor
+	case models.BookingStatusBlocked:
+		return BlockedColor
+	default:
+		return nil
+	}
+}
+
+// cellName возвращает подпись брони в ячейке: имя гостя или причину блокировки
+func (bc *BookingCalendar) cellName(booking *models.Booking, maxLen int) string {
+	if booking.Status == models.BookingStatusBlocked {
+		return models.BlockReasonTitle(booking.BlockReason)
+	}
+	return bc.truncateString(booking.GuestName, maxLen)
+}
+
 func (bc *BookingCalendar) hasAnyBookingOnDate(cottageID int, date time.Time) bool {
 	checkoutBooking := bc.findCheckoutBooking(cottageID, date)
 	checkinBooking := bc.findCheckinBooking(cottageID, date)
@@ -235,6 +263,12 @@ func (bc *BookingCalendar) loadData() error {
 	}
 	bc.calendarData = calendarData
 
+	restrictions, err := bc.bookingService.GetRestrictions(startDate, endDate)
+	if err != nil {
+		return fmt.Errorf("error loading restrictions: %v", err)
+	}
+	bc.restrictions = restrictions
+
 	return nil
 }
 
@@ -277,9 +311,14 @@ func (bc *BookingCalendar) CreateRenderer() fyne.WidgetRenderer {
 	// Легенда
 	legend := bc.createLegend()
 
+	// Предпросмотр переноса брони при перетаскивании
+	bc.dragLabel = widget.NewLabel("")
+	bc.dragLabel.TextStyle = fyne.TextStyle{Bold: true}
+	bc.dragLabel.Hide()
+
 	// Основной контейнер
 	content := container.NewBorder(
-		container.NewVBox(header, legend),
+		container.NewVBox(header, legend, bc.dragLabel),
 		nil, nil, nil,
 		container.NewScroll(bc.calendarGrid),
 	)
@@ -314,6 +353,7 @@ func (bc *BookingCalendar) createCalendarGrid() *fyne.Container {
 
 	// Создаем основной контейнер
 	mainContainer := container.NewVBox()
+	bc.cells = nil
 
 	// Создаем заголовок с днями недели
 	daysHeader := container.NewGridWithColumns(daysToShow + 1)
@@ -394,9 +434,9 @@ func (bc *BookingCalendar) createCalendarCell(cottageID int, date time.Time) fyn
 	needsDiagonal := hasCheckOut || hasCheckIn
 
 	if needsDiagonal {
-		return bc.createDiagonalCell(cottageID, date, status)
+		return bc.wrapDropCell(cottageID, date, bc.createDiagonalCell(cottageID, date, status))
 	} else {
-		return bc.createRegularCell(cottageID, date, status)
+		return bc.wrapDropCell(cottageID, date, bc.createRegularCell(cottageID, date, status))
 	}
 }
 
@@ -465,16 +505,16 @@ func (bc *BookingCalendar) createDiagonalCell(cottageID int, date time.Time, sta
 	// Формируем текст
 	if checkinBooking != nil && checkoutBooking != nil {
 		// И заезд и выезд в один день
-		checkoutGuest := bc.truncateString(checkoutBooking.GuestName, 6)
-		checkinGuest := bc.truncateString(checkinBooking.GuestName, 6)
+		checkoutGuest := bc.cellName(checkoutBooking, 6)
+		checkinGuest := bc.cellName(checkinBooking, 6)
 		text = fmt.Sprintf("←%s\n%s→", checkoutGuest, checkinGuest)
 	} else if checkoutBooking != nil {
 		// Только выезд
-		checkoutGuest := bc.truncateString(checkoutBooking.GuestName, 6)
+		checkoutGuest := bc.cellName(checkoutBooking, 6)
 		text = fmt.Sprintf("←%s\n14:00→", checkoutGuest)
 	} else if checkinBooking != nil {
 		// Только заезд
-		checkinGuest := bc.truncateString(checkinBooking.GuestName, 6)
+		checkinGuest := bc.cellName(checkinBooking, 6)
 		text = fmt.Sprintf("←12:00\n%s→", checkinGuest)
 	} else {
 		// Свободный день (не должно происходить для диагональной кнопки)
@@ -491,7 +531,7 @@ func (bc *BookingCalendar) createDiagonalCell(cottageID int, date time.Time, sta
 				bc.showBookingDetails(checkinBooking.ID)
 			} else {
 				// Проверяем, свободно ли время для заезда
-				checkInTime := time.Date(date.Year(), date.Month(), date.Day(), 14, 0, 0, 0, time.Local)
+				checkInTime := bc.bookingService.StayPolicy().CheckInTime(date)
 				// Проверяем что нет брони которая начинается в этот день после 14:00
 				available, err := bc.bookingService.IsCottageAvailable(cottageID, checkInTime, checkInTime.AddDate(0, 0, 1))
 				if err != nil {
@@ -514,6 +554,21 @@ func (bc *BookingCalendar) createDiagonalCell(cottageID int, date time.Time, sta
 			}
 		})
 
+	// Верхнюю часть тянут, чтобы перенести заезжающую бронь,
+	// нижнюю — чтобы сдвинуть день выезда
+	button.onDragged = func(top bool, ev *fyne.DragEvent) {
+		switch {
+		case top && checkinBooking != nil:
+			bc.onBookingDragged(checkinBooking.ID, dragMove, date, ev)
+		case !top && checkoutBooking != nil:
+			bc.onBookingDragged(checkoutBooking.ID, dragResize, date, ev)
+		case top:
+			// Свободный заезд: выбор периода новой брони
+			bc.onRangeDragged(cottageID, date, ev)
+		}
+	}
+	button.onDragEnd = bc.onCellDragEnd
+
 	button.Resize(fyne.NewSize(35, 60))
 	return button
 }
@@ -523,17 +578,30 @@ func (bc *BookingCalendar) createRegularCell(cottageID int, date time.Time, stat
 	var imagePath string
 	var text string
 
+	var tint color.Color
+
 	if status != nil && status.BookingID > 0 {
 		imagePath = bc.getStatusImage(*status)
+		tint = bc.statusTint(status.Status)
 		if status.IsCheckIn {
 			text = "→ " + bc.truncateString(status.GuestName, 6)
 		} else {
 			text = bc.truncateString(status.GuestName, 8)
 		}
+		switch status.Status {
+		case models.BookingStatusTemporary:
+			text = "⏳ " + text
+		case models.BookingStatusBlocked:
+			text = models.BlockReasonTitle(status.BlockReason)
+		}
 	} else {
 		// Свободный день
 		imagePath = filepath.Join(bc.imagesPath, "free.png")
 		text = "+"
+		if r := models.RestrictionsOn(bc.restrictions, cottageID, date); r.Any() {
+			tint = RestrictedColor
+			text = restrictionMarks(r)
+		}
 	}
 
 	// Создаем кликабельное изображение
@@ -545,15 +613,28 @@ func (bc *BookingCalendar) createRegularCell(cottageID int, date time.Time, stat
 		}
 	})
 
+	if status != nil && status.BookingID > 0 {
+		bookingID := status.BookingID
+		clickableImg.onDragged = func(ev *fyne.DragEvent) {
+			bc.onBookingDragged(bookingID, dragMove, date, ev)
+		}
+	} else {
+		clickableImg.onDragged = func(ev *fyne.DragEvent) {
+			bc.onRangeDragged(cottageID, date, ev)
+		}
+	}
+	clickableImg.onDragEnd = bc.onCellDragEnd
+
 	// Добавляем текст поверх изображения
 	label := canvas.NewText(text, color.White)
 	label.TextSize = 10
 	label.Alignment = fyne.TextAlignCenter
 
-	content := container.NewStack(
-		clickableImg,
-		container.NewCenter(label),
-	)
+	content := container.NewStack(clickableImg)
+	if tint != nil {
+		content.Add(canvas.NewRectangle(tint))
+	}
+	content.Add(container.NewCenter(label))
 
 	content.Resize(fyne.NewSize(35, 60))
 	return content
@@ -568,6 +649,12 @@ type DiagonalImageButton struct {
 	leftTapped  func()
 	rightTapped func()
 	text        string
+
+	// onDragged получает признак, что перетаскивание начато с верхней части
+	onDragged func(top bool, ev *fyne.DragEvent)
+	onDragEnd func()
+	dragging  bool
+	dragTop   bool
 }
 
 // NewDiagonalImageButton создает новую кнопку с диагональными изображениями
@@ -583,6 +670,13 @@ func NewDiagonalImageButton(topImage, bottomImage string, text string, leftTappe
 	return db
 }
 
+// isTopPart сообщает, лежит ли точка выше диагонали (часть заезда)
+func (db *DiagonalImageButton) isTopPart(pos fyne.Position) bool {
+	size := db.Size()
+	k := size.Height / size.Width
+	return pos.Y < pos.X*k
+}
+
 func (db *DiagonalImageButton) Tapped(evt *fyne.PointEvent) {
 	size := db.Size()
 	if size.Width == 0 || size.Height == 0 {
@@ -590,10 +684,7 @@ func (db *DiagonalImageButton) Tapped(evt *fyne.PointEvent) {
 	}
 
 	// Вычисляем, на какую часть кнопки кликнули
-	k := size.Height / size.Width
-	expectedY := evt.Position.X * k
-
-	if evt.Position.Y < expectedY {
+	if db.isTopPart(evt.Position) {
 		// Клик на верхнюю часть
 		if db.leftTapped != nil {
 			db.leftTapped()
@@ -608,6 +699,25 @@ func (db *DiagonalImageButton) Tapped(evt *fyne.PointEvent) {
 
 func (db *DiagonalImageButton) TappedSecondary(_ *fyne.PointEvent) {}
 
+func (db *DiagonalImageButton) Dragged(ev *fyne.DragEvent) {
+	if db.onDragged == nil || db.Size().Width == 0 {
+		return
+	}
+	if !db.dragging {
+		// Часть определяется по точке, с которой начали тянуть
+		db.dragging = true
+		db.dragTop = db.isTopPart(ev.Position.Subtract(ev.Dragged))
+	}
+	db.onDragged(db.dragTop, ev)
+}
+
+func (db *DiagonalImageButton) DragEnd() {
+	db.dragging = false
+	if db.onDragEnd != nil {
+		db.onDragEnd()
+	}
+}
+
 func (db *DiagonalImageButton) CreateRenderer() fyne.WidgetRenderer {
 	return &diagonalImageButtonRenderer{
 		base: db,
@@ -757,11 +867,20 @@ func (bc *BookingCalendar) onCellTapped(cottageID int, date time.Time, status mo
 		// Показываем информацию о брони
 		bc.showBookingDetails(status.BookingID)
 	} else {
-		// Открываем форму быстрого бронирования
-		bc.showQuickBookingForm(cottageID, date)
+		// Открываем форму быстрого бронирования (с Shift — выбираем период)
+		bc.onFreeCellTapped(cottageID, date)
 	}
 }
 
+// onCellDragEnd завершает перетаскивание брони или выбор периода
+func (bc *BookingCalendar) onCellDragEnd() {
+	if bc.drag != nil {
+		bc.onBookingDragEnd()
+		return
+	}
+	bc.finishSelection()
+}
+
 // showBookingDetails показывает детали брони
 func (bc *BookingCalendar) showBookingDetails(bookingID int) {
 	booking, err := bc.bookingService.GetBookingByID(bookingID)
@@ -779,6 +898,12 @@ func (bc *BookingCalendar) showBookingDetails(bookingID int) {
 		}
 	}
 
+	if booking.Status == models.BookingStatusBlocked {
+		bc.showBlockDetails(booking, cottageName)
+		return
+	}
+
+	costLabel := widget.NewLabel("Стоимость: " + formatBookingCost(*booking))
 	content := container.NewVBox(
 		widget.NewCard("Информация о брони", "",
 			container.NewVBox(
@@ -786,18 +911,53 @@ func (bc *BookingCalendar) showBookingDetails(bookingID int) {
 				widget.NewLabel(fmt.Sprintf("Телефон: %s", booking.Phone)),
 				widget.NewLabel(fmt.Sprintf("Email: %s", booking.Email)),
 				widget.NewLabel(fmt.Sprintf("Домик: %s", cottageName)),
+				widget.NewLabel(fmt.Sprintf("Гостей: %s", formatGuests(*booking))),
 				widget.NewLabel(fmt.Sprintf("Заезд: %s", booking.CheckInDate.Format("02.01.2006"))),
 				widget.NewLabel(fmt.Sprintf("Выезд: %s", booking.CheckOutDate.Format("02.01.2006"))),
 				widget.NewLabel(fmt.Sprintf("Статус: %s", bc.getStatusText(booking.Status))),
-				widget.NewLabel(fmt.Sprintf("Стоимость: %.2f руб.", booking.TotalCost)),
+				costLabel,
 			),
 		),
 	)
 
+	// Карточка оплат пересоздается, когда меняется фолио брони
+	payments := container.NewStack()
+	if booking.Status == models.BookingStatusTemporary {
+		content.Add(widget.NewLabel(fmt.Sprintf("⏳ Домик удерживается до %s", booking.HoldExpiresAt.Format("15:04 02.01.2006"))))
+	} else {
+		payments.Add(newPaymentsCard(bc.bookingService, booking, bc.window, bc.Update))
+		content.Add(payments)
+	}
+	if booking.Status == models.BookingStatusCheckedIn || booking.Status == models.BookingStatusCompleted {
+		content.Add(newInvoicesCard(bc.invoiceService, booking, bc.window))
+	}
+
+	if booking.GroupID != 0 {
+		group, err := bc.bookingService.GetBookingGroup(booking.GroupID)
+		if err != nil {
+			dialog.ShowError(err, bc.window)
+		} else {
+			content.Add(newGroupCard(group, bc.cottages))
+			content.Add(newGroupActions(bc.bookingService, group, bc.window, bc.Update))
+		}
+	}
+
 	// Кнопки действий в зависимости от статуса
 	actions := container.NewHBox()
 
 	switch booking.Status {
+	case models.BookingStatusTemporary:
+		actions.Add(widget.NewButton("Подтвердить бронь", func() {
+			bc.showConfirmHoldForm(booking, cottageName)
+		}))
+		actions.Add(widget.NewButton("Снять удержание", func() {
+			if err := bc.bookingService.ReleaseHold(booking.ID); err != nil {
+				dialog.ShowError(err, bc.window)
+				return
+			}
+			bc.Update()
+			dialog.ShowInformation("Успешно", "Удержание снято, домик свободен", bc.window)
+		}))
 	case models.BookingStatusBooked:
 		actions.Add(widget.NewButton("Заселить", func() {
 			err := bc.bookingService.CheckInBooking(booking.ID)
@@ -809,17 +969,10 @@ func (bc *BookingCalendar) showBookingDetails(bookingID int) {
 			dialog.ShowInformation("Успешно", "Гость заселен", bc.window)
 		}))
 		actions.Add(widget.NewButton("Отменить", func() {
-			dialog.ShowConfirm("Подтверждение", "Отменить бронирование?", func(ok bool) {
-				if ok {
-					err := bc.bookingService.CancelBooking(booking.ID)
-					if err != nil {
-						dialog.ShowError(err, bc.window)
-						return
-					}
-					bc.Update()
-					dialog.ShowInformation("Успешно", "Бронирование отменено", bc.window)
-				}
-			}, bc.window)
+			confirmCancelBooking(bc.bookingService, booking.ID, bc.window, bc.Update)
+		}))
+		actions.Add(widget.NewButton("Изменить", func() {
+			showModifyBookingDialog(bc.bookingService, bc.tariffService, bc.cottages, booking, bc.window, bc.Update)
 		}))
 	case models.BookingStatusCheckedIn:
 		actions.Add(widget.NewButton("Выселить", func() {
@@ -833,7 +986,7 @@ func (bc *BookingCalendar) showBookingDetails(bookingID int) {
 							return
 						}
 						bc.Update()
-						dialog.ShowInformation("Успешно", "Гость выселен", bc.window)
+						issueInvoice(bc.invoiceService, booking.ID, "Гость выселен", bc.window, nil)
 					}
 				}, bc.window)
 		}))
@@ -842,17 +995,45 @@ func (bc *BookingCalendar) showBookingDetails(bookingID int) {
 		actions.Add(widget.NewButton("Ранний выезд", func() {
 			bc.showEarlyCheckoutDialog(booking, cottageName)
 		}))
+		actions.Add(widget.NewButton("Изменить даты", func() {
+			showModifyBookingDialog(bc.bookingService, bc.tariffService, bc.cottages, booking, bc.window, bc.Update)
+		}))
 	}
 
 	content.Add(actions)
 
-	d := dialog.NewCustom("Детали бронирования", "Закрыть", content, bc.window)
-	d.Resize(fyne.NewSize(400, 400))
+	var body fyne.CanvasObject = container.NewVScroll(content)
+	if booking.Status != models.BookingStatusTemporary {
+		folio := newFolioCard(bc.bookingService, bc.tariffService, booking, bc.window, func() {
+			bc.Update()
+			updated, err := bc.bookingService.GetBookingByID(booking.ID)
+			if err != nil {
+				dialog.ShowError(err, bc.window)
+				return
+			}
+			costLabel.SetText("Стоимость: " + formatBookingCost(*updated))
+			payments.Objects = []fyne.CanvasObject{newPaymentsCard(bc.bookingService, updated, bc.window, bc.Update)}
+			payments.Refresh()
+		})
+		body = container.NewAppTabs(
+			container.NewTabItem("📋 Бронь", body),
+			container.NewTabItem("🛎 Услуги", container.NewVScroll(folio)),
+		)
+	}
+
+	d := dialog.NewCustom("Детали бронирования", "Закрыть", body, bc.window)
+	d.Resize(fyne.NewSize(450, 550))
 	d.Show()
 }
 
-// showQuickBookingForm показывает форму быстрого бронирования
+// showQuickBookingForm показывает форму быстрого бронирования на одну ночь
 func (bc *BookingCalendar) showQuickBookingForm(cottageID int, startDateTime time.Time) {
+	bc.showBookingForm(cottageID, startDateTime, time.Time{})
+}
+
+// showBookingForm показывает форму бронирования с заполненными датами.
+// Нулевая дата выезда означает одну ночь.
+func (bc *BookingCalendar) showBookingForm(cottageID int, startDateTime, endDateTime time.Time) {
 	// Получаем информацию о домике
 	var cottage models.Cottage
 	for _, c := range bc.cottages {
@@ -877,7 +1058,7 @@ func (bc *BookingCalendar) showQuickBookingForm(cottageID int, startDateTime tim
 	// Создаем список тарифов для выпадающего списка
 	tariffOptions := make([]string, len(tariffs))
 	for i, tariff := range tariffs {
-		tariffOptions[i] = fmt.Sprintf("%s - %.2f руб./сутки", tariff.Name, tariff.PricePerDay)
+		tariffOptions[i] = fmt.Sprintf("%s - %s/сутки", tariff.Name, tariff.PricePerDay)
 	}
 
 	// Поля формы
@@ -898,45 +1079,57 @@ func (bc *BookingCalendar) showQuickBookingForm(cottageID int, startDateTime tim
 	tariffSelect := widget.NewSelect(tariffOptions, nil)
 	tariffSelect.SetSelected(tariffOptions[0]) // Выбираем первый тариф по умолчанию
 
-	// Устанавливаем время заезда
-	var checkInDate time.Time
-	if startDateTime.Hour() == 0 && startDateTime.Minute() == 0 {
-		// Если передана только дата, устанавливаем время 14:00
-		checkInDate = time.Date(startDateTime.Year(), startDateTime.Month(), startDateTime.Day(), 14, 0, 0, 0, time.Local)
-	} else {
-		// Используем переданное время
-		checkInDate = startDateTime
+	// Состав гостей; стоимость пересчитывается при его изменении
+	var updateCost func()
+	guests := NewGuestCountPicker(1, 0, func() { updateCost() })
+
+	// Время заезда и выезда — по расчетному часу
+	if endDateTime.IsZero() {
+		endDateTime = startDateTime.AddDate(0, 0, 1)
 	}
+	checkInDate, checkOutDate := bc.bookingService.StayPolicy().Normalize(startDateTime, endDateTime)
 
-	checkOutDate := checkInDate.AddDate(0, 0, 1)
-	checkOutDate = time.Date(checkOutDate.Year(), checkOutDate.Month(), checkOutDate.Day(), 12, 0, 0, 0, time.Local)
+	// Промокод; скидка пересчитывается при вводе
+	promoEntry := widget.NewEntry()
+	promoEntry.PlaceHolder = "Необязательно"
+	promoEntry.OnChanged = func(string) { updateCost() }
 
 	// Расчет стоимости
 	costLabel := widget.NewLabel("Стоимость: -")
+	breakdownLabel := widget.NewLabel("")
+	discountLabel := widget.NewLabel("")
 	advanceLabel := widget.NewLabel("")
 	remainingLabel := widget.NewLabel("")
 
-	updateCost := func() {
+	updateCost = func() {
 		if tariffSelect.SelectedIndex() >= 0 {
-			// Use only the date part without time
-			checkInDateOnly := time.Date(checkInDate.Year(), checkInDate.Month(), checkInDate.Day(), 0, 0, 0, 0, time.Local)
-			checkOutDateOnly := time.Date(checkOutDate.Year(), checkOutDate.Month(), checkOutDate.Day(), 0, 0, 0, 0, time.Local)
-
-			// Calculate days as the difference between dates
-			days := int(checkOutDateOnly.Sub(checkInDateOnly).Hours()/24) + 1
-			if days <= 0 {
-				days = 1
+			// Стоимость и разбивку по ночам считает движок цен
+			quote, err := bc.bookingService.QuoteBooking(tariffs[tariffSelect.SelectedIndex()].ID, cottageID,
+				guests.Adults(), guests.Children(), checkInDate, checkOutDate)
+			if err != nil {
+				costLabel.SetText("Стоимость: -")
+				breakdownLabel.SetText("")
+				discountLabel.SetText("")
+				return
+			}
+
+			// Неподходящий промокод не мешает расчету: причина видна в строке скидки
+			discountLabel.SetText("")
+			if err := bc.bookingService.ApplyDiscountCode(quote, promoEntry.Text); err != nil {
+				discountLabel.SetText("❌ " + err.Error())
+			} else if quote.Discount.IsPositive() {
+				discountLabel.SetText(fmt.Sprintf("Скидка по промокоду %s: −%s (без скидки %s)",
+					quote.DiscountCode, quote.Discount, quote.Total))
 			}
 
-			// Calculate costs
-			totalCost := float64(days) * tariffs[tariffSelect.SelectedIndex()].PricePerDay
-			advancePayment := totalCost * 0.3 // 30% предоплата
-			remainingAmount := totalCost - advancePayment
+			totalCost := quote.Due()
+			advancePayment := totalCost.Percent(models.DefaultDepositPercent)
+			remainingAmount := totalCost.Sub(advancePayment)
 
-			// Create cost labels
-			costLabel.SetText(fmt.Sprintf("Стоимость: %.2f руб. (%d дней)", totalCost, days))
-			advanceLabel.SetText(fmt.Sprintf("Предоплата (30%%): %.2f руб.", advancePayment))
-			remainingLabel.SetText(fmt.Sprintf("Остаток: %.2f руб.", remainingAmount))
+			costLabel.SetText(fmt.Sprintf("Стоимость: %s (ночей: %d)", totalCost, len(quote.Nights)))
+			breakdownLabel.SetText(FormatPriceQuote(quote))
+			advanceLabel.SetText(fmt.Sprintf("Предоплата (%d%%): %s", models.DefaultDepositPercent, advancePayment))
+			remainingLabel.SetText(fmt.Sprintf("Остаток: %s", remainingAmount))
 		}
 	}
 
@@ -970,14 +1163,18 @@ func (bc *BookingCalendar) showQuickBookingForm(cottageID int, startDateTime tim
 	// Форма
 	form := &widget.Form{
 		Items: []*widget.FormItem{
-			{Text: "Домик", Widget: widget.NewLabel(cottage.Name)},
+			{Text: "Домик", Widget: widget.NewLabel(fmt.Sprintf("%s (%s)", cottage.Name, cottage.CapacityTitle()))},
 			{Text: "ФИО *", Widget: nameEntry},
 			{Text: "Телефон *", Widget: phoneEntry},
 			{Text: "Email", Widget: emailEntry},
 			{Text: "Дата заезда", Widget: checkInPicker.button},
 			{Text: "Дата выезда", Widget: checkOutPicker.button},
+			{Text: "Гости", Widget: guests.Widget()},
 			{Text: "Тариф *", Widget: tariffSelect},
+			{Text: "Промокод", Widget: promoEntry},
 			{Text: "", Widget: costLabel},
+			{Text: "По ночам", Widget: breakdownLabel},
+			{Text: "", Widget: discountLabel},
 			{Text: "", Widget: advanceLabel},
 			{Text: "", Widget: remainingLabel},
 			{Text: "Примечания", Widget: notesEntry},
@@ -1015,19 +1212,28 @@ func (bc *BookingCalendar) showQuickBookingForm(cottageID int, startDateTime tim
 				CheckOutDate: checkOutDate,
 				TariffID:     tariffs[tariffSelect.SelectedIndex()].ID,
 				Notes:        notesEntry.Text,
+				Adults:       guests.Adults(),
+				Children:     guests.Children(),
+				DiscountCode: promoEntry.Text,
 			}
 
-			// Рассчитываем стоимость
-			checkInDateOnly := time.Date(checkInDate.Year(), checkInDate.Month(), checkInDate.Day(), 0, 0, 0, 0, time.Local)
-			checkOutDateOnly := time.Date(checkOutDate.Year(), checkOutDate.Month(), checkOutDate.Day(), 0, 0, 0, 0, time.Local)
-			days := int(checkOutDateOnly.Sub(checkInDateOnly).Hours()/24) + 1
-			if days <= 0 {
-				days = 1
-			}
-			booking.TotalCost = float64(days) * tariffs[tariffSelect.SelectedIndex()].PricePerDay
-
 			// Сохраняем
 			_, err = bc.bookingService.CreateBooking(booking)
+			if errors.Is(err, service.ErrCottageUnavailable) {
+				// Домик успели забронировать с другого рабочего места
+				bc.Update()
+				dialog.ShowInformation("Домик занят",
+					"Эти даты уже забронированы. Календарь обновлен — выберите другие даты или домик.", bc.window)
+				return
+			}
+			if errors.Is(err, service.ErrStayRestricted) {
+				dialog.ShowInformation("Ограничения сроков", err.Error(), bc.window)
+				return
+			}
+			if errors.Is(err, service.ErrDiscountInvalid) {
+				dialog.ShowInformation("Промокод", err.Error(), bc.window)
+				return
+			}
 			if err != nil {
 				dialog.ShowError(err, bc.window)
 				return
@@ -1039,10 +1245,236 @@ func (bc *BookingCalendar) showQuickBookingForm(cottageID int, startDateTime tim
 	}
 
 	d := dialog.NewCustom("Быстрое бронирование", "Отмена", form, bc.window)
+
+	// Удержание: домик занят на время разговора с гостем, данные можно
+	// дозаполнить при подтверждении
+	holdButton := widget.NewButton(fmt.Sprintf("⏳ Удержать на %d мин", int(holdDuration.Minutes())), func() {
+		if !checkOutDate.After(checkInDate) {
+			dialog.ShowError(fmt.Errorf("дата выезда должна быть позже даты заезда"), bc.window)
+			return
+		}
+
+		hold := models.Booking{
+			CottageID:    cottageID,
+			GuestName:    nameEntry.Text,
+			Phone:        phoneEntry.Text,
+			Email:        emailEntry.Text,
+			CheckInDate:  checkInDate,
+			CheckOutDate: checkOutDate,
+			Notes:        notesEntry.Text,
+			Adults:       guests.Adults(),
+			Children:     guests.Children(),
+		}
+		if tariffSelect.SelectedIndex() >= 0 {
+			hold.TariffID = tariffs[tariffSelect.SelectedIndex()].ID
+		}
+
+		created, err := bc.bookingService.CreateHold(hold, holdDuration)
+		if errors.Is(err, service.ErrCottageUnavailable) {
+			bc.Update()
+			dialog.ShowInformation("Домик занят",
+				"Эти даты уже забронированы. Календарь обновлен — выберите другие даты или домик.", bc.window)
+			return
+		}
+		if err != nil {
+			dialog.ShowError(err, bc.window)
+			return
+		}
+
+		d.Hide()
+		bc.Update()
+		dialog.ShowInformation("Домик удержан",
+			fmt.Sprintf("Домик %s удерживается до %s", cottage.Name, created.HoldExpiresAt.Format("15:04")), bc.window)
+	})
+	form.Append("", holdButton)
+
+	groupButton := widget.NewButton("👥 Групповая бронь", func() {
+		d.Hide()
+		bc.showGroupBookingForm(checkInDate)
+	})
+	form.Append("", groupButton)
+
+	blockButton := widget.NewButton("🔧 Закрыть на обслуживание", func() {
+		d.Hide()
+		bc.showBlockForm(cottage, checkInDate)
+	})
+	form.Append("", blockButton)
+
 	d.Resize(fyne.NewSize(500, 700))
 	d.Show()
 }
 
+// showBlockDetails показывает блокировку домика и позволяет ее снять
+func (bc *BookingCalendar) showBlockDetails(block *models.Booking, cottageName string) {
+	lastDay := block.CheckOutDate.AddDate(0, 0, -1)
+	notes := block.Notes
+	if notes == "" {
+		notes = "-"
+	}
+
+	content := container.NewVBox(
+		widget.NewCard("Домик закрыт", models.BlockReasonTitle(block.BlockReason),
+			container.NewVBox(
+				widget.NewLabel(fmt.Sprintf("Домик: %s", cottageName)),
+				widget.NewLabel(fmt.Sprintf("С: %s", block.CheckInDate.Format("02.01.2006"))),
+				widget.NewLabel(fmt.Sprintf("По: %s", lastDay.Format("02.01.2006"))),
+				widget.NewLabel(fmt.Sprintf("Комментарий: %s", notes)),
+			),
+		),
+	)
+
+	var d dialog.Dialog
+	content.Add(widget.NewButton("Снять блокировку", func() {
+		dialog.ShowConfirm("Подтверждение", "Открыть домик для бронирования?", func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := bc.bookingService.UnblockCottage(block.ID); err != nil {
+				dialog.ShowError(err, bc.window)
+				return
+			}
+			d.Hide()
+			bc.Update()
+			dialog.ShowInformation("Успешно", "Блокировка снята", bc.window)
+		}, bc.window)
+	}))
+
+	d = dialog.NewCustom("Обслуживание домика", "Закрыть", content, bc.window)
+	d.Resize(fyne.NewSize(400, 300))
+	d.Show()
+}
+
+// showBlockForm закрывает домик на ремонт, уборку или для владельца
+func (bc *BookingCalendar) showBlockForm(cottage models.Cottage, firstDay time.Time) {
+	lastDay := firstDay
+
+	reasonOptions := make([]string, len(models.BlockReasons))
+	for i, reason := range models.BlockReasons {
+		reasonOptions[i] = models.BlockReasonTitle(reason)
+	}
+	reasonSelect := widget.NewSelect(reasonOptions, nil)
+	reasonSelect.SetSelectedIndex(0)
+
+	noteEntry := widget.NewMultiLineEntry()
+	noteEntry.SetMinRowsVisible(2)
+	noteEntry.PlaceHolder = "Что делаем, кто отвечает..."
+
+	firstPicker := NewDatePickerButton("Первый день", bc.window, func(t time.Time) {
+		firstDay = t
+	})
+	firstPicker.SetSelectedDate(firstDay)
+
+	lastPicker := NewDatePickerButton("Последний день", bc.window, func(t time.Time) {
+		lastDay = t
+	})
+	lastPicker.SetSelectedDate(lastDay)
+
+	items := []*widget.FormItem{
+		{Text: "Домик", Widget: widget.NewLabel(cottage.Name)},
+		{Text: "Причина *", Widget: reasonSelect},
+		{Text: "Первый день", Widget: firstPicker.button},
+		{Text: "Последний день", Widget: lastPicker.button},
+		{Text: "Комментарий", Widget: noteEntry},
+	}
+
+	dialog.ShowForm("Закрыть на обслуживание", "Закрыть домик", "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		reason := models.BlockReasons[reasonSelect.SelectedIndex()]
+		_, err := bc.bookingService.BlockCottage(cottage.ID, firstDay, lastDay, reason, noteEntry.Text)
+		if errors.Is(err, service.ErrCottageUnavailable) {
+			bc.Update()
+			dialog.ShowInformation("Домик занят",
+				"На эти даты у домика есть брони. Перенесите их или выберите другие даты.", bc.window)
+			return
+		}
+		if err != nil {
+			dialog.ShowError(err, bc.window)
+			return
+		}
+
+		bc.Update()
+		dialog.ShowInformation("Успешно",
+			fmt.Sprintf("Домик %s закрыт: %s", cottage.Name, models.BlockReasonTitle(reason)), bc.window)
+	}, bc.window)
+}
+
+// showConfirmHoldForm превращает удержание в бронь, дозаполняя данные гостя
+func (bc *BookingCalendar) showConfirmHoldForm(hold *models.Booking, cottageName string) {
+	tariffs, err := bc.tariffService.GetTariffs()
+	if err != nil {
+		dialog.ShowError(err, bc.window)
+		return
+	}
+	if len(tariffs) == 0 {
+		dialog.ShowError(fmt.Errorf("нет доступных тарифов"), bc.window)
+		return
+	}
+
+	tariffOptions := make([]string, len(tariffs))
+	selected := 0
+	for i, tariff := range tariffs {
+		tariffOptions[i] = fmt.Sprintf("%s - %s/сутки", tariff.Name, tariff.PricePerDay)
+		if tariff.ID == hold.TariffID {
+			selected = i
+		}
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(hold.GuestName)
+	phoneEntry := widget.NewEntry()
+	phoneEntry.SetText(hold.Phone)
+	emailEntry := widget.NewEntry()
+	emailEntry.SetText(hold.Email)
+	notesEntry := widget.NewMultiLineEntry()
+	notesEntry.SetText(hold.Notes)
+	tariffSelect := widget.NewSelect(tariffOptions, nil)
+	tariffSelect.SetSelectedIndex(selected)
+	guests := NewGuestCountPicker(hold.Adults, hold.Children, nil)
+
+	items := []*widget.FormItem{
+		{Text: "Домик", Widget: widget.NewLabel(cottageName)},
+		{Text: "Даты", Widget: widget.NewLabel(fmt.Sprintf("%s - %s",
+			hold.CheckInDate.Format("02.01.2006"), hold.CheckOutDate.Format("02.01.2006")))},
+		{Text: "ФИО *", Widget: nameEntry},
+		{Text: "Телефон *", Widget: phoneEntry},
+		{Text: "Email", Widget: emailEntry},
+		{Text: "Гости", Widget: guests.Widget()},
+		{Text: "Тариф *", Widget: tariffSelect},
+		{Text: "Примечания", Widget: notesEntry},
+	}
+
+	dialog.ShowForm("Подтверждение брони", "Подтвердить", "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		if nameEntry.Text == "" || phoneEntry.Text == "" || tariffSelect.SelectedIndex() < 0 {
+			dialog.ShowError(fmt.Errorf("заполните обязательные поля (отмечены *)"), bc.window)
+			return
+		}
+
+		_, err := bc.bookingService.ConfirmHold(hold.ID, models.Booking{
+			GuestName: nameEntry.Text,
+			Phone:     phoneEntry.Text,
+			Email:     emailEntry.Text,
+			Notes:     notesEntry.Text,
+			TariffID:  tariffs[tariffSelect.SelectedIndex()].ID,
+			Adults:    guests.Adults(),
+			Children:  guests.Children(),
+		})
+		if err != nil {
+			dialog.ShowError(err, bc.window)
+			bc.Update()
+			return
+		}
+
+		bc.Update()
+		dialog.ShowInformation("Успешно", "Бронирование подтверждено", bc.window)
+	}, bc.window)
+}
+
 // createLegend создает легенду с изображениями
 func (bc *BookingCalendar) createLegend() fyne.CanvasObject {
 	items := []fyne.CanvasObject{
@@ -1050,7 +1482,12 @@ func (bc *BookingCalendar) createLegend() fyne.CanvasObject {
 		bc.createLegendItem("Свободно", filepath.Join(bc.imagesPath, "free.png")),
 		bc.createLegendItem("Забронировано", filepath.Join(bc.imagesPath, "booked.png")),
 		bc.createLegendItem("Заселено", filepath.Join(bc.imagesPath, "bought.png")),
+		bc.createLegendColorItem("Удержание", HoldColor),
+		bc.createLegendColorItem("Обслуживание", BlockedColor),
+		bc.createLegendColorItem("Ограничения (≥N — мин. ночей, ⊘→ — нет заезда, →⊘ — нет выезда)", RestrictedColor),
 		widget.NewLabel("| Диагональ = Выезд/Заезд в один день"),
+		widget.NewLabel("| Перетащите бронь, чтобы перенести; нижний угол дня выезда — чтобы изменить срок"),
+		widget.NewLabel("| Протяните по свободным дням или Shift+щелчок по заезду и выезду — новая бронь"),
 	}
 
 	return container.NewHBox(items...)
@@ -1065,6 +1502,14 @@ func (bc *BookingCalendar) createLegendItem(text string, imagePath string) fyne.
 	return container.NewHBox(img, label)
 }
 
+// createLegendColorItem создает элемент легенды с цветной плашкой
+func (bc *BookingCalendar) createLegendColorItem(text string, c color.Color) fyne.CanvasObject {
+	rect := canvas.NewRectangle(c)
+	rect.SetMinSize(fyne.NewSize(20, 20))
+	label := widget.NewLabel(text)
+	return container.NewHBox(rect, label)
+}
+
 // getWeekdayShort возвращает короткое название дня недели
 func (bc *BookingCalendar) getWeekdayShort(weekday time.Weekday) string {
 	days := []string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"}
@@ -1108,51 +1553,42 @@ func (bc *BookingCalendar) updateCalendar() {
 
 // showEarlyCheckoutDialog показывает диалог раннего выселения
 func (bc *BookingCalendar) showEarlyCheckoutDialog(booking *models.Booking, cottageName string) {
-	// Создаем календарь для выбора новой даты выезда
-	today := time.Now()
+	stay := bc.bookingService.StayPolicy()
 	checkInDate := time.Date(booking.CheckInDate.Year(), booking.CheckInDate.Month(), booking.CheckInDate.Day(), 0, 0, 0, 0, time.Local)
-	originalCheckOut := time.Date(booking.CheckOutDate.Year(), booking.CheckOutDate.Month(), booking.CheckOutDate.Day(), 0, 0, 0, 0, time.Local)
+
+	// Расчет возврата денег
+	refundLabel := widget.NewLabel("")
+
+	// Стоимость считается тем же расчетом, что и при создании брони, а штраф
+	// за освобожденные ночи — по правилам отмены тарифа
+	updateRefund := func(newCheckOutDate time.Time) {
+		quote, err := bc.bookingService.QuoteEarlyCheckout(booking.ID, newCheckOutDate)
+		if err != nil {
+			refundLabel.SetText(err.Error())
+			return
+		}
+		text := fmt.Sprintf("Проживание: %s", quote.Stay)
+		if quote.Released.IsPositive() {
+			text += fmt.Sprintf(" (освобождается ночей: %d на %s)",
+				stay.Nights(newCheckOutDate, booking.CheckOutDate), quote.Released)
+		}
+		refundLabel.SetText(text + "\n" + formatCancellationQuote(quote))
+	}
 
 	// Новая дата выезда должна быть между сегодняшним днем и оригинальной датой выезда
-	var newCheckOutDate time.Time = today
+	newCheckOutDate := time.Now()
 
 	newCheckOutPicker := NewDatePickerButton(
 		"Новая дата выезда",
 		bc.window,
 		func(t time.Time) {
 			newCheckOutDate = t
+			updateRefund(t)
 		},
 	)
-	newCheckOutPicker.SetSelectedDate(today)
-
-	// Расчет возврата денег
-	refundLabel := widget.NewLabel("")
-
-	updateRefund := func() {
-		if !newCheckOutDate.IsZero() {
-			// Рассчитываем сколько дней не использовано
-			originalDays := int(originalCheckOut.Sub(checkInDate).Hours() / 24)
-			usedDays := int(newCheckOutDate.Sub(checkInDate).Hours() / 24)
-
-			if usedDays < 0 {
-				usedDays = 0
-			}
-			if usedDays > originalDays {
-				usedDays = originalDays
-			}
-
-			unusedDays := originalDays - usedDays
-			refundAmount := (booking.TotalCost / float64(originalDays)) * float64(unusedDays)
-
-			if refundAmount > 0 {
-				refundLabel.SetText(fmt.Sprintf("К возврату: %.2f руб. (%d дней)", refundAmount, unusedDays))
-			} else {
-				refundLabel.SetText("Возврат не предусмотрен")
-			}
-		}
-	}
+	newCheckOutPicker.SetSelectedDate(newCheckOutDate)
 
-	updateRefund()
+	updateRefund(newCheckOutDate)
 
 	reasonEntry := widget.NewMultiLineEntry()
 	reasonEntry.SetPlaceHolder("Причина раннего выезда...")
@@ -1179,8 +1615,8 @@ func (bc *BookingCalendar) showEarlyCheckoutDialog(booking *models.Booking, cott
 				return
 			}
 
-			// Устанавливаем время выезда на 12:00
-			newCheckOutDateTime := time.Date(newCheckOutDate.Year(), newCheckOutDate.Month(), newCheckOutDate.Day(), 12, 0, 0, 0, time.Local)
+			// Устанавливаем расчетное время выезда
+			newCheckOutDateTime := stay.CheckOutTime(newCheckOutDate)
 
 			err := bc.bookingService.UpdateCheckOutDate(booking.ID, newCheckOutDateTime, reasonEntry.Text)
 			if err != nil {
@@ -1193,16 +1629,6 @@ func (bc *BookingCalendar) showEarlyCheckoutDialog(booking *models.Booking, cott
 		},
 	}
 
-	// Обновляем расчет при изменении даты
-	newCheckOutPicker.button.OnTapped = func() {
-		// Переопределяем обработчик после выбора даты
-		originalTapped := newCheckOutPicker.button.OnTapped
-		newCheckOutPicker.button.OnTapped = func() {
-			originalTapped()
-			updateRefund()
-		}
-	}
-
 	d := dialog.NewCustom("Ранний выезд", "Отмена", form, bc.window)
 	d.Resize(fyne.NewSize(450, 500))
 	d.Show()
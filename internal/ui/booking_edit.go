@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+// showModifyBookingDialog открывает редактирование брони: перенос дат, смена
+// домика, тарифа или состава гостей с пересчетом стоимости. onDone вызывается после сохранения.
+func showModifyBookingDialog(
+	bookingService *service.BookingService,
+	tariffService *service.TariffService,
+	cottages []models.Cottage,
+	booking *models.Booking,
+	window fyne.Window,
+	onDone func(),
+) {
+	tariffs, err := tariffService.GetTariffs()
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	if len(tariffs) == 0 {
+		dialog.ShowError(fmt.Errorf("нет доступных тарифов"), window)
+		return
+	}
+
+	checkedIn := booking.Status == models.BookingStatusCheckedIn
+	mod := models.BookingModification{
+		CottageID:    booking.CottageID,
+		CheckInDate:  booking.CheckInDate,
+		CheckOutDate: booking.CheckOutDate,
+		TariffID:     booking.TariffID,
+		Adults:       booking.Adults,
+		Children:     booking.Children,
+	}
+
+	cottageOptions := make([]string, len(cottages))
+	for i, c := range cottages {
+		cottageOptions[i] = c.Name
+	}
+	cottageSelect := widget.NewSelect(cottageOptions, nil)
+	for i, c := range cottages {
+		if c.ID == booking.CottageID {
+			cottageSelect.SetSelectedIndex(i)
+		}
+	}
+
+	tariffOptions := make([]string, len(tariffs))
+	for i, tariff := range tariffs {
+		tariffOptions[i] = fmt.Sprintf("%s - %s/сутки", tariff.Name, tariff.PricePerDay)
+	}
+	tariffSelect := widget.NewSelect(tariffOptions, nil)
+	for i, tariff := range tariffs {
+		if tariff.ID == booking.TariffID {
+			tariffSelect.SetSelectedIndex(i)
+		}
+	}
+
+	reasonEntry := widget.NewEntry()
+	reasonEntry.PlaceHolder = "Причина изменения"
+
+	costLabel := widget.NewLabel("")
+	breakdownLabel := widget.NewLabel("")
+	var updateCost func()
+	var guests *GuestCountPicker
+	guests = NewGuestCountPicker(booking.Adults, booking.Children, func() {
+		mod.Adults, mod.Children = guests.Adults(), guests.Children()
+		updateCost()
+	})
+	updateCost = func() {
+		breakdownLabel.SetText("")
+		if mod.TariffID == 0 || !mod.CheckOutDate.After(mod.CheckInDate) {
+			costLabel.SetText(fmt.Sprintf("Стоимость: %s", booking.TotalCost))
+			return
+		}
+		quote, err := bookingService.QuoteBooking(mod.TariffID, mod.CottageID, mod.Adults, mod.Children,
+			mod.CheckInDate, mod.CheckOutDate)
+		if err != nil {
+			costLabel.SetText("Стоимость: -")
+			return
+		}
+		costLabel.SetText(fmt.Sprintf("Стоимость: %s → %s", booking.TotalCost, quote.Total))
+		breakdownLabel.SetText(FormatPriceQuote(quote))
+	}
+
+	cottageSelect.OnChanged = func(_ string) {
+		if i := cottageSelect.SelectedIndex(); i >= 0 {
+			mod.CottageID = cottages[i].ID
+		}
+		updateCost()
+	}
+	tariffSelect.OnChanged = func(_ string) {
+		if i := tariffSelect.SelectedIndex(); i >= 0 {
+			mod.TariffID = tariffs[i].ID
+		}
+		updateCost()
+	}
+
+	checkInPicker := NewDatePickerButton("Дата заезда", window, func(t time.Time) {
+		mod.CheckInDate = t
+		updateCost()
+	})
+	checkInPicker.SetSelectedDate(booking.CheckInDate)
+
+	checkOutPicker := NewDatePickerButton("Дата выезда", window, func(t time.Time) {
+		mod.CheckOutDate = t
+		updateCost()
+	})
+	checkOutPicker.SetSelectedDate(booking.CheckOutDate)
+
+	if checkedIn {
+		// Гость уже в домике: переселение оформляется выездом и новой бронью
+		cottageSelect.Disable()
+		checkInPicker.button.Disable()
+	}
+	updateCost()
+
+	items := []*widget.FormItem{
+		{Text: "Гость", Widget: widget.NewLabel(booking.GuestName)},
+		{Text: "Домик", Widget: cottageSelect},
+		{Text: "Дата заезда", Widget: checkInPicker.button},
+		{Text: "Дата выезда", Widget: checkOutPicker.button},
+		{Text: "Гости", Widget: guests.Widget()},
+		{Text: "Тариф", Widget: tariffSelect},
+		{Text: "", Widget: costLabel},
+		{Text: "По ночам", Widget: breakdownLabel},
+		{Text: "Причина", Widget: reasonEntry},
+	}
+
+	if changes, err := bookingService.GetBookingChanges(booking.ID); err == nil && len(changes) > 0 {
+		history := container.NewVBox()
+		for _, c := range changes {
+			history.Add(widget.NewLabel(formatBookingChange(c, cottages)))
+		}
+		items = append(items, &widget.FormItem{Text: "История", Widget: history})
+	}
+
+	d := dialog.NewForm("Изменить бронь", "Сохранить", "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		if mod.TariffID == 0 {
+			dialog.ShowError(fmt.Errorf("выберите тариф"), window)
+			return
+		}
+		mod.Reason = reasonEntry.Text
+
+		updated, err := bookingService.ModifyBooking(booking.ID, mod)
+		if errors.Is(err, service.ErrCottageUnavailable) {
+			onDone()
+			dialog.ShowInformation("Домик занят",
+				"На выбранные даты домик уже занят. Выберите другие даты или домик.", window)
+			return
+		}
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		onDone()
+		dialog.ShowInformation("Успешно",
+			fmt.Sprintf("Бронь изменена, новая стоимость %s", updated.TotalCost), window)
+	}, window)
+	d.Resize(fyne.NewSize(500, 550))
+	d.Show()
+}
+
+// formatBookingChange описывает запись журнала одной строкой
+func formatBookingChange(c models.BookingChange, cottages []models.Cottage) string {
+	text := c.ChangedAt.Format("02.01.2006 15:04") + ":"
+	if c.OldCottageID != c.NewCottageID {
+		text += fmt.Sprintf(" домик %s → %s;", cottageTitle(cottages, c.OldCottageID), cottageTitle(cottages, c.NewCottageID))
+	}
+	if !c.OldCheckInDate.Equal(c.NewCheckInDate) || !c.OldCheckOutDate.Equal(c.NewCheckOutDate) {
+		text += fmt.Sprintf(" даты %s–%s → %s–%s;",
+			c.OldCheckInDate.Format("02.01"), c.OldCheckOutDate.Format("02.01"),
+			c.NewCheckInDate.Format("02.01"), c.NewCheckOutDate.Format("02.01"))
+	}
+	text += fmt.Sprintf(" %s → %s", c.OldTotalCost, c.NewTotalCost)
+	if c.Reason != "" {
+		text += " (" + c.Reason + ")"
+	}
+	return text
+}
+
+// cottageTitle возвращает название домика по ID
+func cottageTitle(cottages []models.Cottage, cottageID int) string {
+	for _, c := range cottages {
+		if c.ID == cottageID {
+			return c.Name
+		}
+	}
+	return fmt.Sprintf("№%d", cottageID)
+}
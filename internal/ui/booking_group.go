@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+// newGroupCard показывает групповую бронь: плательщика, домики группы
+// с их статусами и общую стоимость
+func newGroupCard(view *models.BookingGroupView, cottages []models.Cottage) *widget.Card {
+	rows := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("Плательщик: %s", view.Group.PayerName)),
+	)
+	if view.Group.Phone != "" {
+		rows.Add(widget.NewLabel(fmt.Sprintf("Телефон: %s", view.Group.Phone)))
+	}
+
+	for _, b := range view.Bookings {
+		rows.Add(widget.NewLabel(fmt.Sprintf("🏠 %s — %s, %s",
+			cottageTitle(cottages, b.CottageID), models.BookingStatusTitle(b.Status), b.Total())))
+	}
+
+	total := widget.NewLabel(fmt.Sprintf("Итого по группе: %s", view.TotalCost()))
+	total.TextStyle = fyne.TextStyle{Bold: true}
+	rows.Add(total)
+
+	return widget.NewCard("👥 "+view.Group.Title(), fmt.Sprintf("Домиков в группе: %d", len(view.Bookings)), rows)
+}
+
+// newGroupActions создает кнопки действий над всей группой. Показываются
+// только действия, допустимые хотя бы для одной брони группы.
+func newGroupActions(bookingService *service.BookingService, view *models.BookingGroupView, window fyne.Window, onDone func()) *fyne.Container {
+	can := func(event models.BookingEvent) bool {
+		for _, b := range view.Bookings {
+			if models.BookingStateMachine.Can(b.Status, event) {
+				return true
+			}
+		}
+		return false
+	}
+
+	run := func(question, done string, action func(groupID int) (int, error)) func() {
+		return func() {
+			dialog.ShowConfirm("Подтверждение", question, func(ok bool) {
+				if !ok {
+					return
+				}
+				n, err := action(view.Group.ID)
+				if err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+				onDone()
+				dialog.ShowInformation("Успешно", fmt.Sprintf("%s: %d", done, n), window)
+			}, window)
+		}
+	}
+
+	actions := container.NewHBox()
+	if can(models.BookingEventCheckIn) {
+		actions.Add(widget.NewButton("Заселить группу", run(
+			"Заселить гостей во все домики группы?", "Заселено домиков", bookingService.CheckInGroup)))
+	}
+	if can(models.BookingEventCheckOut) {
+		actions.Add(widget.NewButton("Выселить группу", run(
+			"Выселить гостей из всех домиков группы?", "Освобождено домиков", bookingService.CheckOutGroup)))
+	}
+	if can(models.BookingEventCancel) {
+		actions.Add(widget.NewButton("Отменить группу", run(
+			"Отменить брони всех домиков группы?", "Отменено броней", bookingService.CancelGroup)))
+	}
+	return actions
+}
+
+// showGroupBookingForm бронирует несколько домиков на одни даты
+// под одним плательщиком
+func (bc *BookingCalendar) showGroupBookingForm(checkInDate time.Time) {
+	tariffs, err := bc.tariffService.GetTariffs()
+	if err != nil {
+		dialog.ShowError(err, bc.window)
+		return
+	}
+	if len(tariffs) == 0 {
+		dialog.ShowError(fmt.Errorf("нет доступных тарифов"), bc.window)
+		return
+	}
+
+	tariffOptions := make([]string, len(tariffs))
+	for i, tariff := range tariffs {
+		tariffOptions[i] = fmt.Sprintf("%s - %s/сутки", tariff.Name, tariff.PricePerDay)
+	}
+	tariffSelect := widget.NewSelect(tariffOptions, nil)
+	tariffSelect.SetSelectedIndex(0)
+
+	cottageOptions := make([]string, len(bc.cottages))
+	for i, c := range bc.cottages {
+		cottageOptions[i] = c.Name
+	}
+	cottageChecks := widget.NewCheckGroup(cottageOptions, nil)
+
+	groupNameEntry := widget.NewEntry()
+	groupNameEntry.PlaceHolder = "Например: Юбилей Ивановых"
+	payerEntry := widget.NewEntry()
+	payerEntry.PlaceHolder = "ФИО или организация"
+	phoneEntry := widget.NewEntry()
+	phoneEntry.PlaceHolder = "+7 (999) 123-45-67"
+	emailEntry := widget.NewEntry()
+	emailEntry.PlaceHolder = "email@example.com"
+	notesEntry := widget.NewMultiLineEntry()
+	notesEntry.SetMinRowsVisible(2)
+
+	checkIn, checkOut := bc.bookingService.StayPolicy().Normalize(checkInDate, checkInDate.AddDate(0, 0, 1))
+
+	checkInPicker := NewDatePickerButton("Дата заезда", bc.window, func(t time.Time) {
+		checkIn = t
+	})
+	checkInPicker.SetSelectedDate(checkIn)
+	checkOutPicker := NewDatePickerButton("Дата выезда", bc.window, func(t time.Time) {
+		checkOut = t
+	})
+	checkOutPicker.SetSelectedDate(checkOut)
+
+	items := []*widget.FormItem{
+		{Text: "Название группы", Widget: groupNameEntry},
+		{Text: "Плательщик *", Widget: payerEntry},
+		{Text: "Телефон *", Widget: phoneEntry},
+		{Text: "Email", Widget: emailEntry},
+		{Text: "Дата заезда", Widget: checkInPicker.button},
+		{Text: "Дата выезда", Widget: checkOutPicker.button},
+		{Text: "Тариф *", Widget: tariffSelect},
+		{Text: "Домики *", Widget: container.NewVScroll(cottageChecks)},
+		{Text: "Примечания", Widget: notesEntry},
+	}
+
+	d := dialog.NewForm("Групповая бронь", "Забронировать", "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		if payerEntry.Text == "" || phoneEntry.Text == "" || tariffSelect.SelectedIndex() < 0 {
+			dialog.ShowError(fmt.Errorf("заполните обязательные поля (отмечены *)"), bc.window)
+			return
+		}
+		if len(cottageChecks.Selected) == 0 {
+			dialog.ShowError(fmt.Errorf("выберите домики группы"), bc.window)
+			return
+		}
+
+		tariffID := tariffs[tariffSelect.SelectedIndex()].ID
+		var bookings []models.Booking
+		for _, c := range bc.cottages {
+			for _, name := range cottageChecks.Selected {
+				if c.Name == name {
+					bookings = append(bookings, models.Booking{
+						CottageID:    c.ID,
+						CheckInDate:  checkIn,
+						CheckOutDate: checkOut,
+						TariffID:     tariffID,
+						Notes:        notesEntry.Text,
+					})
+					break
+				}
+			}
+		}
+
+		view, err := bc.bookingService.CreateGroupBooking(models.BookingGroup{
+			Name:      groupNameEntry.Text,
+			PayerName: payerEntry.Text,
+			Phone:     phoneEntry.Text,
+			Email:     emailEntry.Text,
+			Notes:     notesEntry.Text,
+		}, bookings)
+		if errors.Is(err, service.ErrCottageUnavailable) {
+			bc.Update()
+			dialog.ShowInformation("Домик занят",
+				fmt.Sprintf("Группа не создана: %v. Уберите этот домик или выберите другие даты.", err), bc.window)
+			return
+		}
+		if err != nil {
+			dialog.ShowError(err, bc.window)
+			return
+		}
+
+		bc.Update()
+		dialog.ShowInformation("Успешно",
+			fmt.Sprintf("Групповая бронь создана: %d домиков, %s", len(view.Bookings), view.TotalCost()), bc.window)
+	}, bc.window)
+	d.Resize(fyne.NewSize(500, 700))
+	d.Show()
+}
@@ -18,6 +18,7 @@ type BookingListWidget struct {
 
 	bookingService *service.BookingService
 	cottageService *service.CottageService
+	tariffService  *service.TariffService
 
 	window   fyne.Window
 	bookings []models.Booking
@@ -35,11 +36,13 @@ type BookingListWidget struct {
 func NewBookingListWidget(
 	bookingService *service.BookingService,
 	cottageService *service.CottageService,
+	tariffService *service.TariffService,
 	window fyne.Window,
 ) *BookingListWidget {
 	blw := &BookingListWidget{
 		bookingService: bookingService,
 		cottageService: cottageService,
+		tariffService:  tariffService,
 		window:         window,
 	}
 
@@ -80,6 +83,18 @@ func (blw *BookingListWidget) loadBookings() {
 		bookings, err = blw.bookingService.GetBookingsByStatus(models.BookingStatusBooked, today)
 	case "Текущие":
 		bookings, err = blw.bookingService.GetBookingsByStatus(models.BookingStatusCheckedIn, time.Time{})
+	case "Удержания":
+		bookings, err = blw.bookingService.GetBookingsByStatus(models.BookingStatusTemporary, time.Time{})
+	case "Обслуживание":
+		bookings, err = blw.bookingService.GetBookingsByStatus(models.BookingStatusBlocked, time.Time{})
+	case "Групповые":
+		var all []models.Booking
+		all, err = blw.bookingService.GetBookingsByDateRange(today, today.AddDate(0, 3, 0))
+		for _, b := range all {
+			if b.GroupID != 0 {
+				bookings = append(bookings, b)
+			}
+		}
 	case "Все активные":
 		// Получаем все брони за следующие 3 месяца
 		endDate := today.AddDate(0, 3, 0)
@@ -106,7 +121,7 @@ func (blw *BookingListWidget) loadBookings() {
 func (blw *BookingListWidget) CreateRenderer() fyne.WidgetRenderer {
 	// Фильтр
 	blw.filterSelect = widget.NewSelect(
-		[]string{"Предстоящие", "Текущие", "Все активные", "За последний месяц"},
+		[]string{"Предстоящие", "Текущие", "Удержания", "Обслуживание", "Групповые", "Все активные", "За последний месяц"},
 		func(value string) {
 			blw.loadBookings()
 		},
@@ -192,7 +207,11 @@ func (blw *BookingListWidget) updateBookingItem(id widget.ListItemID, item fyne.
 
 	// Обновляем labels
 	nameLabel := vbox.Objects[0].(*widget.Label)
-	nameLabel.SetText(booking.GuestName)
+	if booking.GroupID != 0 {
+		nameLabel.SetText("👥 " + booking.GuestName)
+	} else {
+		nameLabel.SetText(booking.GuestName)
+	}
 
 	infoBox1 := vbox.Objects[1].(*fyne.Container)
 	cottageLabel := infoBox1.Objects[0].(*widget.Label)
@@ -216,6 +235,10 @@ func (blw *BookingListWidget) updateBookingItem(id widget.ListItemID, item fyne.
 		statusLabel.SetText("Заселено")
 	case models.BookingStatusCancelled:
 		statusLabel.SetText("Отменено")
+	case models.BookingStatusTemporary:
+		statusLabel.SetText("Удержание до " + booking.HoldExpiresAt.Format("15:04"))
+	case models.BookingStatusBlocked:
+		statusLabel.SetText(models.BlockReasonTitle(booking.BlockReason))
 	}
 
 	// Цвет карточки в зависимости от статуса
@@ -226,6 +249,10 @@ func (blw *BookingListWidget) updateBookingItem(id widget.ListItemID, item fyne.
 		card.SetSubTitle("Заселено")
 	case models.BookingStatusCancelled:
 		card.SetSubTitle("Отменено")
+	case models.BookingStatusTemporary:
+		card.SetSubTitle("Удержание")
+	case models.BookingStatusBlocked:
+		card.SetSubTitle("Обслуживание")
 	}
 }
 
@@ -250,7 +277,7 @@ func (blw *BookingListWidget) showBookingActions(booking models.Booking) {
 				widget.NewLabel(fmt.Sprintf("Заезд: %s", booking.CheckInDate.Format("02.01.2006 15:04"))),
 				widget.NewLabel(fmt.Sprintf("Выезд: %s", booking.CheckOutDate.Format("02.01.2006 15:04"))),
 				widget.NewLabel(fmt.Sprintf("Статус: %s", blw.getStatusText(booking.Status))),
-				widget.NewLabel(fmt.Sprintf("Стоимость: %.2f руб.", booking.TotalCost)),
+				widget.NewLabel(fmt.Sprintf("Стоимость: %s", booking.Total())),
 				widget.NewLabel(fmt.Sprintf("Создано: %s", booking.CreatedAt.Format("02.01.2006 15:04"))),
 			),
 		),
@@ -262,10 +289,45 @@ func (blw *BookingListWidget) showBookingActions(booking models.Booking) {
 		))
 	}
 
+	if booking.GroupID != 0 {
+		group, err := blw.bookingService.GetBookingGroup(booking.GroupID)
+		if err != nil {
+			dialog.ShowError(err, blw.window)
+		} else {
+			content.Add(newGroupCard(group, blw.cottages))
+			content.Add(newGroupActions(blw.bookingService, group, blw.window, func() {
+				blw.loadData()
+				blw.triggerRefresh()
+			}))
+		}
+	}
+
 	// Кнопки действий
 	actions := container.NewHBox()
 
 	switch booking.Status {
+	case models.BookingStatusTemporary:
+		actions.Add(widget.NewButton("Снять удержание", func() {
+			if err := blw.bookingService.ReleaseHold(booking.ID); err != nil {
+				dialog.ShowError(err, blw.window)
+				return
+			}
+			blw.loadData()
+			blw.triggerRefresh()
+			dialog.ShowInformation("Успешно", "Удержание снято", blw.window)
+		}))
+
+	case models.BookingStatusBlocked:
+		actions.Add(widget.NewButton("Снять блокировку", func() {
+			if err := blw.bookingService.UnblockCottage(booking.ID); err != nil {
+				dialog.ShowError(err, blw.window)
+				return
+			}
+			blw.loadData()
+			blw.triggerRefresh()
+			dialog.ShowInformation("Успешно", "Блокировка снята", blw.window)
+		}))
+
 	case models.BookingStatusBooked:
 		actions.Add(widget.NewButton("Заселить", func() {
 			dialog.ShowConfirm("Подтверждение", "Заселить гостя?", func(ok bool) {
@@ -283,31 +345,37 @@ func (blw *BookingListWidget) showBookingActions(booking models.Booking) {
 		}))
 
 		actions.Add(widget.NewButton("Отменить", func() {
-			dialog.ShowConfirm("Подтверждение", "Отменить бронирование?", func(ok bool) {
-				if ok {
-					err := blw.bookingService.CancelBooking(booking.ID)
-					if err != nil {
-						dialog.ShowError(err, blw.window)
-						return
-					}
-					blw.loadData()
-					blw.triggerRefresh()
-					dialog.ShowInformation("Успешно", "Бронирование отменено", blw.window)
-				}
-			}, blw.window)
+			confirmCancelBooking(blw.bookingService, booking.ID, blw.window, func() {
+				blw.loadData()
+				blw.triggerRefresh()
+			})
+		}))
+
+		actions.Add(widget.NewButton("Изменить", func() {
+			blw.showModifyDialog(booking)
 		}))
 
 	case models.BookingStatusCheckedIn:
-		// Здесь можно добавить кнопку "Изменить даты"
+		actions.Add(widget.NewButton("Изменить даты", func() {
+			blw.showModifyDialog(booking)
+		}))
 	}
 
 	content.Add(actions)
 
-	d := dialog.NewCustom("Действия с бронированием", "Закрыть", content, blw.window)
+	d := dialog.NewCustom("Действия с бронированием", "Закрыть", container.NewVScroll(content), blw.window)
 	d.Resize(fyne.NewSize(500, 500))
 	d.Show()
 }
 
+// showModifyDialog открывает редактирование дат, домика и тарифа брони
+func (blw *BookingListWidget) showModifyDialog(booking models.Booking) {
+	showModifyBookingDialog(blw.bookingService, blw.tariffService, blw.cottages, &booking, blw.window, func() {
+		blw.loadData()
+		blw.triggerRefresh()
+	})
+}
+
 // getStatusText возвращает текст статуса
 func (blw *BookingListWidget) getStatusText(status string) string {
 	switch status {
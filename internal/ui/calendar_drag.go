@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+var (
+	// dropOKColor — подсветка свободных ячеек при перетаскивании брони
+	dropOKColor = color.NRGBA{R: 40, G: 167, B: 69, A: 110}
+	// dropConflictColor — подсветка ячеек, где перенос невозможен
+	dropConflictColor = color.NRGBA{R: 220, G: 53, B: 69, A: 130}
+)
+
+// dragMode — что меняет перетаскивание брони
+type dragMode int
+
+const (
+	dragMove   dragMode = iota // перенос брони в другой день или домик
+	dragResize                 // перенос дня выезда (продление или сокращение)
+)
+
+// calendarCell — ячейка сетки, в которую можно бросить бронь
+type calendarCell struct {
+	cottageID int
+	date      time.Time
+	object    fyne.CanvasObject
+	preview   *canvas.Rectangle
+}
+
+// calendarDrag — состояние текущего перетаскивания
+type calendarDrag struct {
+	booking *models.Booking
+	mode    dragMode
+	// anchor — день, за который взяли бронь
+	anchor time.Time
+	target *calendarCell
+	mod    models.BookingModification
+	cost   models.Money
+	err    error
+	// lit — ячейки, подсвеченные предпросмотром
+	lit []*calendarCell
+}
+
+// wrapDropCell добавляет поверх ячейки слой предпросмотра и запоминает ее
+// как цель для перетаскивания
+func (bc *BookingCalendar) wrapDropCell(cottageID int, date time.Time, cell fyne.CanvasObject) fyne.CanvasObject {
+	preview := canvas.NewRectangle(color.Transparent)
+	preview.Hide()
+
+	wrapped := container.NewStack(cell, preview)
+	bc.cells = append(bc.cells, &calendarCell{
+		cottageID: cottageID,
+		date:      date,
+		object:    wrapped,
+		preview:   preview,
+	})
+	return wrapped
+}
+
+// cellAt находит ячейку под точкой окна
+func (bc *BookingCalendar) cellAt(pos fyne.Position) *calendarCell {
+	driver := fyne.CurrentApp().Driver()
+	for _, c := range bc.cells {
+		if !c.object.Visible() {
+			continue
+		}
+		origin := driver.AbsolutePositionForObject(c.object)
+		size := c.object.Size()
+		if pos.X >= origin.X && pos.X < origin.X+size.Width &&
+			pos.Y >= origin.Y && pos.Y < origin.Y+size.Height {
+			return c
+		}
+	}
+	return nil
+}
+
+// onBookingDragged ведет бронь за указателем: при смене ячейки под ним
+// проверяет перенос и подсвечивает новый период зеленым или красным
+func (bc *BookingCalendar) onBookingDragged(bookingID int, mode dragMode, anchor time.Time, ev *fyne.DragEvent) {
+	if bc.drag == nil {
+		booking, err := bc.bookingService.GetBookingByID(bookingID)
+		if err != nil {
+			return
+		}
+		bc.drag = &calendarDrag{booking: booking, mode: mode, anchor: anchor}
+	}
+	drag := bc.drag
+
+	target := bc.cellAt(ev.AbsolutePosition)
+	if target == nil || target == drag.target {
+		return
+	}
+	drag.target = target
+
+	b := drag.booking
+	drag.mod = models.BookingModification{
+		CottageID:    b.CottageID,
+		CheckInDate:  b.CheckInDate,
+		CheckOutDate: b.CheckOutDate,
+		TariffID:     b.TariffID,
+		Adults:       b.Adults,
+		Children:     b.Children,
+	}
+	switch drag.mode {
+	case dragMove:
+		shift := int(math.Round(target.date.Sub(drag.anchor).Hours() / 24))
+		drag.mod.CottageID = target.cottageID
+		drag.mod.CheckInDate = b.CheckInDate.AddDate(0, 0, shift)
+		drag.mod.CheckOutDate = b.CheckOutDate.AddDate(0, 0, shift)
+	case dragResize:
+		out := b.CheckOutDate
+		drag.mod.CheckOutDate = time.Date(target.date.Year(), target.date.Month(), target.date.Day(),
+			out.Hour(), out.Minute(), 0, 0, time.Local)
+	}
+
+	switch {
+	case drag.mode == dragResize && target.cottageID != b.CottageID:
+		drag.err = fmt.Errorf("срок меняется только в строке своего домика")
+	case drag.mod.TariffID == 0:
+		drag.err = fmt.Errorf("у брони не выбран тариф")
+	default:
+		drag.cost, drag.err = bc.bookingService.PreviewModification(b.ID, drag.mod)
+	}
+
+	bc.showDropPreview(drag)
+}
+
+// showDropPreview подсвечивает ячейки нового периода и пишет итог проверки
+func (bc *BookingCalendar) showDropPreview(drag *calendarDrag) {
+	bc.clearDropPreview(drag)
+
+	first := dateOnly(drag.mod.CheckInDate)
+	last := dateOnly(drag.mod.CheckOutDate)
+	tint := dropOKColor
+	if drag.err != nil {
+		tint = dropConflictColor
+	}
+
+	for _, c := range bc.cells {
+		if c.cottageID != drag.mod.CottageID || c.date.Before(first) || c.date.After(last) {
+			continue
+		}
+		c.preview.FillColor = tint
+		c.preview.Show()
+		c.preview.Refresh()
+		drag.lit = append(drag.lit, c)
+	}
+
+	dates := fmt.Sprintf("%s – %s, %s", drag.mod.CheckInDate.Format("02.01"),
+		drag.mod.CheckOutDate.Format("02.01"), cottageTitle(bc.cottages, drag.mod.CottageID))
+	switch {
+	case drag.err == nil:
+		bc.dragLabel.SetText(fmt.Sprintf("✅ %s: %s → %s", dates, drag.booking.TotalCost, drag.cost))
+	case errors.Is(drag.err, service.ErrCottageUnavailable):
+		bc.dragLabel.SetText(fmt.Sprintf("❌ %s: домик занят", dates))
+	default:
+		bc.dragLabel.SetText(fmt.Sprintf("❌ %s: %v", dates, drag.err))
+	}
+	bc.dragLabel.Show()
+}
+
+// clearDropPreview снимает подсветку предыдущей цели
+func (bc *BookingCalendar) clearDropPreview(drag *calendarDrag) {
+	for _, c := range drag.lit {
+		c.preview.Hide()
+	}
+	drag.lit = nil
+}
+
+// onBookingDragEnd предлагает сохранить перенос с новой стоимостью
+func (bc *BookingCalendar) onBookingDragEnd() {
+	drag := bc.drag
+	bc.drag = nil
+	if drag == nil {
+		return
+	}
+	bc.clearDropPreview(drag)
+	bc.dragLabel.Hide()
+
+	b := drag.booking
+	if drag.target == nil || (drag.mod.CottageID == b.CottageID &&
+		drag.mod.CheckInDate.Equal(b.CheckInDate) && drag.mod.CheckOutDate.Equal(b.CheckOutDate)) {
+		return
+	}
+	if drag.err != nil {
+		if errors.Is(drag.err, service.ErrCottageUnavailable) {
+			dialog.ShowInformation("Домик занят", "На выбранные даты домик уже занят", bc.window)
+		} else {
+			dialog.ShowError(drag.err, bc.window)
+		}
+		return
+	}
+
+	action, reason := "Перенести", "Перенос в календаре"
+	if drag.mode == dragResize {
+		action, reason = "Изменить срок", "Изменение срока в календаре"
+	}
+
+	message := fmt.Sprintf("%s бронь гостя %s?\n\n%s: %s – %s\n→ %s: %s – %s\n\nСтоимость: %s → %s",
+		action, b.GuestName,
+		cottageTitle(bc.cottages, b.CottageID), b.CheckInDate.Format("02.01.2006"), b.CheckOutDate.Format("02.01.2006"),
+		cottageTitle(bc.cottages, drag.mod.CottageID), drag.mod.CheckInDate.Format("02.01.2006"), drag.mod.CheckOutDate.Format("02.01.2006"),
+		b.TotalCost, drag.cost)
+
+	dialog.ShowConfirm(action, message, func(ok bool) {
+		if !ok {
+			return
+		}
+		mod := drag.mod
+		mod.Reason = reason
+		_, err := bc.bookingService.ModifyBooking(b.ID, mod)
+		if errors.Is(err, service.ErrCottageUnavailable) {
+			bc.Update()
+			dialog.ShowInformation("Домик занят",
+				"Пока вы переносили бронь, эти даты заняли. Календарь обновлен.", bc.window)
+			return
+		}
+		if err != nil {
+			dialog.ShowError(err, bc.window)
+			return
+		}
+		bc.Update()
+	}, bc.window)
+}
+
+// dateOnly отбрасывает время, оставляя начало дня
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
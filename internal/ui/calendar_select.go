@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// selectionColor — подсветка выбранного периода новой брони
+var selectionColor = color.NRGBA{R: 0, G: 123, B: 255, A: 110}
+
+// calendarSelection — выбор периода новой брони в строке домика:
+// перетаскиванием по свободным ячейкам или двумя щелчками с Shift
+type calendarSelection struct {
+	cottageID int
+	// first и last — день заезда и день выезда
+	first time.Time
+	last  time.Time
+	// busy — занятые ночи домика (начала дней)
+	busy map[time.Time]bool
+	lit  []*calendarCell
+}
+
+// shiftPressed сообщает, зажат ли Shift (только на десктопе)
+func shiftPressed() bool {
+	if d, ok := fyne.CurrentApp().Driver().(desktop.Driver); ok {
+		return d.CurrentKeyModifiers()&fyne.KeyModifierShift != 0
+	}
+	return false
+}
+
+// startSelection начинает выбор периода с дня заезда
+func (bc *BookingCalendar) startSelection(cottageID int, date time.Time) *calendarSelection {
+	if bc.selection != nil {
+		bc.clearSelection()
+	}
+
+	sel := &calendarSelection{
+		cottageID: cottageID,
+		first:     date,
+		last:      date,
+		busy:      make(map[time.Time]bool),
+	}
+
+	// Занятые ночи берем один раз на весь выбор: брони домика за видимый период
+	monthStart := time.Date(bc.currentMonth.Year(), bc.currentMonth.Month(), 1, 0, 0, 0, 0, time.Local)
+	bookings, err := bc.bookingService.GetBookingsByDateRange(monthStart.AddDate(0, 0, -1), monthStart.AddDate(0, 1, 1))
+	if err == nil {
+		for _, b := range bookings {
+			if b.CottageID != cottageID || !models.IsActiveBookingStatus(b.Status) {
+				continue
+			}
+			for d := dateOnly(b.CheckInDate); d.Before(dateOnly(b.CheckOutDate)); d = d.AddDate(0, 0, 1) {
+				sel.busy[d] = true
+			}
+		}
+	}
+
+	bc.selection = sel
+	bc.showSelection()
+	return sel
+}
+
+// extendSelection растягивает выбор до дня date в той же строке
+func (bc *BookingCalendar) extendSelection(date time.Time) {
+	sel := bc.selection
+	if sel == nil || date.Equal(sel.last) {
+		return
+	}
+	sel.last = date
+	bc.showSelection()
+}
+
+// selectionRange возвращает день заезда и день выезда по порядку.
+// Выбор одной ячейки означает одну ночь.
+func (sel *calendarSelection) selectionRange() (time.Time, time.Time) {
+	first, last := sel.first, sel.last
+	if last.Before(first) {
+		first, last = last, first
+	}
+	if last.Equal(first) {
+		last = first.AddDate(0, 0, 1)
+	}
+	return first, last
+}
+
+// conflicts возвращает занятые ночи внутри выбранного периода
+func (sel *calendarSelection) conflicts() []time.Time {
+	first, last := sel.selectionRange()
+	var busy []time.Time
+	for d := first; d.Before(last); d = d.AddDate(0, 0, 1) {
+		if sel.busy[d] {
+			busy = append(busy, d)
+		}
+	}
+	return busy
+}
+
+// showSelection подсвечивает выбранный период, занятые ночи — красным
+func (bc *BookingCalendar) showSelection() {
+	sel := bc.selection
+	for _, c := range sel.lit {
+		c.preview.Hide()
+	}
+	sel.lit = nil
+
+	first, last := sel.selectionRange()
+	for _, c := range bc.cells {
+		if c.cottageID != sel.cottageID || c.date.Before(first) || c.date.After(last) {
+			continue
+		}
+		c.preview.FillColor = selectionColor
+		if sel.busy[c.date] && c.date.Before(last) {
+			c.preview.FillColor = dropConflictColor
+		}
+		c.preview.Show()
+		c.preview.Refresh()
+		sel.lit = append(sel.lit, c)
+	}
+
+	nights := bc.bookingService.StayPolicy().Nights(first, last)
+	text := fmt.Sprintf("📅 %s: %s – %s, ночей: %d", cottageTitle(bc.cottages, sel.cottageID),
+		first.Format("02.01"), last.Format("02.01"), nights)
+	if busy := sel.conflicts(); len(busy) > 0 {
+		text += fmt.Sprintf(" — ❌ занято: %s", formatDays(busy))
+	}
+	bc.dragLabel.SetText(text)
+	bc.dragLabel.Show()
+}
+
+// clearSelection снимает подсветку выбора
+func (bc *BookingCalendar) clearSelection() {
+	if bc.selection == nil {
+		return
+	}
+	for _, c := range bc.selection.lit {
+		c.preview.Hide()
+	}
+	bc.selection = nil
+	bc.dragLabel.Hide()
+}
+
+// finishSelection открывает форму брони на выбранный период или сообщает,
+// какие ночи в нем заняты
+func (bc *BookingCalendar) finishSelection() {
+	sel := bc.selection
+	if sel == nil {
+		return
+	}
+	first, last := sel.selectionRange()
+	busy := sel.conflicts()
+	bc.clearSelection()
+
+	if len(busy) > 0 {
+		dialog.ShowInformation("Домик занят",
+			fmt.Sprintf("В выбранном периоде домик занят: %s. Выберите другие даты.", formatDays(busy)), bc.window)
+		return
+	}
+
+	checkIn, checkOut := bc.bookingService.StayPolicy().Normalize(first, last)
+	bc.showBookingForm(sel.cottageID, checkIn, checkOut)
+}
+
+// onRangeDragged выделяет период, пока указатель тянут по строке домика
+func (bc *BookingCalendar) onRangeDragged(cottageID int, date time.Time, ev *fyne.DragEvent) {
+	if bc.selection == nil || bc.selection.cottageID != cottageID {
+		bc.startSelection(cottageID, date)
+	}
+	if target := bc.cellAt(ev.AbsolutePosition); target != nil {
+		// Период выбирается только в строке того домика, с которого начали
+		bc.extendSelection(target.date)
+	}
+}
+
+// onFreeCellTapped обрабатывает щелчок по свободному дню: с Shift первый
+// щелчок отмечает заезд, второй — выезд; без Shift открывается форма
+// на одну ночь, как раньше
+func (bc *BookingCalendar) onFreeCellTapped(cottageID int, date time.Time) {
+	if !shiftPressed() {
+		bc.clearSelection()
+		bc.showQuickBookingForm(cottageID, date)
+		return
+	}
+
+	if bc.selection == nil || bc.selection.cottageID != cottageID {
+		bc.startSelection(cottageID, date)
+		return
+	}
+	bc.extendSelection(date)
+	bc.finishSelection()
+}
+
+// formatDays перечисляет дни через запятую
+func formatDays(days []time.Time) string {
+	parts := make([]string, len(days))
+	for i, d := range days {
+		parts[i] = d.Format("02.01")
+	}
+	return strings.Join(parts, ", ")
+}
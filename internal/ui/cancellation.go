@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+// ShowCancellationPoliciesDialog показывает правила отмены, которые можно
+// назначить тарифам в окне цен тарифа
+func ShowCancellationPoliciesDialog(tariffService *service.TariffService, window fyne.Window) {
+	list := container.NewVBox()
+
+	var reload func()
+	reload = func() {
+		list.RemoveAll()
+		policies, err := tariffService.GetCancellationPolicies()
+		if err != nil {
+			list.Add(widget.NewLabel(err.Error()))
+			return
+		}
+		if len(policies) == 0 {
+			list.Add(widget.NewLabel("Правил нет — брони отменяются без штрафа"))
+		}
+		for _, p := range policies {
+			p := p
+			list.Add(container.NewBorder(nil, nil, nil,
+				container.NewHBox(
+					widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+						showCancellationPolicyForm(tariffService, p, window, reload)
+					}),
+					widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+						dialog.ShowConfirm("Подтверждение",
+							fmt.Sprintf("Удалить правила «%s»? Тарифы с ними останутся без штрафов.", p.Name),
+							func(ok bool) {
+								if !ok {
+									return
+								}
+								if err := tariffService.DeleteCancellationPolicy(p.ID); err != nil {
+									dialog.ShowError(err, window)
+									return
+								}
+								reload()
+							}, window)
+					}),
+				),
+				widget.NewLabel(fmt.Sprintf("📜 %s: %s", p.Name, p.Terms())),
+			))
+		}
+	}
+	reload()
+
+	addBtn := widget.NewButtonWithIcon("Добавить правила", theme.ContentAddIcon(), func() {
+		showCancellationPolicyForm(tariffService, models.CancellationPolicy{}, window, reload)
+	})
+
+	content := container.NewBorder(
+		widget.NewLabel("Штраф удерживается при отмене брони и с ночей, освобожденных при раннем выезде"),
+		addBtn, nil, nil,
+		container.NewVScroll(list),
+	)
+
+	d := dialog.NewCustom("Правила отмены", "Закрыть", content, window)
+	d.Resize(fyne.NewSize(650, 500))
+	d.Show()
+}
+
+// showCancellationPolicyForm создает или изменяет правила отмены
+func showCancellationPolicyForm(tariffService *service.TariffService, policy models.CancellationPolicy, window fyne.Window, onDone func()) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(policy.Name)
+	nameEntry.PlaceHolder = "Например: Гибкий"
+
+	freeDaysEntry := widget.NewEntry()
+	freeDaysEntry.SetText(strconv.Itoa(policy.FreeDays))
+	penaltyEntry := widget.NewEntry()
+	penaltyEntry.SetText(formatPercent(policy.PenaltyPercent))
+
+	nonRefundableCheck := widget.NewCheck("Невозвратный тариф (удерживается вся стоимость)", nil)
+	nonRefundableCheck.SetChecked(policy.NonRefundable)
+
+	items := []*widget.FormItem{
+		{Text: "Название *", Widget: nameEntry},
+		{Text: "Бесплатно за, дней до заезда", Widget: freeDaysEntry},
+		{Text: "Штраф позже, %", Widget: penaltyEntry},
+		{Text: "", Widget: nonRefundableCheck},
+	}
+
+	dialog.ShowForm("Правила отмены", "Сохранить", "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		freeDays, err := strconv.Atoi(strings.TrimSpace(freeDaysEntry.Text))
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("неверное число дней"), window)
+			return
+		}
+		penalty, err := parsePercent(penaltyEntry.Text)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("неверный размер штрафа"), window)
+			return
+		}
+
+		policy.Name = nameEntry.Text
+		policy.FreeDays = freeDays
+		policy.PenaltyPercent = penalty
+		policy.NonRefundable = nonRefundableCheck.Checked
+		if _, err := tariffService.SaveCancellationPolicy(policy); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		onDone()
+	}, window)
+}
+
+// formatCancellationQuote описывает расчет с гостем при отмене или раннем выезде
+func formatCancellationQuote(quote *models.CancellationQuote) string {
+	var lines []string
+	if quote.Policy == "" {
+		lines = append(lines, "Тариф без штрафов за отмену")
+	} else if quote.Penalty.IsPositive() {
+		lines = append(lines, fmt.Sprintf("Штраф по правилам «%s»: %s", quote.Policy, quote.Penalty))
+	} else {
+		lines = append(lines, fmt.Sprintf("Без штрафа по правилам «%s»", quote.Policy))
+	}
+	lines = append(lines, fmt.Sprintf("Оплачено: %s", quote.Paid))
+	switch {
+	case quote.Refund().IsPositive():
+		lines = append(lines, fmt.Sprintf("К возврату гостю: %s", quote.Refund()))
+	case quote.Due().IsPositive():
+		lines = append(lines, fmt.Sprintf("Гость должен доплатить: %s", quote.Due()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// confirmCancelBooking показывает штраф и возврат по правилам отмены
+// и отменяет бронь после подтверждения
+func confirmCancelBooking(bookingService *service.BookingService, bookingID int, window fyne.Window, onDone func()) {
+	quote, err := bookingService.QuoteCancellation(bookingID)
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	dialog.ShowConfirm("Подтверждение", "Отменить бронирование?\n\n"+formatCancellationQuote(quote), func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := bookingService.CancelBooking(bookingID); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		onDone()
+		message := "Бронирование отменено"
+		if refund := quote.Refund(); refund.IsPositive() {
+			message += fmt.Sprintf("\nВозврат %s записан в журнал оплат", refund)
+		}
+		dialog.ShowInformation("Успешно", message, window)
+	}, window)
+}
+
+// newTariffPolicyCard создает карточку выбора правил отмены тарифа
+func newTariffPolicyCard(tariffService *service.TariffService, tariff models.Tariff, window fyne.Window) fyne.CanvasObject {
+	policies, err := tariffService.GetCancellationPolicies()
+	if err != nil {
+		return widget.NewLabel(err.Error())
+	}
+
+	options := []string{"Без штрафов"}
+	selected := options[0]
+	for _, p := range policies {
+		options = append(options, p.Name)
+		if p.ID == tariff.CancellationPolicyID {
+			selected = p.Name
+		}
+	}
+	terms := widget.NewLabel("")
+	policySelect := widget.NewSelect(options, nil)
+	policySelect.OnChanged = func(string) {
+		terms.SetText("")
+		if i := policySelect.SelectedIndex(); i > 0 {
+			terms.SetText(policies[i-1].Terms())
+		}
+	}
+	policySelect.SetSelected(selected)
+
+	save := widget.NewButtonWithIcon("Сохранить правила", theme.DocumentSaveIcon(), func() {
+		policyID := 0
+		if i := policySelect.SelectedIndex(); i > 0 {
+			policyID = policies[i-1].ID
+		}
+		if err := tariffService.SetCancellationPolicy(tariff.ID, policyID); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		dialog.ShowInformation("Успешно", "Правила отмены сохранены", window)
+	})
+
+	return widget.NewCard("Правила отмены", "Настраиваются кнопкой «📜 Правила отмены»",
+		container.NewVBox(policySelect, terms, save))
+}
@@ -0,0 +1,267 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+// discountKinds — виды скидок в порядке показа
+var discountKinds = []string{models.DiscountPercent, models.DiscountFixed}
+
+// discountKindTitle возвращает название вида скидки
+func discountKindTitle(kind string) string {
+	if kind == models.DiscountFixed {
+		return "Сумма на бронь, руб."
+	}
+	return "Процент"
+}
+
+// ShowDiscountsDialog показывает промокоды: размер скидки, срок действия,
+// лимит использований и тарифы
+func ShowDiscountsDialog(tariffService *service.TariffService, window fyne.Window) {
+	list := container.NewVBox()
+
+	var reload func()
+	reload = func() {
+		list.RemoveAll()
+		tariffs, err := tariffService.GetTariffs()
+		if err != nil {
+			list.Add(widget.NewLabel(err.Error()))
+			return
+		}
+		discounts, err := tariffService.GetDiscounts()
+		if err != nil {
+			list.Add(widget.NewLabel(err.Error()))
+			return
+		}
+		if len(discounts) == 0 {
+			list.Add(widget.NewLabel("Промокодов нет"))
+		}
+		for _, d := range discounts {
+			d := d
+			list.Add(container.NewBorder(nil, nil, nil,
+				container.NewHBox(
+					widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+						showDiscountForm(tariffService, tariffs, d, window, reload)
+					}),
+					widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+						dialog.ShowConfirm("Подтверждение", fmt.Sprintf("Удалить промокод «%s»?", d.Code), func(ok bool) {
+							if !ok {
+								return
+							}
+							if err := tariffService.DeleteDiscount(d.ID); err != nil {
+								dialog.ShowError(err, window)
+								return
+							}
+							reload()
+						}, window)
+					}),
+				),
+				widget.NewLabel(formatDiscount(d, tariffs)),
+			))
+		}
+	}
+	reload()
+
+	addBtn := widget.NewButtonWithIcon("Добавить промокод", theme.ContentAddIcon(), func() {
+		tariffs, err := tariffService.GetTariffs()
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		showDiscountForm(tariffService, tariffs,
+			models.Discount{Kind: models.DiscountPercent, Active: true}, window, reload)
+	})
+
+	content := container.NewBorder(
+		widget.NewLabel("Промокод применяется при создании брони, скидка хранится в брони отдельно"),
+		addBtn, nil, nil,
+		container.NewVScroll(list),
+	)
+
+	d := dialog.NewCustom("Промокоды", "Закрыть", content, window)
+	d.Resize(fyne.NewSize(650, 600))
+	d.Show()
+}
+
+// showDiscountForm создает или изменяет промокод
+func showDiscountForm(tariffService *service.TariffService, tariffs []models.Tariff, discount models.Discount, window fyne.Window, onDone func()) {
+	codeEntry := widget.NewEntry()
+	codeEntry.SetText(discount.Code)
+	codeEntry.PlaceHolder = "Например: LETO10"
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(discount.Name)
+
+	kindOptions := make([]string, len(discountKinds))
+	for i, k := range discountKinds {
+		kindOptions[i] = discountKindTitle(k)
+	}
+	kindSelect := widget.NewSelect(kindOptions, nil)
+	kindSelect.SetSelected(discountKindTitle(discount.Kind))
+
+	valueEntry := widget.NewEntry()
+	if discount.Kind == models.DiscountFixed && discount.FixedAmount.IsPositive() {
+		valueEntry.SetText(discount.FixedAmount.Decimal())
+	} else if discount.Value > 0 {
+		valueEntry.SetText(formatPercent(discount.Value))
+	}
+
+	// Пустая дата («Очистить» в календаре) — без ограничения
+	fromPicker := NewDatePickerButton("Начало", window, func(t time.Time) {
+		discount.ValidFrom = t
+	})
+	fromPicker.SetSelectedDate(discount.ValidFrom)
+	toPicker := NewDatePickerButton("Конец", window, func(t time.Time) {
+		discount.ValidTo = t
+	})
+	toPicker.SetSelectedDate(discount.ValidTo)
+
+	maxUsesEntry := widget.NewEntry()
+	maxUsesEntry.PlaceHolder = "без ограничения"
+	if discount.MaxUses > 0 {
+		maxUsesEntry.SetText(strconv.Itoa(discount.MaxUses))
+	}
+
+	tariffNames := make([]string, len(tariffs))
+	for i, t := range tariffs {
+		tariffNames[i] = t.Name
+	}
+	tariffCheck := widget.NewCheckGroup(tariffNames, nil)
+	var selected []string
+	for _, t := range tariffs {
+		if discount.AppliesTo(t.ID) && len(discount.TariffIDs) > 0 {
+			selected = append(selected, t.Name)
+		}
+	}
+	tariffCheck.SetSelected(selected)
+
+	activeCheck := widget.NewCheck("Промокод включен", nil)
+	activeCheck.SetChecked(discount.Active)
+
+	items := []*widget.FormItem{
+		{Text: "Промокод *", Widget: codeEntry},
+		{Text: "Описание", Widget: nameEntry},
+		{Text: "Вид скидки", Widget: kindSelect},
+		{Text: "Размер *", Widget: valueEntry},
+		{Text: "Действует с", Widget: fromPicker.button},
+		{Text: "Действует по", Widget: toPicker.button},
+		{Text: "Лимит броней", Widget: maxUsesEntry},
+		{Text: "Тарифы (пусто — все)", Widget: tariffCheck},
+		{Text: "", Widget: activeCheck},
+	}
+	if discount.ID != 0 {
+		items = append(items, widget.NewFormItem("Использован", widget.NewLabel(strconv.Itoa(discount.UsedCount))))
+	}
+
+	dialog.ShowForm("Промокод", "Сохранить", "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		kind := discountKinds[0]
+		if i := kindSelect.SelectedIndex(); i >= 0 {
+			kind = discountKinds[i]
+		}
+		discount.Value, discount.FixedAmount = 0, models.NewMoney(0)
+		var err error
+		if kind == models.DiscountFixed {
+			discount.FixedAmount, err = models.ParseMoney(valueEntry.Text)
+		} else {
+			discount.Value, err = strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(valueEntry.Text), ",", "."), 64)
+		}
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("неверный размер скидки"), window)
+			return
+		}
+		maxUses := 0
+		if s := strings.TrimSpace(maxUsesEntry.Text); s != "" {
+			if maxUses, err = strconv.Atoi(s); err != nil {
+				dialog.ShowError(fmt.Errorf("неверный лимит броней"), window)
+				return
+			}
+		}
+
+		discount.Code = codeEntry.Text
+		discount.Name = nameEntry.Text
+		discount.Kind = kind
+		discount.MaxUses = maxUses
+		discount.Active = activeCheck.Checked
+		discount.TariffIDs = nil
+		for _, t := range tariffs {
+			for _, name := range tariffCheck.Selected {
+				if name == t.Name {
+					discount.TariffIDs = append(discount.TariffIDs, t.ID)
+					break
+				}
+			}
+		}
+
+		if _, err := tariffService.SaveDiscount(discount); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		onDone()
+	}, window)
+}
+
+// formatDiscount описывает промокод одной строкой
+func formatDiscount(d models.Discount, tariffs []models.Tariff) string {
+	parts := []string{d.Code + " " + d.Title()}
+	if d.Name != "" {
+		parts = append(parts, d.Name)
+	}
+
+	switch {
+	case d.ValidFrom.IsZero() && d.ValidTo.IsZero():
+		parts = append(parts, "бессрочно")
+	case d.ValidTo.IsZero():
+		parts = append(parts, "с "+d.ValidFrom.Format("02.01.2006"))
+	case d.ValidFrom.IsZero():
+		parts = append(parts, "по "+d.ValidTo.Format("02.01.2006"))
+	default:
+		parts = append(parts, d.ValidFrom.Format("02.01.2006")+" – "+d.ValidTo.Format("02.01.2006"))
+	}
+
+	if d.MaxUses > 0 {
+		parts = append(parts, fmt.Sprintf("использован %d из %d", d.UsedCount, d.MaxUses))
+	} else {
+		parts = append(parts, fmt.Sprintf("использован %d", d.UsedCount))
+	}
+
+	if len(d.TariffIDs) > 0 {
+		var names []string
+		for _, t := range tariffs {
+			if d.AppliesTo(t.ID) {
+				names = append(names, t.Name)
+			}
+		}
+		parts = append(parts, "тарифы: "+strings.Join(names, ", "))
+	}
+	if !d.Active {
+		parts = append(parts, "отключен")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// formatBookingCost описывает стоимость брони со скидкой по промокоду
+// и услугами по фолио
+func formatBookingCost(booking models.Booking) string {
+	text := booking.TotalCost.String()
+	if booking.DiscountAmount.IsPositive() {
+		text = fmt.Sprintf("%s (скидка %s по промокоду %s)",
+			booking.TotalCost, booking.DiscountAmount, booking.DiscountCode)
+	}
+	if booking.ExtrasTotal.IsPositive() {
+		text += fmt.Sprintf(" + услуги %s = %s", booking.ExtrasTotal, booking.Total())
+	}
+	return text
+}
@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+// ShowExtrasDialog показывает справочник платных услуг, которые можно
+// добавить в фолио брони
+func ShowExtrasDialog(tariffService *service.TariffService, window fyne.Window) {
+	list := container.NewVBox()
+
+	var reload func()
+	reload = func() {
+		list.RemoveAll()
+		extras, err := tariffService.GetExtras()
+		if err != nil {
+			list.Add(widget.NewLabel(err.Error()))
+			return
+		}
+		if len(extras) == 0 {
+			list.Add(widget.NewLabel("Услуг пока нет"))
+		}
+		for _, e := range extras {
+			e := e
+			list.Add(container.NewBorder(nil, nil, nil,
+				container.NewHBox(
+					widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+						showExtraForm(tariffService, e, window, reload)
+					}),
+					widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+						dialog.ShowConfirm("Подтверждение",
+							fmt.Sprintf("Удалить услугу «%s»? Уже заказанные услуги останутся в броне.", e.Name),
+							func(ok bool) {
+								if !ok {
+									return
+								}
+								if err := tariffService.DeleteExtra(e.ID); err != nil {
+									dialog.ShowError(err, window)
+									return
+								}
+								reload()
+							}, window)
+					}),
+				),
+				widget.NewLabel(formatExtra(e)),
+			))
+		}
+	}
+	reload()
+
+	addBtn := widget.NewButtonWithIcon("Добавить услугу", theme.ContentAddIcon(), func() {
+		showExtraForm(tariffService, models.Extra{Active: true}, window, reload)
+	})
+
+	content := container.NewBorder(
+		widget.NewLabel("Услуги добавляются в бронь на вкладке «Услуги» и входят в ее стоимость"),
+		addBtn, nil, nil,
+		container.NewVScroll(list),
+	)
+
+	d := dialog.NewCustom("Услуги", "Закрыть", content, window)
+	d.Resize(fyne.NewSize(650, 500))
+	d.Show()
+}
+
+// formatExtra описывает услугу справочника
+func formatExtra(e models.Extra) string {
+	text := fmt.Sprintf("🛎 %s — %s", e.Name, e.Price)
+	if e.Unit != "" {
+		text += " за " + e.Unit
+	}
+	if !e.Active {
+		text += " · снята с продажи"
+	}
+	return text
+}
+
+// showExtraForm создает или изменяет услугу справочника
+func showExtraForm(tariffService *service.TariffService, extra models.Extra, window fyne.Window, onDone func()) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(extra.Name)
+	nameEntry.PlaceHolder = "Например: Баня"
+
+	unitEntry := widget.NewEntry()
+	unitEntry.SetText(extra.Unit)
+	unitEntry.PlaceHolder = "шт., час, сутки"
+
+	priceEntry := widget.NewEntry()
+	priceEntry.SetText(extra.Price.Decimal())
+
+	activeCheck := widget.NewCheck("В продаже", nil)
+	activeCheck.SetChecked(extra.Active)
+
+	items := []*widget.FormItem{
+		{Text: "Название *", Widget: nameEntry},
+		{Text: "Единица", Widget: unitEntry},
+		{Text: "Цена, руб.", Widget: priceEntry},
+		{Text: "", Widget: activeCheck},
+	}
+
+	dialog.ShowForm("Услуга", "Сохранить", "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		price, err := models.ParseMoney(priceEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		extra.Name = nameEntry.Text
+		extra.Unit = unitEntry.Text
+		extra.Price = price
+		extra.Active = activeCheck.Checked
+		if _, err := tariffService.SaveExtra(extra); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		onDone()
+	}, window)
+}
+
+// newFolioCard показывает фолио брони — заказанные гостем услуги —
+// с кнопками добавления и удаления. onChange вызывается после каждого
+// изменения фолио.
+func newFolioCard(bookingService *service.BookingService, tariffService *service.TariffService, booking *models.Booking, window fyne.Window, onChange func()) fyne.CanvasObject {
+	rows := container.NewVBox()
+	editable := booking.Status == models.BookingStatusBooked || booking.Status == models.BookingStatusCheckedIn ||
+		booking.Status == models.BookingStatusCompleted
+
+	var refresh func()
+	refresh = func() {
+		rows.RemoveAll()
+
+		items, err := bookingService.GetFolio(booking.ID)
+		if err != nil {
+			rows.Add(widget.NewLabel("❌ " + err.Error()))
+			return
+		}
+		if len(items) == 0 {
+			rows.Add(widget.NewLabel("Услуг не заказано"))
+		}
+
+		changed := func() {
+			refresh()
+			onChange()
+		}
+		total := models.NewMoney(0)
+		for _, item := range items {
+			item := item
+			total = total.Add(item.Amount())
+			label := widget.NewLabel(formatFolioItem(item))
+			if !editable {
+				rows.Add(label)
+				continue
+			}
+			rows.Add(container.NewBorder(nil, nil, nil,
+				widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+					dialog.ShowConfirm("Подтверждение", fmt.Sprintf("Удалить услугу «%s» из брони?", item.Title),
+						func(ok bool) {
+							if !ok {
+								return
+							}
+							if err := bookingService.RemoveFolioItem(item.ID); err != nil {
+								dialog.ShowError(err, window)
+								return
+							}
+							changed()
+						}, window)
+				}),
+				label,
+			))
+		}
+
+		if len(items) > 0 {
+			totalLabel := widget.NewLabel(fmt.Sprintf("Итого услуги: %s", total))
+			totalLabel.TextStyle = fyne.TextStyle{Bold: true}
+			rows.Add(totalLabel)
+		}
+		if editable {
+			rows.Add(widget.NewButtonWithIcon("Добавить услугу", theme.ContentAddIcon(), func() {
+				showFolioItemForm(bookingService, tariffService, booking, window, changed)
+			}))
+		}
+	}
+	refresh()
+
+	return widget.NewCard("🛎 Услуги", "", rows)
+}
+
+// formatFolioItem описывает строку фолио
+func formatFolioItem(item models.FolioItem) string {
+	return fmt.Sprintf("%s  %s  %d × %s = %s", item.ServiceDate.Format("02.01.2006"), item.Title,
+		item.Quantity, item.UnitPrice, item.Amount())
+}
+
+// showFolioItemForm добавляет услугу из справочника в фолио брони;
+// цена подставляется из справочника и может быть изменена
+func showFolioItemForm(bookingService *service.BookingService, tariffService *service.TariffService, booking *models.Booking, window fyne.Window, onDone func()) {
+	all, err := tariffService.GetExtras()
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	var extras []models.Extra
+	for _, e := range all {
+		if e.Active {
+			extras = append(extras, e)
+		}
+	}
+	if len(extras) == 0 {
+		dialog.ShowInformation("Услуги", "В справочнике нет услуг в продаже. Добавьте их на вкладке тарифов.", window)
+		return
+	}
+
+	priceEntry := widget.NewEntry()
+	options := make([]string, len(extras))
+	for i, e := range extras {
+		options[i] = formatExtra(e)
+	}
+	var extraSelect *widget.Select
+	extraSelect = widget.NewSelect(options, func(string) {
+		if i := extraSelect.SelectedIndex(); i >= 0 {
+			priceEntry.SetText(extras[i].Price.Decimal())
+		}
+	})
+	extraSelect.SetSelectedIndex(0)
+
+	quantityEntry := widget.NewEntry()
+	quantityEntry.SetText("1")
+
+	// День оказания по умолчанию — сегодня, если гость уже живет, иначе день заезда
+	serviceDate := time.Now()
+	if booking.Status == models.BookingStatusBooked {
+		serviceDate = booking.CheckInDate
+	}
+	datePicker := NewDatePickerButton("Дата", window, func(t time.Time) {
+		serviceDate = t
+	})
+	datePicker.SetSelectedDate(serviceDate)
+
+	items := []*widget.FormItem{
+		{Text: "Услуга", Widget: extraSelect},
+		{Text: "Количество", Widget: quantityEntry},
+		{Text: "Цена, руб.", Widget: priceEntry},
+		{Text: "Дата", Widget: datePicker},
+	}
+
+	dialog.ShowForm("Добавить услугу", "Добавить", "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		quantity, err := strconv.Atoi(strings.TrimSpace(quantityEntry.Text))
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("неверное количество"), window)
+			return
+		}
+		price, err := models.ParseMoney(priceEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		item := models.FolioItem{
+			BookingID:   booking.ID,
+			ExtraID:     extras[extraSelect.SelectedIndex()].ID,
+			Quantity:    quantity,
+			UnitPrice:   price,
+			ServiceDate: serviceDate,
+		}
+		if _, err := bookingService.AddFolioItem(item); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		onDone()
+	}, window)
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// maxGuestsChoice — сколько гостей каждого вида можно выбрать в форме;
+// вместимость конкретного домика проверяет сервис броней
+const maxGuestsChoice = 12
+
+// GuestCountPicker — выбор числа взрослых и детей в брони
+type GuestCountPicker struct {
+	adults   *widget.Select
+	children *widget.Select
+}
+
+// NewGuestCountPicker создает выбор состава гостей; onChange вызывается
+// при каждом изменении
+func NewGuestCountPicker(adults, children int, onChange func()) *GuestCountPicker {
+	adultOptions := make([]string, maxGuestsChoice)
+	for i := range adultOptions {
+		adultOptions[i] = strconv.Itoa(i + 1)
+	}
+	childOptions := make([]string, maxGuestsChoice+1)
+	for i := range childOptions {
+		childOptions[i] = strconv.Itoa(i)
+	}
+
+	p := &GuestCountPicker{
+		adults:   widget.NewSelect(adultOptions, nil),
+		children: widget.NewSelect(childOptions, nil),
+	}
+	if adults < 1 {
+		adults = 1
+	}
+	p.adults.SetSelected(strconv.Itoa(adults))
+	p.children.SetSelected(strconv.Itoa(children))
+
+	changed := func(string) {
+		if onChange != nil {
+			onChange()
+		}
+	}
+	p.adults.OnChanged = changed
+	p.children.OnChanged = changed
+	return p
+}
+
+// Adults возвращает выбранное число взрослых
+func (p *GuestCountPicker) Adults() int {
+	n, _ := strconv.Atoi(p.adults.Selected)
+	return n
+}
+
+// Children возвращает выбранное число детей
+func (p *GuestCountPicker) Children() int {
+	n, _ := strconv.Atoi(p.children.Selected)
+	return n
+}
+
+// Widget возвращает поля выбора одной строкой
+func (p *GuestCountPicker) Widget() fyne.CanvasObject {
+	return container.NewHBox(
+		widget.NewLabel("Взрослых"), p.adults,
+		widget.NewLabel("Детей"), p.children,
+	)
+}
+
+// formatGuests описывает состав гостей брони
+func formatGuests(booking models.Booking) string {
+	if booking.Guests() == 0 {
+		return "не указано"
+	}
+	if booking.Children == 0 {
+		return fmt.Sprintf("%d взр.", booking.Adults)
+	}
+	return fmt.Sprintf("%d взр. + %d реб.", booking.Adults, booking.Children)
+}
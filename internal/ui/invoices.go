@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+// newInvoicesCard показывает выставленные по брони счета-квитанции
+// с кнопками открытия PDF и выставления нового счета
+func newInvoicesCard(invoiceService *service.InvoiceService, booking *models.Booking, window fyne.Window) fyne.CanvasObject {
+	rows := container.NewVBox()
+
+	var refresh func()
+	refresh = func() {
+		rows.RemoveAll()
+
+		invoices, err := invoiceService.GetInvoices(booking.ID)
+		if err != nil {
+			rows.Add(widget.NewLabel("❌ " + err.Error()))
+			return
+		}
+		if len(invoices) == 0 {
+			rows.Add(widget.NewLabel("Счета еще не выставлялись"))
+		}
+		for _, inv := range invoices {
+			inv := inv
+			label := widget.NewLabel(fmt.Sprintf("№ %s от %s — %s", inv.Number, inv.IssuedAt.Format("02.01.2006"), inv.Total))
+			rows.Add(container.NewBorder(nil, nil, nil, widget.NewButton("Открыть", func() {
+				openInvoice(inv, window)
+			}), label))
+		}
+	}
+	refresh()
+
+	issue := widget.NewButton("🧾 Выставить счет", func() {
+		issueInvoice(invoiceService, booking.ID, "", window, refresh)
+	})
+	return widget.NewCard("🧾 Счета", "", container.NewVBox(rows, issue))
+}
+
+// issueInvoice выставляет счет по брони и предлагает открыть его.
+// done — уже выполненное действие, после которого выставляется счет
+// (например, «Гость выселен»); оно упоминается и в сообщении об ошибке.
+// onDone вызывается после выставления счета, если задан.
+func issueInvoice(invoiceService *service.InvoiceService, bookingID int, done string, window fyne.Window, onDone func()) {
+	inv, err := invoiceService.IssueInvoice(bookingID)
+	if err != nil {
+		if done != "" {
+			err = fmt.Errorf("%s, но счет не выставлен: %w", strings.ToLower(done), err)
+		}
+		dialog.ShowError(err, window)
+		return
+	}
+	if onDone != nil {
+		onDone()
+	}
+
+	text := fmt.Sprintf("Счет-квитанция № %s сохранена", inv.Number)
+	if done != "" {
+		text = done + "\n" + text
+	}
+	dialog.ShowCustomConfirm("Успешно", "Открыть счет", "Закрыть", widget.NewLabel(text), func(open bool) {
+		if open {
+			openInvoice(*inv, window)
+		}
+	}, window)
+}
+
+// openInvoice открывает PDF счета в программе просмотра по умолчанию
+func openInvoice(inv models.Invoice, window fyne.Window) {
+	path, err := filepath.Abs(inv.FilePath)
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	u, err := url.Parse(storage.NewFileURI(path).String())
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	if err := fyne.CurrentApp().OpenURL(u); err != nil {
+		dialog.ShowError(fmt.Errorf("не удалось открыть счет %s: %w", inv.Number, err), window)
+	}
+}
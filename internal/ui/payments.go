@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+// lastOperator — кто принимал последнюю оплату; подставляется в следующую
+var lastOperator string
+
+// newPaymentsCard показывает расчеты с гостем по брони: предоплату, оплаты
+// и остаток, с кнопками приема оплаты и возврата. onChange вызывается после
+// каждого изменения журнала.
+func newPaymentsCard(bookingService *service.BookingService, booking *models.Booking, window fyne.Window, onChange func()) fyne.CanvasObject {
+	rows := container.NewVBox()
+	actions := container.NewHBox()
+
+	var refresh func()
+	refresh = func() {
+		rows.RemoveAll()
+		actions.RemoveAll()
+
+		summary, err := bookingService.GetPaymentSummary(booking.ID)
+		if err != nil {
+			rows.Add(widget.NewLabel("❌ " + err.Error()))
+			return
+		}
+		payments, err := bookingService.GetPayments(booking.ID)
+		if err != nil {
+			rows.Add(widget.NewLabel("❌ " + err.Error()))
+			return
+		}
+
+		deposit := fmt.Sprintf("Предоплата: %s", summary.Deposit)
+		if due := summary.DepositDue(); due.IsPositive() {
+			deposit += fmt.Sprintf(" (не внесено %s)", due)
+		} else if summary.Deposit.IsPositive() {
+			deposit += " ✅"
+		}
+		if booking.Status != models.BookingStatusCancelled {
+			rows.Add(widget.NewLabel(deposit))
+		}
+		if summary.Penalty.IsPositive() {
+			rows.Add(widget.NewLabel(fmt.Sprintf("Штрафы по правилам отмены: %s", summary.Penalty)))
+		}
+		rows.Add(widget.NewLabel(fmt.Sprintf("Оплачено: %s", summary.Paid)))
+
+		balance := widget.NewLabel(formatBalance(summary.Balance()))
+		balance.TextStyle = fyne.TextStyle{Bold: true}
+		rows.Add(balance)
+
+		for _, p := range payments {
+			rows.Add(widget.NewLabel(formatPayment(p)))
+		}
+
+		changed := func() {
+			refresh()
+			onChange()
+		}
+		active := booking.Status == models.BookingStatusBooked || booking.Status == models.BookingStatusCheckedIn
+		owes := booking.Status == models.BookingStatusCancelled && summary.Balance().IsPositive()
+		if active || owes || booking.Status == models.BookingStatusCompleted {
+			actions.Add(widget.NewButton("💳 Принять оплату", func() {
+				showPaymentForm(bookingService, booking.ID, false, summary.Balance(), window, changed)
+			}))
+		}
+		if summary.Paid.IsPositive() {
+			actions.Add(widget.NewButton("↩ Возврат", func() {
+				showPaymentForm(bookingService, booking.ID, true, summary.Balance().Neg(), window, changed)
+			}))
+		}
+		if active {
+			actions.Add(widget.NewButton("Изменить предоплату", func() {
+				showDepositForm(bookingService, booking, summary.Deposit, window, changed)
+			}))
+		}
+	}
+	refresh()
+
+	return widget.NewCard("💰 Оплата", "", container.NewVBox(rows, actions))
+}
+
+// formatBalance описывает остаток к оплате или переплату гостя
+func formatBalance(balance models.Money) string {
+	switch {
+	case balance.IsPositive():
+		return fmt.Sprintf("Остаток к оплате: %s", balance)
+	case balance.IsNegative():
+		return fmt.Sprintf("Переплата: %s", balance.Neg())
+	default:
+		return "Оплачено полностью ✅"
+	}
+}
+
+// formatPayment описывает строку журнала оплат
+func formatPayment(p models.Payment) string {
+	sign := "+"
+	if p.Amount.IsNegative() {
+		sign = ""
+	}
+	text := fmt.Sprintf("%s  %s  %s%s", p.PaidAt.Format("02.01.2006 15:04"), models.PaymentMethodTitle(p.Method), sign, p.Amount)
+	switch {
+	case p.IsRefund():
+		text = "↩ " + text
+	case p.IsPenalty():
+		text = fmt.Sprintf("⚠ %s  штраф %s", p.PaidAt.Format("02.01.2006 15:04"), p.Amount)
+	}
+	if p.Operator != "" {
+		text += " — " + p.Operator
+	}
+	if p.Note != "" {
+		text += " (" + p.Note + ")"
+	}
+	return text
+}
+
+// showPaymentForm принимает оплату или оформляет возврат; suggested —
+// сумма, подставляемая в форму, если она положительна
+func showPaymentForm(bookingService *service.BookingService, bookingID int, refund bool, suggested models.Money, window fyne.Window, onDone func()) {
+	amountEntry := widget.NewEntry()
+	if suggested.IsPositive() {
+		amountEntry.SetText(suggested.Decimal())
+	}
+
+	methodOptions := make([]string, len(models.PaymentMethods))
+	for i, m := range models.PaymentMethods {
+		methodOptions[i] = models.PaymentMethodTitle(m)
+	}
+	methodSelect := widget.NewSelect(methodOptions, nil)
+	methodSelect.SetSelectedIndex(0)
+
+	operatorEntry := widget.NewEntry()
+	operatorEntry.SetText(lastOperator)
+	operatorEntry.PlaceHolder = "Кто принял"
+
+	noteEntry := widget.NewEntry()
+
+	items := []*widget.FormItem{
+		{Text: "Сумма, руб. *", Widget: amountEntry},
+		{Text: "Способ", Widget: methodSelect},
+		{Text: "Оператор", Widget: operatorEntry},
+		{Text: "Примечание", Widget: noteEntry},
+	}
+
+	title, confirm := "Оплата брони", "Принять"
+	if refund {
+		title, confirm = "Возврат гостю", "Вернуть"
+	}
+
+	dialog.ShowForm(title, confirm, "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		amount, err := models.ParseMoney(amountEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		payment := models.Payment{
+			BookingID: bookingID,
+			Amount:    amount,
+			Method:    models.PaymentMethods[0],
+			Operator:  operatorEntry.Text,
+			Note:      noteEntry.Text,
+		}
+		if i := methodSelect.SelectedIndex(); i >= 0 {
+			payment.Method = models.PaymentMethods[i]
+		}
+
+		if refund {
+			_, err = bookingService.RegisterRefund(payment)
+		} else {
+			_, err = bookingService.RegisterPayment(payment)
+		}
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		lastOperator = strings.TrimSpace(operatorEntry.Text)
+		onDone()
+	}, window)
+}
+
+// showDepositForm меняет требуемую предоплату брони
+func showDepositForm(bookingService *service.BookingService, booking *models.Booking, current models.Money, window fyne.Window, onDone func()) {
+	amountEntry := widget.NewEntry()
+	amountEntry.SetText(current.Decimal())
+
+	items := []*widget.FormItem{
+		{Text: "Предоплата, руб.", Widget: amountEntry},
+		{Text: "", Widget: widget.NewLabel(fmt.Sprintf("Стоимость брони: %s", booking.TotalCost))},
+	}
+
+	dialog.ShowForm("Предоплата", "Сохранить", "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		amount, err := models.ParseMoney(amountEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if err := bookingService.SetDeposit(booking.ID, amount); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		onDone()
+	}, window)
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/VallfIK/bazaotdx/internal/models"
+)
+
+// FormatPriceQuote описывает стоимость по ночам. Подряд идущие ночи
+// с одинаковой ценой и основанием объединяются в одну строку.
+func FormatPriceQuote(quote *models.PriceQuote) string {
+	if quote == nil || len(quote.Nights) == 0 {
+		return ""
+	}
+
+	var lines []string
+	for i := 0; i < len(quote.Nights); {
+		night := quote.Nights[i]
+		j := i + 1
+		for j < len(quote.Nights) && sameNightPrice(quote.Nights[j], night) {
+			j++
+		}
+
+		dates := night.Date.Format("02.01")
+		if j-i > 1 {
+			dates += "–" + quote.Nights[j-1].Date.Format("02.01")
+		}
+		line := fmt.Sprintf("%s: %d × %s = %s · %s", dates, j-i, night.Price.Decimal(),
+			night.Price.Mul(j-i), night.RateName)
+		if night.Surcharge != 0 {
+			line += fmt.Sprintf(", %s %+.0f%%", night.SurchargeName, night.Surcharge)
+		}
+		if !night.ExtraGuests.IsZero() {
+			line += ", доп. гости +" + night.ExtraGuests.Decimal()
+		}
+		lines = append(lines, line)
+		i = j
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sameNightPrice сообщает, одинаково ли посчитаны две ночи
+func sameNightPrice(a, b models.NightPrice) bool {
+	return a.Price.Cmp(b.Price) == 0 && a.RateName == b.RateName && a.SurchargeName == b.SurchargeName &&
+		a.ExtraGuests.Cmp(b.ExtraGuests) == 0
+}
@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/export"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+// ShowOccupancyReportDialog показывает отчет о заполняемости домиков
+// за выбранный период (по умолчанию — текущий месяц) с выгрузкой в CSV
+// и Excel
+func ShowOccupancyReportDialog(reportService *service.ReportService, window fyne.Window) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 1, -1)
+
+	showReportDialog("📈 Заполняемость", from, to, window, func(from, to time.Time) ([]export.Table, error) {
+		report, err := reportService.Occupancy(from, to)
+		if err != nil {
+			return nil, err
+		}
+		return export.OccupancyTables(report), nil
+	})
+}
+
+// ShowRevenueReportDialog показывает выручку, ADR, RevPAR, среднюю длину
+// проживания, срок бронирования и отмены за выбранный период (по умолчанию —
+// текущий месяц) с разбивкой по месяцам, дням, домикам и тарифам
+func ShowRevenueReportDialog(reportService *service.ReportService, window fyne.Window) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 1, -1)
+
+	showReportDialog("💰 Выручка", from, to, window, func(from, to time.Time) ([]export.Table, error) {
+		report, err := reportService.Revenue(from, to)
+		if err != nil {
+			return nil, err
+		}
+		return export.RevenueTables(report), nil
+	})
+}
+
+// ShowBookingsReportDialog показывает брони за выбранный период (по
+// умолчанию — текущий месяц) с отбором по статусу, а также гостей и
+// тарифы — для выгрузки в CSV и Excel
+func ShowBookingsReportDialog(bookingService *service.BookingService, guestService *service.GuestService,
+	cottageService *service.CottageService, tariffService *service.TariffService, window fyne.Window) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 1, -1)
+
+	statuses := []string{
+		"",
+		models.BookingStatusBooked,
+		models.BookingStatusCheckedIn,
+		models.BookingStatusCompleted,
+		models.BookingStatusCancelled,
+	}
+	options := make([]string, len(statuses))
+	options[0] = "Все"
+	for i, status := range statuses[1:] {
+		options[i+1] = models.BookingStatusTitle(status)
+	}
+
+	var refresh func()
+	statusSelect := widget.NewSelect(options, func(string) {
+		if refresh != nil {
+			refresh()
+		}
+	})
+	statusSelect.SetSelectedIndex(0)
+
+	refresh = showReportDialog("📋 Брони", from, to, window, func(from, to time.Time) ([]export.Table, error) {
+		status := ""
+		if i := statusSelect.SelectedIndex(); i > 0 {
+			status = statuses[i]
+		}
+		bookings, err := bookingService.GetBookingsForPeriod(from, to, status)
+		if err != nil {
+			return nil, err
+		}
+		guests, err := guestService.GetGuests()
+		if err != nil {
+			return nil, err
+		}
+		cottages, err := cottageService.GetAllCottages()
+		if err != nil {
+			return nil, err
+		}
+		tariffs, err := tariffService.GetTariffs()
+		if err != nil {
+			return nil, err
+		}
+		return []export.Table{
+			export.BookingsTable(bookings, cottages, tariffs),
+			export.GuestsTable(guests, cottages, tariffs),
+			export.TariffsTable(tariffs),
+		}, nil
+	}, statusSelect)
+}
+
+// showReportDialog показывает отчет, построенный build за период, на
+// вкладках — по таблице на вкладку — с выбором периода и выгрузкой в CSV
+// и Excel. filters — дополнительные отборы рядом с периодом; возвращает
+// функцию, перестраивающую отчет после их изменения.
+func showReportDialog(title string, from, to time.Time, window fyne.Window,
+	build func(from, to time.Time) ([]export.Table, error), filters ...fyne.CanvasObject) func() {
+	var tables []export.Table
+	tabs := container.NewAppTabs()
+	body := container.NewStack()
+
+	refresh := func() {
+		var err error
+		tables, err = build(from, to)
+		if err != nil {
+			body.Objects = []fyne.CanvasObject{widget.NewLabel("❌ " + err.Error())}
+			body.Refresh()
+			return
+		}
+		items := make([]*container.TabItem, len(tables))
+		for i, t := range tables {
+			items[i] = container.NewTabItem(t.Title, newReportTable(t))
+		}
+		tabs.SetItems(items)
+		body.Objects = []fyne.CanvasObject{tabs}
+		body.Refresh()
+	}
+
+	fromPicker := NewDatePickerButton("С", window, func(t time.Time) {
+		if !t.IsZero() {
+			from = t
+			refresh()
+		}
+	})
+	fromPicker.SetSelectedDate(from)
+	toPicker := NewDatePickerButton("По", window, func(t time.Time) {
+		if !t.IsZero() {
+			to = t
+			refresh()
+		}
+	})
+	toPicker.SetSelectedDate(to)
+
+	fileName := func(ext string) string {
+		return fmt.Sprintf("%s_%s_%s%s", reportFileName(title), from.Format("2006-01-02"), to.Format("2006-01-02"), ext)
+	}
+	csvBtn := widget.NewButton("💾 Экспорт в CSV", func() {
+		saveReport(tables, fileName(".csv"), export.WriteCSV, window)
+	})
+	xlsxBtn := widget.NewButton("📗 Экспорт в Excel", func() {
+		saveReport(tables, fileName(".xlsx"), export.WriteXLSX, window)
+	})
+
+	refresh()
+
+	toolbar := container.NewHBox(fromPicker, toPicker)
+	for _, f := range filters {
+		toolbar.Add(f)
+	}
+	toolbar.Add(csvBtn)
+	toolbar.Add(xlsxBtn)
+
+	content := container.NewBorder(toolbar, nil, nil, nil, body)
+	d := dialog.NewCustom(title, "Закрыть", content, window)
+	d.Resize(fyne.NewSize(800, 600))
+	d.Show()
+	return refresh
+}
+
+// saveReport спрашивает, куда сохранить таблицы отчета, и записывает их
+// функцией write в файл с предложенным именем name
+func saveReport(tables []export.Table, name string, write func(io.Writer, ...export.Table) error, window fyne.Window) {
+	if len(tables) == 0 {
+		return
+	}
+	save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if w == nil {
+			return
+		}
+		defer w.Close()
+		if err := write(w, tables...); err != nil {
+			dialog.ShowError(fmt.Errorf("не удалось сохранить отчет: %w", err), window)
+			return
+		}
+		dialog.ShowInformation("Успешно", "Отчет сохранен: "+w.URI().Name(), window)
+	}, window)
+	save.SetFileName(name)
+	save.SetFilter(storage.NewExtensionFileFilter([]string{filepath.Ext(name)}))
+	save.Show()
+}
+
+// newReportTable показывает таблицу отчета с заголовками столбцов
+func newReportTable(t export.Table) *widget.Table {
+	table := widget.NewTableWithHeaders(
+		func() (int, int) { return len(t.Rows), len(t.Columns) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {
+			text := ""
+			if row := t.Rows[id.Row]; id.Col < len(row) {
+				text = export.FormatCell(row[id.Col])
+			}
+			cell.(*widget.Label).SetText(text)
+		},
+	)
+	table.ShowHeaderColumn = false
+	table.UpdateHeader = func(id widget.TableCellID, cell fyne.CanvasObject) {
+		label := cell.(*widget.Label)
+		label.TextStyle = fyne.TextStyle{Bold: true}
+		if id.Row < 0 && id.Col >= 0 && id.Col < len(t.Columns) {
+			label.SetText(t.Columns[id.Col])
+		}
+	}
+	for i, column := range t.Columns {
+		width := float32(120)
+		if i == 0 {
+			width = 180
+		}
+		if w := widget.NewLabel(column).MinSize().Width; w > width {
+			width = w
+		}
+		table.SetColumnWidth(i, width)
+	}
+	return table
+}
+
+// reportFileName убирает из названия отчета эмодзи для имени файла
+func reportFileName(title string) string {
+	var name []rune
+	for _, r := range title {
+		if r > 0xFFFF || r == ' ' && len(name) == 0 {
+			continue
+		}
+		name = append(name, r)
+	}
+	return string(name)
+}
@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+// ShowRestrictionsDialog показывает ограничения сроков брони за год:
+// минимальный срок проживания и запреты заезда и выезда
+func ShowRestrictionsDialog(bookingService *service.BookingService, cottages []models.Cottage, window fyne.Window, onChange func()) {
+	year := time.Now().Year()
+	yearLabel := widget.NewLabel("")
+	list := container.NewVBox()
+
+	var reload func()
+	reload = func() {
+		yearLabel.SetText(fmt.Sprintf("%d год", year))
+		list.RemoveAll()
+		from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+		restrictions, err := bookingService.GetRestrictions(from, from.AddDate(1, 0, -1))
+		if err != nil {
+			list.Add(widget.NewLabel(err.Error()))
+			return
+		}
+		if len(restrictions) == 0 {
+			list.Add(widget.NewLabel("Ограничений нет"))
+		}
+		for _, r := range restrictions {
+			r := r
+			list.Add(container.NewBorder(nil, nil, nil,
+				container.NewHBox(
+					widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+						showRestrictionForm(bookingService, cottages, r, window, func() {
+							reload()
+							if onChange != nil {
+								onChange()
+							}
+						})
+					}),
+					widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+						dialog.ShowConfirm("Подтверждение", "Удалить ограничение?", func(ok bool) {
+							if !ok {
+								return
+							}
+							if err := bookingService.DeleteRestriction(r.ID); err != nil {
+								dialog.ShowError(err, window)
+								return
+							}
+							reload()
+							if onChange != nil {
+								onChange()
+							}
+						}, window)
+					}),
+				),
+				widget.NewLabel(formatRestriction(r, cottages)),
+			))
+		}
+	}
+	reload()
+
+	nav := container.NewHBox(
+		widget.NewButton("◀", func() { year--; reload() }),
+		yearLabel,
+		widget.NewButton("▶", func() { year++; reload() }),
+	)
+
+	addBtn := widget.NewButtonWithIcon("Добавить ограничение", theme.ContentAddIcon(), func() {
+		showRestrictionForm(bookingService, cottages, models.StayRestriction{}, window, func() {
+			reload()
+			if onChange != nil {
+				onChange()
+			}
+		})
+	})
+
+	content := container.NewBorder(
+		container.NewVBox(nav, widget.NewLabel("Минимальный срок считается по дню заезда")),
+		addBtn, nil, nil,
+		container.NewVScroll(list),
+	)
+
+	d := dialog.NewCustom("Ограничения сроков", "Закрыть", content, window)
+	d.Resize(fyne.NewSize(600, 600))
+	d.Show()
+}
+
+// showRestrictionForm создает или изменяет ограничение
+func showRestrictionForm(bookingService *service.BookingService, cottages []models.Cottage, restriction models.StayRestriction, window fyne.Window, onDone func()) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(restriction.Name)
+	nameEntry.PlaceHolder = "Например: Новогодние праздники"
+
+	cottageOptions := []string{"Все домики"}
+	for _, c := range cottages {
+		cottageOptions = append(cottageOptions, c.Name)
+	}
+	cottageSelect := widget.NewSelect(cottageOptions, nil)
+	cottageSelect.SetSelectedIndex(0)
+	for i, c := range cottages {
+		if c.ID == restriction.CottageID {
+			cottageSelect.SetSelectedIndex(i + 1)
+		}
+	}
+
+	startPicker := NewDatePickerButton("Начало", window, func(t time.Time) {
+		restriction.StartDate = t
+	})
+	startPicker.SetSelectedDate(restriction.StartDate)
+	endPicker := NewDatePickerButton("Конец", window, func(t time.Time) {
+		restriction.EndDate = t
+	})
+	endPicker.SetSelectedDate(restriction.EndDate)
+
+	minNightsEntry := widget.NewEntry()
+	minNightsEntry.PlaceHolder = "нет"
+	if restriction.MinNights > 1 {
+		minNightsEntry.SetText(strconv.Itoa(restriction.MinNights))
+	}
+	arrivalCheck := widget.NewCheck("Заезд закрыт", nil)
+	arrivalCheck.SetChecked(restriction.ClosedToArrival)
+	departureCheck := widget.NewCheck("Выезд закрыт", nil)
+	departureCheck.SetChecked(restriction.ClosedToDeparture)
+
+	items := []*widget.FormItem{
+		{Text: "Название", Widget: nameEntry},
+		{Text: "Домик", Widget: cottageSelect},
+		{Text: "Первый день *", Widget: startPicker.button},
+		{Text: "Последний день *", Widget: endPicker.button},
+		{Text: "Минимум ночей", Widget: minNightsEntry},
+		{Text: "", Widget: arrivalCheck},
+		{Text: "", Widget: departureCheck},
+	}
+
+	dialog.ShowForm("Ограничение сроков", "Сохранить", "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		minNights := 0
+		if s := strings.TrimSpace(minNightsEntry.Text); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("неверное число ночей"), window)
+				return
+			}
+			minNights = n
+		}
+
+		restriction.Name = nameEntry.Text
+		restriction.MinNights = minNights
+		restriction.ClosedToArrival = arrivalCheck.Checked
+		restriction.ClosedToDeparture = departureCheck.Checked
+		restriction.CottageID = 0
+		if i := cottageSelect.SelectedIndex(); i > 0 {
+			restriction.CottageID = cottages[i-1].ID
+		}
+
+		if _, err := bookingService.SaveRestriction(restriction); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		onDone()
+	}, window)
+}
+
+// formatRestriction описывает ограничение одной строкой
+func formatRestriction(r models.StayRestriction, cottages []models.Cottage) string {
+	where := "все домики"
+	if r.CottageID != 0 {
+		where = cottageTitle(cottages, r.CottageID)
+	}
+	var rules []string
+	if r.MinNights > 1 {
+		rules = append(rules, fmt.Sprintf("от %d ноч.", r.MinNights))
+	}
+	if r.ClosedToArrival {
+		rules = append(rules, "заезд закрыт")
+	}
+	if r.ClosedToDeparture {
+		rules = append(rules, "выезд закрыт")
+	}
+	parts := []string{}
+	if r.Name != "" {
+		parts = append(parts, r.Name)
+	}
+	parts = append(parts, where,
+		r.StartDate.Format("02.01.2006")+" – "+r.EndDate.Format("02.01.2006"),
+		strings.Join(rules, ", "))
+	return strings.Join(parts, " · ")
+}
+
+// restrictionMarks — короткая пометка ограничений для ячейки календаря
+func restrictionMarks(d models.DayRestriction) string {
+	var marks []string
+	if d.MinNights > 1 {
+		marks = append(marks, fmt.Sprintf("≥%d", d.MinNights))
+	}
+	if d.ClosedToArrival {
+		marks = append(marks, "⊘→")
+	}
+	if d.ClosedToDeparture {
+		marks = append(marks, "→⊘")
+	}
+	return strings.Join(marks, " ")
+}
@@ -0,0 +1,340 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VallfIK/bazaotdx/internal/models"
+	"github.com/VallfIK/bazaotdx/internal/service"
+)
+
+// ShowTariffPricingDialog показывает цены тарифа: наценки на выходные
+// и праздники, доплату за гостей, сезонные цены и цены отдельных домиков
+func ShowTariffPricingDialog(tariffService *service.TariffService, cottages []models.Cottage, tariff models.Tariff, window fyne.Window) {
+	// Наценки могли измениться с тех пор, как загружен список тарифов
+	if fresh, err := tariffService.GetTariffByID(tariff.ID); err == nil {
+		tariff = *fresh
+	}
+
+	weekendEntry := widget.NewEntry()
+	weekendEntry.SetText(formatPercent(tariff.WeekendSurcharge))
+	holidayEntry := widget.NewEntry()
+	holidayEntry.SetText(formatPercent(tariff.HolidaySurcharge))
+
+	saveSurcharges := widget.NewButtonWithIcon("Сохранить наценки", theme.DocumentSaveIcon(), func() {
+		weekend, err1 := parsePercent(weekendEntry.Text)
+		holiday, err2 := parsePercent(holidayEntry.Text)
+		if err1 != nil || err2 != nil {
+			dialog.ShowError(fmt.Errorf("неверный формат наценки"), window)
+			return
+		}
+		if err := tariffService.SetSurcharges(tariff.ID, weekend, holiday); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		dialog.ShowInformation("Успешно", "Наценки сохранены", window)
+	})
+
+	surcharges := widget.NewForm(
+		widget.NewFormItem("Выходные (пт, сб), %", weekendEntry),
+		widget.NewFormItem("Праздники, %", holidayEntry),
+	)
+
+	baseGuestsEntry := widget.NewEntry()
+	baseGuestsEntry.PlaceHolder = "все"
+	if tariff.BaseGuests > 0 {
+		baseGuestsEntry.SetText(strconv.Itoa(tariff.BaseGuests))
+	}
+	extraAdultEntry := widget.NewEntry()
+	extraAdultEntry.SetText(tariff.ExtraAdultPrice.Decimal())
+	extraChildEntry := widget.NewEntry()
+	extraChildEntry.SetText(tariff.ExtraChildPrice.Decimal())
+
+	saveGuestFees := widget.NewButtonWithIcon("Сохранить доплату", theme.DocumentSaveIcon(), func() {
+		baseGuests := 0
+		if s := strings.TrimSpace(baseGuestsEntry.Text); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("неверное число гостей"), window)
+				return
+			}
+			baseGuests = n
+		}
+		// Пустое поле доплаты — ноль, как у наценок
+		extraAdult, err1 := models.ParseMoney(extraAdultEntry.Text)
+		extraChild, err2 := models.ParseMoney(extraChildEntry.Text)
+		if err1 != nil || err2 != nil {
+			dialog.ShowError(fmt.Errorf("неверный формат доплаты"), window)
+			return
+		}
+		if err := tariffService.SetGuestFees(tariff.ID, baseGuests, extraAdult, extraChild); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		dialog.ShowInformation("Успешно", "Доплата за гостей сохранена", window)
+	})
+
+	guestFees := widget.NewForm(
+		widget.NewFormItem("Гостей в цене", baseGuestsEntry),
+		widget.NewFormItem("Взрослый сверх, руб./ночь", extraAdultEntry),
+		widget.NewFormItem("Ребенок сверх, руб./ночь", extraChildEntry),
+	)
+
+	rateList := container.NewVBox()
+	var reload func()
+	reload = func() {
+		rateList.RemoveAll()
+		rates, err := tariffService.GetRates(tariff.ID)
+		if err != nil {
+			rateList.Add(widget.NewLabel(err.Error()))
+			return
+		}
+		if len(rates) == 0 {
+			rateList.Add(widget.NewLabel(fmt.Sprintf("Отдельных цен нет — действует %s/сутки", tariff.PricePerDay)))
+		}
+		for _, rate := range rates {
+			rate := rate
+			rateList.Add(container.NewBorder(nil, nil, nil,
+				container.NewHBox(
+					widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+						showRateForm(tariffService, cottages, rate, window, reload)
+					}),
+					widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+						dialog.ShowConfirm("Подтверждение", fmt.Sprintf("Удалить цену «%s»?", rateTitle(rate)), func(ok bool) {
+							if !ok {
+								return
+							}
+							if err := tariffService.DeleteRate(rate.ID); err != nil {
+								dialog.ShowError(err, window)
+								return
+							}
+							reload()
+						}, window)
+					}),
+				),
+				widget.NewLabel(formatRate(rate, cottages)),
+			))
+		}
+	}
+	reload()
+
+	addRate := widget.NewButtonWithIcon("Добавить цену", theme.ContentAddIcon(), func() {
+		showRateForm(tariffService, cottages, models.TariffRate{TariffID: tariff.ID}, window, reload)
+	})
+
+	content := container.NewBorder(
+		container.NewVBox(
+			widget.NewCard("Наценки", "Праздничная наценка заменяет выходную",
+				container.NewVBox(surcharges, saveSurcharges)),
+			widget.NewCard("Доплата за гостей", "Места в цене занимают сначала взрослые",
+				container.NewVBox(guestFees, saveGuestFees)),
+			newTariffPolicyCard(tariffService, tariff, window),
+			widget.NewLabel("Сезоны и цены домиков (действует самая точная)"),
+		),
+		addRate, nil, nil,
+		container.NewVScroll(rateList),
+	)
+
+	d := dialog.NewCustom(fmt.Sprintf("Цены тарифа «%s»", tariff.Name), "Закрыть", content, window)
+	d.Resize(fyne.NewSize(650, 850))
+	d.Show()
+}
+
+// showRateForm создает или изменяет цену тарифа
+func showRateForm(tariffService *service.TariffService, cottages []models.Cottage, rate models.TariffRate, window fyne.Window, onDone func()) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(rate.Name)
+	nameEntry.PlaceHolder = "Например: Лето"
+
+	cottageOptions := []string{"Все домики"}
+	for _, c := range cottages {
+		cottageOptions = append(cottageOptions, c.Name)
+	}
+	cottageSelect := widget.NewSelect(cottageOptions, nil)
+	cottageSelect.SetSelectedIndex(0)
+	for i, c := range cottages {
+		if c.ID == rate.CottageID {
+			cottageSelect.SetSelectedIndex(i + 1)
+		}
+	}
+
+	priceEntry := widget.NewEntry()
+	priceEntry.PlaceHolder = "Цена за сутки"
+	if rate.PricePerDay.IsPositive() {
+		priceEntry.SetText(rate.PricePerDay.Decimal())
+	}
+
+	startPicker := NewDatePickerButton("Начало", window, func(t time.Time) {
+		rate.StartDate = t
+	})
+	startPicker.SetSelectedDate(rate.StartDate)
+	endPicker := NewDatePickerButton("Конец", window, func(t time.Time) {
+		rate.EndDate = t
+	})
+	endPicker.SetSelectedDate(rate.EndDate)
+
+	seasonCheck := widget.NewCheck("Только в сезон", func(on bool) {
+		if on {
+			startPicker.button.Enable()
+			endPicker.button.Enable()
+		} else {
+			startPicker.button.Disable()
+			endPicker.button.Disable()
+		}
+	})
+	seasonCheck.SetChecked(rate.Seasonal() || rate.ID == 0)
+
+	items := []*widget.FormItem{
+		{Text: "Название", Widget: nameEntry},
+		{Text: "Домик", Widget: cottageSelect},
+		{Text: "", Widget: seasonCheck},
+		{Text: "Первая ночь", Widget: startPicker.button},
+		{Text: "Последняя ночь", Widget: endPicker.button},
+		{Text: "Цена за сутки *", Widget: priceEntry},
+	}
+
+	dialog.ShowForm("Цена тарифа", "Сохранить", "Отмена", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		price, err := models.ParseMoney(priceEntry.Text)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("неверный формат цены"), window)
+			return
+		}
+
+		rate.Name = nameEntry.Text
+		rate.PricePerDay = price
+		rate.CottageID = 0
+		if i := cottageSelect.SelectedIndex(); i > 0 {
+			rate.CottageID = cottages[i-1].ID
+		}
+		if !seasonCheck.Checked {
+			rate.StartDate, rate.EndDate = time.Time{}, time.Time{}
+		}
+
+		if _, err := tariffService.SaveRate(rate); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		onDone()
+	}, window)
+}
+
+// ShowHolidaysDialog показывает праздники года: ночи с них оплачиваются
+// с праздничной наценкой тарифа
+func ShowHolidaysDialog(tariffService *service.TariffService, window fyne.Window) {
+	year := time.Now().Year()
+	yearLabel := widget.NewLabel("")
+	list := container.NewVBox()
+
+	var reload func()
+	reload = func() {
+		yearLabel.SetText(fmt.Sprintf("%d год", year))
+		list.RemoveAll()
+		holidays, err := tariffService.GetHolidays(year)
+		if err != nil {
+			list.Add(widget.NewLabel(err.Error()))
+			return
+		}
+		if len(holidays) == 0 {
+			list.Add(widget.NewLabel("Праздники не отмечены"))
+		}
+		for _, h := range holidays {
+			h := h
+			list.Add(container.NewBorder(nil, nil, nil,
+				widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+					if err := tariffService.DeleteHoliday(h.ID); err != nil {
+						dialog.ShowError(err, window)
+						return
+					}
+					reload()
+				}),
+				widget.NewLabel(fmt.Sprintf("%s %s", h.Date.Format("02.01.2006"), h.Name)),
+			))
+		}
+	}
+	reload()
+
+	nav := container.NewHBox(
+		widget.NewButton("◀", func() { year--; reload() }),
+		yearLabel,
+		widget.NewButton("▶", func() { year++; reload() }),
+	)
+
+	var date time.Time
+	datePicker := NewDatePickerButton("Дата", window, func(t time.Time) {
+		date = t
+	})
+	nameEntry := widget.NewEntry()
+	nameEntry.PlaceHolder = "Например: Новый год"
+	addBtn := widget.NewButtonWithIcon("Добавить", theme.ContentAddIcon(), func() {
+		if date.IsZero() {
+			dialog.ShowError(fmt.Errorf("выберите дату"), window)
+			return
+		}
+		if _, err := tariffService.AddHoliday(date, nameEntry.Text); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		year = date.Year()
+		nameEntry.SetText("")
+		reload()
+	})
+
+	content := container.NewBorder(
+		nav,
+		container.NewVBox(widget.NewSeparator(), datePicker.button, nameEntry, addBtn),
+		nil, nil,
+		container.NewVScroll(list),
+	)
+
+	d := dialog.NewCustom("Праздники", "Закрыть", content, window)
+	d.Resize(fyne.NewSize(450, 550))
+	d.Show()
+}
+
+// rateTitle возвращает название цены или ее период
+func rateTitle(rate models.TariffRate) string {
+	if rate.Name != "" {
+		return rate.Name
+	}
+	if rate.Seasonal() {
+		return rate.StartDate.Format("02.01") + "–" + rate.EndDate.Format("02.01")
+	}
+	return "цена домика"
+}
+
+// formatRate описывает цену тарифа одной строкой
+func formatRate(rate models.TariffRate, cottages []models.Cottage) string {
+	where := "все домики"
+	if rate.CottageID != 0 {
+		where = cottageTitle(cottages, rate.CottageID)
+	}
+	when := "круглый год"
+	if rate.Seasonal() {
+		when = rate.StartDate.Format("02.01.2006") + " – " + rate.EndDate.Format("02.01.2006")
+	}
+	return fmt.Sprintf("%s · %s · %s · %s/сутки", rateTitle(rate), where, when, rate.PricePerDay)
+}
+
+// formatPercent печатает процент без лишних нулей
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// parsePercent читает процент; пустое поле — ноль
+func parsePercent(s string) (float64, error) {
+	s = strings.TrimSpace(strings.ReplaceAll(s, ",", "."))
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}